@@ -178,10 +178,17 @@ func createOrUpdateMongodbIndex(ctx context.Context) {
 		commonrepo.NewStatDashboardConfigColl(),
 		commonrepo.NewProjectManagementColl(),
 		commonrepo.NewImageTagsCollColl(),
+		commonrepo.NewImageSBOMColl(),
+		commonrepo.NewImageSigningKeyColl(),
+		commonrepo.NewTestCaseHistoryColl(),
+		commonrepo.NewTestCaseQuarantineColl(),
+		commonrepo.NewTestCoverageColl(),
 		commonrepo.NewLLMIntegrationColl(),
 		commonrepo.NewReleasePlanColl(),
 		commonrepo.NewReleasePlanLogColl(),
 		commonrepo.NewEnvServiceVersionColl(),
+		commonrepo.NewEnvSnapshotColl(),
+		commonrepo.NewEnvDriftColl(),
 		commonrepo.NewLabelColl(),
 		commonrepo.NewSprintTemplateColl(),
 		commonrepo.NewSprintColl(),
@@ -0,0 +1,121 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/koderover/zadig/v2/pkg/cli/zadig-agent/helper/log"
+	"github.com/koderover/zadig/v2/pkg/cli/zadig-agent/internal/common/types"
+	agentutil "github.com/koderover/zadig/v2/pkg/cli/zadig-agent/util/file"
+	"github.com/koderover/zadig/v2/pkg/tool/buildcache"
+	"github.com/koderover/zadig/v2/pkg/tool/s3"
+	"github.com/koderover/zadig/v2/pkg/types/step"
+	"github.com/koderover/zadig/v2/pkg/util"
+)
+
+type CacheRestoreStep struct {
+	spec       *step.StepCacheRestoreSpec
+	envs       []string
+	secretEnvs []string
+	workspace  string
+	logger     *log.JobLogger
+	dirs       *types.AgentWorkDirs
+}
+
+func NewCacheRestoreStep(spec interface{}, dirs *types.AgentWorkDirs, envs, secretEnvs []string, logger *log.JobLogger) (*CacheRestoreStep, error) {
+	cacheStep := &CacheRestoreStep{dirs: dirs, workspace: dirs.Workspace, envs: envs, secretEnvs: secretEnvs, logger: logger}
+	yamlBytes, err := yaml.Marshal(spec)
+	if err != nil {
+		return cacheStep, fmt.Errorf("marshal spec %+v failed", spec)
+	}
+	if err := yaml.Unmarshal(yamlBytes, &cacheStep.spec); err != nil {
+		return cacheStep, fmt.Errorf("unmarshal spec %s to cache restore spec failed", yamlBytes)
+	}
+	return cacheStep, nil
+}
+
+func (s *CacheRestoreStep) Run(ctx context.Context) error {
+	start := time.Now()
+	defer func() {
+		s.logger.Infof("Cache restore ended. Duration: %.2f seconds", time.Since(start).Seconds())
+	}()
+
+	if err := s.run(); err != nil {
+		if s.spec.IgnoreErr {
+			s.logger.Errorf("failed to restore cache, err: %s", err)
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *CacheRestoreStep) run() error {
+	envMap := util.MakeEnvMap(s.envs, s.secretEnvs)
+	key, err := buildcache.RenderKey(util.ReplaceEnvWithValue(s.spec.Key, envMap), s.workspace)
+	if err != nil {
+		return err
+	}
+	restoreKeys := make([]string, 0, len(s.spec.RestoreKeys))
+	for _, restoreKey := range s.spec.RestoreKeys {
+		restoreKey, err = buildcache.RenderKey(util.ReplaceEnvWithValue(restoreKey, envMap), s.workspace)
+		if err != nil {
+			return err
+		}
+		restoreKeys = append(restoreKeys, restoreKey)
+	}
+	s.logger.Infof("Start restore cache %s.", key)
+
+	client, err := s3.NewClient(s.spec.S3.Endpoint, s.spec.S3.Ak, s.spec.S3.Sk, s.spec.S3.Region, s.spec.S3.Insecure, s.spec.S3.Provider)
+	if err != nil {
+		return fmt.Errorf("failed to create s3 client to restore cache, err: %s", err)
+	}
+	prefix := buildcache.ProjectPrefix(s.spec.S3.Subfolder, s.spec.ProjectName)
+	objectKey, exactMatch, err := buildcache.Lookup(client, s.spec.S3.Bucket, prefix, key, restoreKeys)
+	if err != nil {
+		return fmt.Errorf("failed to find cache %s, err: %s", key, err)
+	}
+	if objectKey == "" {
+		s.logger.Infof("Cache %s not found.", key)
+		return nil
+	}
+	if !exactMatch {
+		s.logger.Infof("Cache %s not found, restore from %s.", key, objectKey)
+	}
+
+	sourceFilename, err := agentutil.GenerateTmpFile()
+	if err != nil {
+		return fmt.Errorf("failed to GenerateTmpFile, err: %s", err)
+	}
+	defer func() {
+		_ = os.Remove(sourceFilename)
+	}()
+	if err := client.Download(s.spec.S3.Bucket, objectKey, sourceFilename); err != nil {
+		return fmt.Errorf("failed to download cache from s3, bucketName: %s, objectKey: %s, err: %s", s.spec.S3.Bucket, objectKey, err)
+	}
+	if err := buildcache.Extract(s.workspace, sourceFilename); err != nil {
+		return err
+	}
+	s.logger.Infof("Cache restored from %s.", objectKey)
+	return nil
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/koderover/zadig/v2/pkg/cli/zadig-agent/helper/log"
+	"github.com/koderover/zadig/v2/pkg/cli/zadig-agent/internal/common/types"
+	agentutil "github.com/koderover/zadig/v2/pkg/cli/zadig-agent/util/file"
+	"github.com/koderover/zadig/v2/pkg/tool/buildcache"
+	"github.com/koderover/zadig/v2/pkg/tool/s3"
+	"github.com/koderover/zadig/v2/pkg/types/step"
+	"github.com/koderover/zadig/v2/pkg/util"
+)
+
+type CacheSaveStep struct {
+	spec       *step.StepCacheSaveSpec
+	envs       []string
+	secretEnvs []string
+	workspace  string
+	logger     *log.JobLogger
+	dirs       *types.AgentWorkDirs
+}
+
+func NewCacheSaveStep(spec interface{}, dirs *types.AgentWorkDirs, envs, secretEnvs []string, logger *log.JobLogger) (*CacheSaveStep, error) {
+	cacheStep := &CacheSaveStep{dirs: dirs, workspace: dirs.Workspace, envs: envs, secretEnvs: secretEnvs, logger: logger}
+	yamlBytes, err := yaml.Marshal(spec)
+	if err != nil {
+		return cacheStep, fmt.Errorf("marshal spec %+v failed", spec)
+	}
+	if err := yaml.Unmarshal(yamlBytes, &cacheStep.spec); err != nil {
+		return cacheStep, fmt.Errorf("unmarshal spec %s to cache save spec failed", yamlBytes)
+	}
+	return cacheStep, nil
+}
+
+func (s *CacheSaveStep) Run(ctx context.Context) error {
+	start := time.Now()
+	defer func() {
+		s.logger.Infof("Cache save ended. Duration: %.2f seconds", time.Since(start).Seconds())
+	}()
+
+	if err := s.run(); err != nil {
+		if s.spec.IgnoreErr {
+			s.logger.Errorf("failed to save cache, err: %s", err)
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *CacheSaveStep) run() error {
+	envMap := util.MakeEnvMap(s.envs, s.secretEnvs)
+	key, err := buildcache.RenderKey(util.ReplaceEnvWithValue(s.spec.Key, envMap), s.workspace)
+	if err != nil {
+		return err
+	}
+	s.logger.Infof("Start save cache %s.", key)
+
+	client, err := s3.NewClient(s.spec.S3.Endpoint, s.spec.S3.Ak, s.spec.S3.Sk, s.spec.S3.Region, s.spec.S3.Insecure, s.spec.S3.Provider)
+	if err != nil {
+		return fmt.Errorf("failed to create s3 client to save cache, err: %s", err)
+	}
+	prefix := buildcache.ProjectPrefix(s.spec.S3.Subfolder, s.spec.ProjectName)
+	_, exactMatch, err := buildcache.Lookup(client, s.spec.S3.Bucket, prefix, key, nil)
+	if err != nil {
+		return fmt.Errorf("failed to find cache %s, err: %s", key, err)
+	}
+	if exactMatch {
+		s.logger.Infof("Cache %s already exists, skip saving.", key)
+		return nil
+	}
+
+	paths := make([]string, 0, len(s.spec.Paths))
+	for _, p := range s.spec.Paths {
+		paths = append(paths, util.ReplaceEnvWithValue(p, envMap))
+	}
+	tarName, err := agentutil.GenerateTmpFile()
+	if err != nil {
+		return fmt.Errorf("failed to GenerateTmpFile, err: %s", err)
+	}
+	defer func() {
+		_ = os.Remove(tarName)
+	}()
+	archived, err := buildcache.Archive(s.workspace, tarName, paths)
+	if err != nil {
+		return err
+	}
+	if !archived {
+		s.logger.Warnf("None of the cache paths %v exists, skip saving.", paths)
+		return nil
+	}
+
+	objectKey := buildcache.ObjectKey(s.spec.S3.Subfolder, s.spec.ProjectName, key)
+	if err := client.Upload(s.spec.S3.Bucket, tarName, objectKey); err != nil {
+		return fmt.Errorf("failed to upload cache to s3, bucketName: %s, objectKey: %s, err: %s", s.spec.S3.Bucket, objectKey, err)
+	}
+	s.logger.Infof("Cache saved to %s.", objectKey)
+	return nil
+}
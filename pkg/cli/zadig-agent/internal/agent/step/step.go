@@ -22,6 +22,7 @@ import (
 
 	"github.com/koderover/zadig/v2/pkg/cli/zadig-agent/helper/log"
 	"github.com/koderover/zadig/v2/pkg/cli/zadig-agent/internal/agent/step/archive"
+	"github.com/koderover/zadig/v2/pkg/cli/zadig-agent/internal/agent/step/cache"
 	"github.com/koderover/zadig/v2/pkg/cli/zadig-agent/internal/agent/step/docker"
 	"github.com/koderover/zadig/v2/pkg/cli/zadig-agent/internal/agent/step/git"
 	"github.com/koderover/zadig/v2/pkg/cli/zadig-agent/internal/agent/step/perforce"
@@ -99,11 +100,26 @@ func RunStep(ctx context.Context, jobCtx *jobctl.JobContext, step *commonmodels.
 		if err != nil {
 			return err
 		}
+	case "cache_restore":
+		stepInstance, err = cache.NewCacheRestoreStep(step.Spec, dirs, envs, secretEnvs, logger)
+		if err != nil {
+			return err
+		}
+	case "cache_save":
+		stepInstance, err = cache.NewCacheSaveStep(step.Spec, dirs, envs, secretEnvs, logger)
+		if err != nil {
+			return err
+		}
 	case "junit_report":
 		stepInstance, err = testing.NewJunitReportStep(step.Spec, dirs, envs, secretEnvs, logger)
 		if err != nil {
 			return err
 		}
+	case "coverage_report":
+		stepInstance, err = testing.NewCoverageReportStep(step.Spec, dirs, envs, secretEnvs, logger)
+		if err != nil {
+			return err
+		}
 	case "sonar_check":
 		stepInstance, err = scanning.NewSonarCheckStep(step.Spec, dirs, envs, secretEnvs, logger)
 		if err != nil {
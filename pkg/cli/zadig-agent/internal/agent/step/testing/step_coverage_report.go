@@ -0,0 +1,119 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/koderover/zadig/v2/pkg/cli/zadig-agent/helper/log"
+	"github.com/koderover/zadig/v2/pkg/cli/zadig-agent/internal/common/types"
+	"github.com/koderover/zadig/v2/pkg/tool/coverage"
+	"github.com/koderover/zadig/v2/pkg/tool/s3"
+	"github.com/koderover/zadig/v2/pkg/types/step"
+	"github.com/koderover/zadig/v2/pkg/util"
+)
+
+type CoverageReportStep struct {
+	spec       *step.StepCoverageReportSpec
+	envs       []string
+	secretEnvs []string
+	workspace  string
+	dirs       *types.AgentWorkDirs
+	Logger     *log.JobLogger
+}
+
+func NewCoverageReportStep(spec interface{}, dirs *types.AgentWorkDirs, envs, secretEnvs []string, logger *log.JobLogger) (*CoverageReportStep, error) {
+	coverageReportStep := &CoverageReportStep{dirs: dirs, workspace: dirs.Workspace, envs: envs, secretEnvs: secretEnvs}
+	yamlBytes, err := yaml.Marshal(spec)
+	if err != nil {
+		return coverageReportStep, fmt.Errorf("marshal spec %+v failed", spec)
+	}
+	if err := yaml.Unmarshal(yamlBytes, &coverageReportStep.spec); err != nil {
+		return coverageReportStep, fmt.Errorf("unmarshal spec %s to coverage report spec failed", yamlBytes)
+	}
+	coverageReportStep.Logger = logger
+	return coverageReportStep, nil
+}
+
+func (s *CoverageReportStep) Run(ctx context.Context) error {
+	s.Logger.Infof("Start parsing %s coverage report.", s.spec.Format)
+	envMap := util.MakeEnvMap(s.envs, s.secretEnvs)
+	reportPath := util.ReplaceEnvWithValue(s.spec.ReportPath, envMap)
+	if !filepath.IsAbs(reportPath) {
+		reportPath = filepath.Join(s.workspace, reportPath)
+	}
+
+	data, err := os.ReadFile(reportPath)
+	if err != nil {
+		return fmt.Errorf("failed to read coverage report %s: %s", reportPath, err)
+	}
+	report, err := coverage.Parse(s.spec.Format, data)
+	if err != nil {
+		return err
+	}
+	s.Logger.Infof("Total coverage: %.2f%% (%d/%d), %d file(s).", report.Rate(), report.Covered, report.Total, len(report.Files))
+	if s.spec.BaseCoverage != nil {
+		s.Logger.Infof("Coverage of the target branch %s: %.2f%%, delta: %+.2f%%.", s.spec.Branch, *s.spec.BaseCoverage, coverage.Delta(report.Rate(), *s.spec.BaseCoverage))
+	}
+
+	// the parsed report is archived even if the coverage gate fails
+	if err := s.upload(report); err != nil {
+		return err
+	}
+
+	return coverage.CheckGate(report.Rate(), s.spec.Threshold, s.spec.MaxDrop, s.spec.BaseCoverage)
+}
+
+func (s *CoverageReportStep) upload(report *coverage.Report) error {
+	if s.spec.S3DestDir == "" || s.spec.FileName == "" || s.spec.S3Storage == nil {
+		return nil
+	}
+
+	content, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("failed to marshal coverage report: %s", err)
+	}
+	tmpFile, err := os.CreateTemp("", "coverage-*.json")
+	if err != nil {
+		return fmt.Errorf("failed to create coverage report file: %s", err)
+	}
+	defer os.Remove(tmpFile.Name())
+	if _, err := tmpFile.Write(content); err != nil {
+		tmpFile.Close()
+		return fmt.Errorf("failed to write coverage report file: %s", err)
+	}
+	tmpFile.Close()
+
+	client, err := s3.NewClient(s.spec.S3Storage.Endpoint, s.spec.S3Storage.Ak, s.spec.S3Storage.Sk, s.spec.S3Storage.Region, s.spec.S3Storage.Insecure, s.spec.S3Storage.Provider)
+	if err != nil {
+		return fmt.Errorf("failed to create s3 client to upload coverage report, err: %s", err)
+	}
+	objectKey := strings.TrimLeft(path.Join(s.spec.S3Storage.Subfolder, s.spec.S3DestDir, s.spec.FileName), "/")
+	if err := client.Upload(s.spec.S3Storage.Bucket, tmpFile.Name(), objectKey); err != nil {
+		return fmt.Errorf("failed to upload coverage report: %s", err)
+	}
+	s.Logger.Infof("Finish archive coverage report to %s.", objectKey)
+	return nil
+}
@@ -34,6 +34,7 @@ import (
 	"github.com/koderover/zadig/v2/pkg/cli/zadig-agent/internal/common/types"
 	"github.com/koderover/zadig/v2/pkg/microservice/reaper/core/service/meta"
 	"github.com/koderover/zadig/v2/pkg/tool/s3"
+	"github.com/koderover/zadig/v2/pkg/tool/testreport"
 	"github.com/koderover/zadig/v2/pkg/types/step"
 	"github.com/koderover/zadig/v2/pkg/util"
 )
@@ -75,7 +76,7 @@ func (s *JunitReportStep) Run(ctx context.Context) error {
 	s.spec.ReportDir = util.ReplaceEnvWithValue(s.spec.ReportDir, envMap)
 
 	reportDir := filepath.Join(s.workspace, s.spec.ReportDir)
-	results, err := mergeGinkgoTestResults(s.spec.FileName, reportDir, s.spec.DestDir, time.Now(), s.Logger)
+	results, err := mergeGinkgoTestResults(s.spec.Format, s.spec.FileName, reportDir, s.spec.DestDir, time.Now(), s.Logger)
 	if err != nil {
 		return fmt.Errorf("failed to merge test result: %s", err)
 	}
@@ -114,13 +115,17 @@ func (s *JunitReportStep) Run(ctx context.Context) error {
 		}
 	}
 	s.Logger.Infof("Finish archive %s.", s.spec.FileName)
-	if results.Failures > 0 || results.Errors > 0 {
-		return fmt.Errorf("%d case(s) failed, %d case(s) error", results.Failures, results.Errors)
+	quarantinedFailures, quarantinedErrors := results.CountQuarantined(s.spec.IsQuarantined)
+	if quarantinedFailures > 0 || quarantinedErrors > 0 {
+		s.Logger.Infof("%d quarantined case(s) failed, %d quarantined case(s) error, ignored.", quarantinedFailures, quarantinedErrors)
+	}
+	if failures, errors := results.Failures-quarantinedFailures, results.Errors-quarantinedErrors; failures > 0 || errors > 0 {
+		return fmt.Errorf("%d case(s) failed, %d case(s) error", failures, errors)
 	}
 	return nil
 }
 
-func mergeGinkgoTestResults(testResultFile, testResultPath, testUploadPath string, startTime time.Time, logger *log.JobLogger) (*meta.TestSuite, error) {
+func mergeGinkgoTestResults(format, testResultFile, testResultPath, testUploadPath string, startTime time.Time, logger *log.JobLogger) (*meta.TestSuite, error) {
 	var (
 		err           error
 		newXMLBytes   []byte
@@ -142,7 +147,21 @@ func mergeGinkgoTestResults(testResultFile, testResultPath, testUploadPath strin
 	sort.SliceStable(files, func(i, j int) bool {
 		return files[i].ModTime().Before(files[j].ModTime())
 	})
+	// junit reports are merged as they are, other formats are normalized by the parser
+	var parser testreport.Parser
+	if format != "" && format != testreport.FormatJUnit {
+		parser, err = testreport.GetParser(format)
+		if err != nil {
+			return summaryResult, err
+		}
+	}
 	for _, file := range files {
+		if parser != nil {
+			if !file.IsDir() && parser.Match(file.Name()) {
+				mergeParsedTestResult(summaryResult, parser, path.Join(testResultPath, file.Name()), logger)
+			}
+			continue
+		}
 		if filepath.Ext(file.Name()) == ".xml" {
 			filePath := filepath.Join(testResultPath, file.Name())
 			logger.Infof("name %s mod time: %v", file.Name(), file.ModTime())
@@ -226,6 +245,26 @@ func mergeGinkgoTestResults(testResultFile, testResultPath, testUploadPath strin
 	return summaryResult, nil
 }
 
+func mergeParsedTestResult(summaryResult *meta.TestSuite, parser testreport.Parser, filePath string, logger *log.JobLogger) {
+	logger.Infof("parse file %s", filePath)
+	data, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		logger.Warnf("Read file [%s], error: %v", filePath, err)
+		return
+	}
+	result, err := parser.Parse(filePath, data)
+	if err != nil {
+		logger.Warnf("Parse file [%s], error: %v", filePath, err)
+		return
+	}
+	summaryResult.Tests += result.Tests
+	summaryResult.Failures += result.Failures
+	summaryResult.Errors += result.Errors
+	summaryResult.Skips += result.Skips
+	summaryResult.TestCases = append(summaryResult.TestCases, result.TestCases...)
+	summaryResult.SuiteType = ReploaceTestSuites
+}
+
 func getSecondSince(startTime time.Time) float64 {
 	return float64(time.Since(startTime).Round(time.Millisecond).Nanoseconds()) / float64(time.Second)
 }
@@ -207,8 +207,14 @@ const (
 	StepSonarCheck        StepType = "sonar_check"
 	StepSonarGetMetrics   StepType = "sonar_get_metrics"
 	StepDistributeImage   StepType = "distribute_image"
+	StepImageScan         StepType = "image_scan"
+	StepImageSign         StepType = "image_sign"
+	StepSBOM              StepType = "sbom"
+	StepCoverageReport    StepType = "coverage_report"
 	StepDebugBefore       StepType = "debug_before"
 	StepDebugAfter        StepType = "debug_after"
+	StepCacheRestore      StepType = "cache_restore"
+	StepCacheSave         StepType = "cache_save"
 )
 
 type JobType string
@@ -252,10 +258,13 @@ const (
 	JobMseGrayOffline       JobType = "mse-gray-offline"
 	JobGuanceyunCheck       JobType = "guanceyun-check"
 	JobGrafana              JobType = "grafana"
+	JobMetricCheck          JobType = "metric-check"
+	JobImageScan            JobType = "image-scan"
 	JobBlueKing             JobType = "blueking"
 	JobApproval             JobType = "approval"
 	JobNotification         JobType = "notification"
 	JobSAEDeploy            JobType = "sae-deploy"
+	JobCallWorkflow         JobType = "call-workflow"
 )
 
 const (
@@ -267,15 +276,39 @@ const (
 type DBInstanceType string
 
 const (
-	DBInstanceTypeMySQL   DBInstanceType = "mysql"
-	DBInstanceTypeMariaDB DBInstanceType = "mariadb"
+	DBInstanceTypeMySQL      DBInstanceType = "mysql"
+	DBInstanceTypeMariaDB    DBInstanceType = "mariadb"
+	DBInstanceTypePostgreSQL DBInstanceType = "postgresql"
 )
 
 type ObservabilityType string
 
 const (
-	ObservabilityTypeGrafana   ObservabilityType = "grafana"
-	ObservabilityTypeGuanceyun ObservabilityType = "guanceyun"
+	ObservabilityTypeGrafana    ObservabilityType = "grafana"
+	ObservabilityTypeGuanceyun  ObservabilityType = "guanceyun"
+	ObservabilityTypePrometheus ObservabilityType = "prometheus"
+)
+
+type IstioHealthCheckType string
+
+const (
+	// IstioHealthCheckPodReady requires all pods of the new version to be ready
+	IstioHealthCheckPodReady IstioHealthCheckType = "pod_ready"
+	// IstioHealthCheckRestartCount limits the container restarts of the new version pods
+	IstioHealthCheckRestartCount IstioHealthCheckType = "restart_count"
+	// IstioHealthCheckMetric runs a prometheus metric check
+	IstioHealthCheckMetric IstioHealthCheckType = "metric"
+)
+
+type MetricCheckMode string
+
+const (
+	// MetricCheckModeAbsolute compares the query result with the threshold directly
+	MetricCheckModeAbsolute MetricCheckMode = "absolute"
+	// MetricCheckModeBaseline compares the query result with the same query evaluated at the task start time
+	MetricCheckModeBaseline MetricCheckMode = "baseline"
+	// MetricCheckModeCanary compares the query result with the result of the baseline query, e.g. the stable version
+	MetricCheckModeCanary MetricCheckMode = "canary"
 )
 
 type ApprovalType string
@@ -347,6 +380,25 @@ const (
 	EnvOperationTypeSaeChangeOrder EnvOperationType = "sae_change_order"
 )
 
+type EnvSnapshotSource string
+
+const (
+	EnvSnapshotSourceManual   EnvSnapshotSource = "manual"
+	EnvSnapshotSourceWorkflow EnvSnapshotSource = "workflow"
+	// EnvSnapshotSourceRestore is the snapshot taken automatically before restoring the env to another snapshot
+	EnvSnapshotSourceRestore EnvSnapshotSource = "restore"
+)
+
+type EnvDriftStatus string
+
+const (
+	EnvDriftStatusOpen EnvDriftStatus = "open"
+	// EnvDriftStatusResolved means the drift disappeared by itself, e.g. reverted by a deployment
+	EnvDriftStatusResolved   EnvDriftStatus = "resolved"
+	EnvDriftStatusReconciled EnvDriftStatus = "reconciled"
+	EnvDriftStatusAdopted    EnvDriftStatus = "adopted"
+)
+
 type ServiceType string
 
 const (
@@ -553,6 +605,7 @@ const (
 	TestJobHTMLReportArchiveStepName = "html-report-archive-step"
 	TestJobArchiveResultStepName     = "archive-result-step"
 	TestJobObjectStorageStepName     = "object-storage-step"
+	TestJobCoverageReportStepName    = "coverage-report-step"
 )
 
 const (
@@ -31,6 +31,8 @@ type DBInstance struct {
 	Port      string                `bson:"port"                  json:"port"`
 	Username  string                `bson:"username"              json:"username"`
 	Password  string                `bson:"password"              json:"password,omitempty"`
+	Database  string                `bson:"database,omitempty"    json:"database,omitempty"`
+	SSLMode   string                `bson:"ssl_mode,omitempty"    json:"ssl_mode,omitempty"` // only used by PostgreSQL, disable by default
 	UpdateBy  string                `bson:"update_by"             json:"update_by"`
 	CreatedAt int64                 `bson:"created_at"            json:"created_at"`
 	UpdatedAt int64                 `bson:"updated_at"            json:"updated_at"`
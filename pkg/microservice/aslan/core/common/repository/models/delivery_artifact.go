@@ -18,6 +18,8 @@ package models
 
 import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/koderover/zadig/v2/pkg/tool/trivy"
 )
 
 type DeliveryArtifact struct {
@@ -38,6 +40,14 @@ type DeliveryArtifact struct {
 	PackageStorageURI   string             `bson:"package_storage_uri,omitempty"   json:"package_storage_uri,omitempty"`
 	CreatedBy           string             `bson:"created_by"                      json:"created_by"`
 	CreatedTime         int64              `bson:"created_time"                    json:"created_time"`
+
+	// VulnerabilitySummary is the result of the latest image scan job on this image
+	VulnerabilitySummary *trivy.Summary `bson:"vulnerability_summary,omitempty" json:"vulnerability_summary,omitempty"`
+	ScanTime             int64          `bson:"scan_time,omitempty"             json:"scan_time,omitempty"`
+
+	// SBOMID refers to the sbom generated for this image by the build job
+	SBOMID     string `bson:"sbom_id,omitempty"     json:"sbom_id,omitempty"`
+	SBOMFormat string `bson:"sbom_format,omitempty" json:"sbom_format,omitempty"`
 }
 
 type Descriptor struct {
@@ -0,0 +1,53 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+)
+
+// EnvDrift records the differences between a resource running in the env namespace and the manifest rendered by Zadig
+type EnvDrift struct {
+	ID          primitive.ObjectID    `bson:"_id,omitempty"          json:"id,omitempty"`
+	ProductName string                `bson:"product_name"           json:"product_name"`
+	EnvName     string                `bson:"env_name"               json:"env_name"`
+	Production  bool                  `bson:"production"             json:"production"`
+	Namespace   string                `bson:"namespace"              json:"namespace"`
+	ServiceName string                `bson:"service_name"           json:"service_name"`
+	Kind        string                `bson:"kind"                   json:"kind"`
+	Name        string                `bson:"name"                   json:"name"`
+	Diffs       []*EnvDriftFieldDiff  `bson:"diffs"                  json:"diffs"`
+	Status      config.EnvDriftStatus `bson:"status"                 json:"status"`
+	// DetectTime is the time the drift is first found, UpdateTime is the last time it is found
+	DetectTime  int64  `bson:"detect_time"            json:"detect_time"`
+	UpdateTime  int64  `bson:"update_time"            json:"update_time"`
+	ResolveBy   string `bson:"resolve_by,omitempty"   json:"resolve_by,omitempty"`
+	ResolveTime int64  `bson:"resolve_time,omitempty" json:"resolve_time,omitempty"`
+}
+
+type EnvDriftFieldDiff struct {
+	// Path of the field, like spec.template.spec.containers[0].image
+	Path     string `bson:"path"     json:"path"`
+	Expected string `bson:"expected" json:"expected"`
+	Actual   string `bson:"actual"   json:"actual"`
+}
+
+func (EnvDrift) TableName() string {
+	return "env_drift"
+}
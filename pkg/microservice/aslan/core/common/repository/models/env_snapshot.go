@@ -0,0 +1,55 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	templatemodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models/template"
+	commontypes "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/types"
+)
+
+// EnvSnapshot records the state of all the services and env-level configurations of an environment at a point in time
+type EnvSnapshot struct {
+	ID           primitive.ObjectID       `bson:"_id,omitempty"             json:"id,omitempty"`
+	Name         string                   `bson:"name"                      json:"name"`
+	ProductName  string                   `bson:"product_name"              json:"product_name"`
+	EnvName      string                   `bson:"env_name"                  json:"env_name"`
+	Production   bool                     `bson:"production"                json:"production"`
+	Source       config.EnvSnapshotSource `bson:"source"                    json:"source"`
+	WorkflowName string                   `bson:"workflow_name,omitempty"   json:"workflow_name,omitempty"`
+	TaskID       int64                    `bson:"task_id,omitempty"         json:"task_id,omitempty"`
+	Services     []*ProductService        `bson:"services"                  json:"services"`
+	// GlobalValues for helm projects
+	DefaultValues string                     `bson:"default_values,omitempty"       json:"default_values,omitempty"`
+	YamlData      *templatemodels.CustomYaml `bson:"yaml_data,omitempty"            json:"yaml_data,omitempty"`
+	// GlobalValues for k8s projects
+	GlobalVariables []*commontypes.GlobalVariableKV `bson:"global_variables,omitempty"     json:"global_variables,omitempty"`
+	ConfigMaps      []*EnvSnapshotConfigMap         `bson:"config_maps,omitempty"          json:"config_maps,omitempty"`
+	CreateBy        string                          `bson:"create_by"                 json:"create_by"`
+	CreateTime      int64                           `bson:"create_time"               json:"create_time"`
+}
+
+type EnvSnapshotConfigMap struct {
+	Name     string `bson:"name"      json:"name"`
+	YamlData string `bson:"yaml_data" json:"yaml_data"`
+}
+
+func (EnvSnapshot) TableName() string {
+	return "env_snapshot"
+}
@@ -28,6 +28,7 @@ type HelmRepo struct {
 	Password    string             `bson:"password"              json:"password"`
 	Projects    []string           `bson:"projects"              json:"projects"`
 	EnableProxy bool               `bson:"enable_proxy"          json:"enable_proxy"`
+	RegistryID  string             `bson:"registry_id,omitempty" json:"registry_id,omitempty"`
 	UpdateBy    string             `bson:"update_by"             json:"update_by"`
 	CreatedAt   int64              `bson:"created_at"            json:"created_at"`
 	UpdatedAt   int64              `bson:"updated_at"            json:"updated_at"`
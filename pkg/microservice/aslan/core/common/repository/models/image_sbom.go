@@ -0,0 +1,44 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/koderover/zadig/v2/pkg/tool/sbom"
+)
+
+// ImageSBOM is the software bill of materials generated for an image built by a build job
+type ImageSBOM struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty"  json:"id"`
+	Image         string             `bson:"image"          json:"image"`
+	ServiceName   string             `bson:"service_name"   json:"service_name"`
+	ServiceModule string             `bson:"service_module" json:"service_module"`
+	ProjectName   string             `bson:"project_name"   json:"project_name"`
+	WorkflowName  string             `bson:"workflow_name"  json:"workflow_name"`
+	TaskID        int64              `bson:"task_id"        json:"task_id"`
+	Format        string             `bson:"format"         json:"format"`
+	// the sbom file is archived to ObjectKey of the object storage
+	ObjectStorageID string          `bson:"object_storage_id" json:"object_storage_id"`
+	ObjectKey       string          `bson:"object_key"        json:"object_key"`
+	Packages        []*sbom.Package `bson:"packages"          json:"packages,omitempty"`
+	CreatedTime     int64           `bson:"created_time"      json:"created_time"`
+}
+
+func (ImageSBOM) TableName() string {
+	return "image_sbom"
+}
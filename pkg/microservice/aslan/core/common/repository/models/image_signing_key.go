@@ -0,0 +1,38 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ImageSigningKey is a cosign key pair used to sign built images and verify them before deploying
+type ImageSigningKey struct {
+	ID          primitive.ObjectID `json:"id"          bson:"_id,omitempty"`
+	Name        string             `json:"name"        bson:"name"`
+	Description string             `json:"description" bson:"description"`
+	// PrivateKey is the PEM encoded cosign private key, it is encrypted by Password
+	PrivateKey string `json:"private_key" bson:"private_key"`
+	Password   string `json:"password"    bson:"password"`
+	PublicKey  string `json:"public_key"  bson:"public_key"`
+	UpdateBy   string `json:"update_by"   bson:"update_by"`
+	UpdateTime int64  `json:"update_time" bson:"update_time"`
+}
+
+func (ImageSigningKey) TableName() string {
+	return "image_signing_key"
+}
@@ -67,10 +67,25 @@ type NotificationTask struct {
 	ID                  int64             `bson:"id"                      json:"id"`
 	Status              config.TaskStatus `bson:"status"                  json:"status"`
 	TestReports         []*TestSuite      `bson:"test_reports,omitempty"  json:"test_reports,omitempty"`
+	// Coverages is the code coverage collected by the testing jobs of workflow v4 tasks
+	Coverages []*NotificationCoverage `bson:"coverages,omitempty" json:"coverages,omitempty"`
 
 	FirstCommented bool `json:"first_commented,omitempty" bson:"first_commented,omitempty"`
 }
 
+type NotificationCoverage struct {
+	Name     string   `bson:"name"            json:"name"`
+	Coverage float64  `bson:"coverage"        json:"coverage"`
+	Delta    *float64 `bson:"delta,omitempty" json:"delta,omitempty"`
+}
+
+func (c NotificationCoverage) Verbose() string {
+	if c.Delta == nil {
+		return fmt.Sprintf("%.2f%%", c.Coverage)
+	}
+	return fmt.Sprintf("%.2f%% (%+.2f%%)", c.Coverage, *c.Delta)
+}
+
 func (t NotificationTask) StatusVerbose() string {
 	switch t.Status {
 	case config.TaskStatusReady:
@@ -102,11 +117,14 @@ func (n *Notification) ToString() string {
 
 func (n *Notification) CreateCommentBody() (comment string, err error) {
 	hasTest := false
+	hasCoverage := false
 	for _, task := range n.Tasks {
 		task.EncodedDisplayName = url.QueryEscape(task.WorkflowDisplayName)
+		if len(task.Coverages) != 0 {
+			hasCoverage = true
+		}
 		if len(task.TestReports) != 0 {
 			hasTest = true
-			break
 		}
 	}
 
@@ -142,6 +160,9 @@ func (n *Notification) CreateCommentBody() (comment string, err error) {
 	} else if n.IsWorkflowV4 {
 		if len(n.Tasks) == 0 {
 			tmplSource = "触发的工作流：等待任务启动中"
+		} else if hasCoverage {
+			tmplSource =
+				"|触发的工作流|状态|代码覆盖率（较目标分支变化）| \n |---|---|---| \n {{range .Tasks}}|[{{.WorkflowDisplayName}}#{{.ID}}]({{$.BaseURI}}/v1/projects/detail/{{.ProductName}}/pipelines/custom/{{.WorkflowName}}/{{.ID}}?display_name={{.EncodedDisplayName}}) | {{if eq .StatusVerbose $.Success}} {+ {{.StatusVerbose}} +}{{else}}{- {{.StatusVerbose}} -}{{end}} | {{range .Coverages}}{{.Name}}: {{.Verbose}} <br> {{end}} | \n {{end}}"
 		} else {
 			tmplSource =
 				"|触发的工作流|状态| \n |---|---| \n {{range .Tasks}}|[{{.WorkflowDisplayName}}#{{.ID}}]({{$.BaseURI}}/v1/projects/detail/{{.ProductName}}/pipelines/custom/{{.WorkflowName}}/{{.ID}}?display_name={{.EncodedDisplayName}}) | {{if eq .StatusVerbose $.Success}} {+ {{.StatusVerbose}} +}{{else}}{- {{.StatusVerbose}} -}{{end}} | \n {{end}}"
@@ -33,7 +33,9 @@ type Observability struct {
 	ApiKey string `json:"api_key" bson:"api_key" yaml:"api_key"`
 
 	GrafanaToken string `json:"grafana_token" bson:"grafana_token" yaml:"grafana_token"`
-	UpdateTime   int64  `json:"update_time" bson:"update_time" yaml:"update_time"`
+	// PrometheusToken is an optional bearer token for prometheus compatible endpoints
+	PrometheusToken string `json:"prometheus_token" bson:"prometheus_token" yaml:"prometheus_token"`
+	UpdateTime      int64  `json:"update_time" bson:"update_time" yaml:"update_time"`
 }
 
 func (Observability) TableName() string {
@@ -88,6 +88,45 @@ type Product struct {
 	// For production environment
 	Production bool   `json:"production" bson:"production"`
 	Alias      string `json:"alias" bson:"alias"`
+
+	// SignaturePolicy refuses to deploy images not signed by a trusted key
+	SignaturePolicy *ImageSignaturePolicy `bson:"signature_policy,omitempty" json:"signature_policy,omitempty"`
+
+	// DriftDetection configures the periodic scan of the resources modified outside Zadig
+	DriftDetection *EnvDriftDetection `bson:"drift_detection,omitempty" json:"drift_detection,omitempty"`
+
+	// TTL deletes the ephemeral environment automatically once it expires
+	TTL *EnvTTL `bson:"ttl,omitempty" json:"ttl,omitempty"`
+}
+
+type ImageSignaturePolicy struct {
+	Enabled bool `bson:"enabled"         json:"enabled"`
+	// TrustedKeyIDs are the ids of the image signing keys whose signatures are trusted
+	TrustedKeyIDs []string `bson:"trusted_key_ids" json:"trusted_key_ids"`
+}
+
+type EnvDriftDetection struct {
+	// Disabled stops scanning the env, envs are scanned by default
+	Disabled bool `bson:"disabled"  json:"disabled"`
+	Notify   bool `bson:"notify"    json:"notify"`
+	// Receivers are the users notified of new drifts, the last user updating the env is notified if empty
+	Receivers []string `bson:"receivers" json:"receivers"`
+}
+
+type EnvTTL struct {
+	// ExpireTime is the unix time the env is deleted at, 0 means the env never expires
+	ExpireTime int64 `bson:"expire_time"  json:"expire_time"`
+	// IdleTimeout is the hours the env is kept without being updated, 0 means the env is never deleted for idleness
+	IdleTimeout int64 `bson:"idle_timeout" json:"idle_timeout"`
+	// ActiveTime is the last time the env is extended, the idle time is counted from the later of it and the update time
+	ActiveTime int64 `bson:"active_time"  json:"active_time"`
+	// CreateBy is the user warned before the env expires
+	CreateBy string `bson:"create_by"    json:"create_by"`
+	// WarnTime is the time the expiry warning is sent, it's reset once the env is extended
+	WarnTime int64 `bson:"warn_time"    json:"warn_time"`
+	// DeleteFailTime is the last time the expired env failed to be deleted, the deletion is retried after a while
+	DeleteFailTime  int64 `bson:"delete_fail_time"  json:"delete_fail_time"`
+	DeleteFailCount int   `bson:"delete_fail_count" json:"delete_fail_count"`
 }
 
 type NotificationEvent string
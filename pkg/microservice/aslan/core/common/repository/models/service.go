@@ -82,9 +82,46 @@ type Service struct {
 	DeployTime         int64                            `bson:"deploy_time,omitempty"          json:"deploy_time,omitempty"`
 	TemplateID         string                           `bson:"template_id,omitempty"          json:"template_id,omitempty"`
 	AutoSync           bool                             `bson:"auto_sync"                      json:"auto_sync"`
+	Kustomize          *KustomizeConfig                 `bson:"kustomize,omitempty"            json:"kustomize,omitempty"`
 	Production         bool                             `bson:"-"                              json:"-"` // check current service data is production service
 }
 
+// KustomizeConfig is set on the k8s services loaded from a kustomization directory, the service yaml
+// is built from the files with the overlay of the environment instead of using the yaml field
+type KustomizeConfig struct {
+	// BasePath is the kustomization built for the environments without overlay, all paths are relative to the load path
+	BasePath string              `bson:"base_path" json:"base_path"`
+	Overlays []*KustomizeOverlay `bson:"overlays"  json:"overlays"`
+	Files    []*KustomizeFile    `bson:"files"     json:"files,omitempty"`
+}
+
+type KustomizeOverlay struct {
+	EnvName string            `bson:"env_name" json:"env_name"`
+	Path    string            `bson:"path"     json:"path"`
+	Images  []*KustomizeImage `bson:"images"   json:"images"`
+}
+
+// KustomizeImage overrides the image in the kustomize output, Name is the image name without tag
+type KustomizeImage struct {
+	Name    string `bson:"name"     json:"name"`
+	NewName string `bson:"new_name" json:"new_name"`
+	NewTag  string `bson:"new_tag"  json:"new_tag"`
+}
+
+type KustomizeFile struct {
+	Path    string `bson:"path"    json:"path"`
+	Content string `bson:"content" json:"content"`
+}
+
+func (c *KustomizeConfig) GetOverlay(envName string) *KustomizeOverlay {
+	for _, overlay := range c.Overlays {
+		if overlay.EnvName == envName {
+			return overlay
+		}
+	}
+	return nil
+}
+
 type CreateFromRepo struct {
 	GitRepoConfig *templatemodels.GitRepoConfig `bson:"git_repo_config,omitempty"      json:"git_repo_config,omitempty"`
 	LoadPath      string                        `bson:"load_path,omitempty"            json:"load_path,omitempty"`
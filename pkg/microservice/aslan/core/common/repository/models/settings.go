@@ -19,14 +19,15 @@ package models
 import "go.mongodb.org/mongo-driver/bson/primitive"
 
 type SystemSetting struct {
-	ID                  primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
-	WorkflowConcurrency int64              `bson:"workflow_concurrency" json:"workflow_concurrency"`
-	BuildConcurrency    int64              `bson:"build_concurrency" json:"build_concurrency"`
-	DefaultLogin        string             `bson:"default_login" json:"default_login"`
-	Theme               *Theme             `bson:"theme" json:"theme"`
-	Security            *SecuritySettings  `bson:"security" json:"security"`
-	Privacy             *PrivacySettings   `bson:"privacy"  json:"privacy"`
-	UpdateTime          int64              `bson:"update_time" json:"update_time"`
+	ID                  primitive.ObjectID          `bson:"_id,omitempty" json:"id,omitempty"`
+	WorkflowConcurrency int64                       `bson:"workflow_concurrency" json:"workflow_concurrency"`
+	BuildConcurrency    int64                       `bson:"build_concurrency" json:"build_concurrency"`
+	DefaultLogin        string                      `bson:"default_login" json:"default_login"`
+	Theme               *Theme                      `bson:"theme" json:"theme"`
+	Security            *SecuritySettings           `bson:"security" json:"security"`
+	Privacy             *PrivacySettings            `bson:"privacy"  json:"privacy"`
+	WorkflowScheduling  *WorkflowSchedulingSettings `bson:"workflow_scheduling" json:"workflow_scheduling"`
+	UpdateTime          int64                       `bson:"update_time" json:"update_time"`
 }
 
 type Theme struct {
@@ -72,3 +73,19 @@ type PrivacySettings struct {
 func (SystemSetting) TableName() string {
 	return "system_setting"
 }
+
+// WorkflowSchedulingSettings controls how the waiting workflow tasks are picked from the queue:
+// tasks with higher priority first, then the project with the least running tasks relative to its weight.
+type WorkflowSchedulingSettings struct {
+	// DefaultProjectConcurrency is the max number of running tasks of a project without its own quota, 0 means no limit
+	DefaultProjectConcurrency int                         `bson:"default_project_concurrency" json:"default_project_concurrency"`
+	Projects                  []*ProjectSchedulingSetting `bson:"projects" json:"projects"`
+}
+
+type ProjectSchedulingSetting struct {
+	ProjectName string `bson:"project_name" json:"project_name"`
+	// Concurrency is the max number of running tasks of the project, 0 means using the default one
+	Concurrency int `bson:"concurrency" json:"concurrency"`
+	// Weight is the share of the project when competing with other projects, default to 1
+	Weight int `bson:"weight" json:"weight"`
+}
@@ -150,6 +150,19 @@ type ServiceRender struct {
 
 	// OverrideYaml will be used in both helm and k8s projects
 	OverrideYaml *CustomYaml `bson:"override_yaml,omitempty"   json:"override_yaml,omitempty"`
+
+	// AdoptedFields are used for k8s services, they are the fields modified outside Zadig and adopted into the env
+	AdoptedFields []*AdoptedField `bson:"adopted_fields,omitempty"   json:"adopted_fields,omitempty"`
+}
+
+// AdoptedField overrides a field of a resource in the rendered manifests of the service in the env
+type AdoptedField struct {
+	Kind string `bson:"kind"            json:"kind"`
+	Name string `bson:"name"            json:"name"`
+	// Path is the keys and list indexes like [0] leading to the field
+	Path []string `bson:"path"            json:"path"`
+	// Value is the json encoded value of the field, the field is removed if it's empty
+	Value string `bson:"value,omitempty" json:"value,omitempty"`
 }
 
 func (rc *ServiceRender) DeployedFromZadig() bool {
@@ -0,0 +1,83 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	TestCaseStatusPassed  = "passed"
+	TestCaseStatusFailed  = "failed"
+	TestCaseStatusError   = "error"
+	TestCaseStatusSkipped = "skipped"
+)
+
+// TestCaseHistory is the result of a single test case in a testing job run
+type TestCaseHistory struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty"  json:"id"`
+	ProjectName  string             `bson:"project_name"   json:"project_name"`
+	TestName     string             `bson:"test_name"      json:"test_name"`
+	WorkflowName string             `bson:"workflow_name"  json:"workflow_name"`
+	JobTaskName  string             `bson:"job_task_name"  json:"job_task_name"`
+	TaskID       int64              `bson:"task_id"        json:"task_id"`
+	CommitID     string             `bson:"commit_id"      json:"commit_id"`
+	ClassName    string             `bson:"class_name"     json:"class_name"`
+	CaseName     string             `bson:"case_name"      json:"case_name"`
+	Status       string             `bson:"status"         json:"status"`
+	Duration     float64            `bson:"duration"       json:"duration"`
+	Quarantined  bool               `bson:"quarantined"    json:"quarantined"`
+	CreateTime   int64              `bson:"create_time"    json:"create_time"`
+	// CreatedAt is the create time as a date, the histories expire by the ttl index on it
+	CreatedAt time.Time `bson:"created_at" json:"-"`
+}
+
+// TestCaseHistoryStat is the result of a test case aggregated from its histories
+type TestCaseHistoryStat struct {
+	ClassName     string  `bson:"class_name"`
+	CaseName      string  `bson:"case_name"`
+	Runs          int     `bson:"runs"`
+	Passed        int     `bson:"passed"`
+	Failed        int     `bson:"failed"`
+	Skipped       int     `bson:"skipped"`
+	TotalDuration float64 `bson:"total_duration"`
+	MaxDuration   float64 `bson:"max_duration"`
+	// Commits is the number of commits the case ran on, FlakyCommits is the number of them on which the case both passed and failed
+	Commits      int    `bson:"commits"`
+	FlakyCommits int    `bson:"flaky_commits"`
+	LastStatus   string `bson:"last_status"`
+	LastRunTime  int64  `bson:"last_run_time"`
+}
+
+func (TestCaseHistory) TableName() string {
+	return "test_case_history"
+}
+
+func TestCaseStatus(tc *TestCase) string {
+	switch {
+	case tc.Failure != nil:
+		return TestCaseStatusFailed
+	case tc.Error != nil:
+		return TestCaseStatusError
+	case tc.Skipped != nil:
+		return TestCaseStatusSkipped
+	default:
+		return TestCaseStatusPassed
+	}
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/koderover/zadig/v2/pkg/tool/coverage"
+)
+
+// TestCoverage is the code coverage collected by a testing job
+type TestCoverage struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	ProjectName  string             `bson:"project_name"  json:"project_name"`
+	TestName     string             `bson:"test_name"     json:"test_name"`
+	WorkflowName string             `bson:"workflow_name" json:"workflow_name"`
+	JobTaskName  string             `bson:"job_task_name" json:"job_task_name"`
+	TaskID       int64              `bson:"task_id"       json:"task_id"`
+	// Branch is the target branch if PR is set
+	Branch   string  `bson:"branch"    json:"branch"`
+	PR       int     `bson:"pr"        json:"pr"`
+	CommitID string  `bson:"commit_id" json:"commit_id"`
+	Format   string  `bson:"format"    json:"format"`
+	Covered  int     `bson:"covered"   json:"covered"`
+	Total    int     `bson:"total"     json:"total"`
+	Coverage float64 `bson:"coverage"  json:"coverage"`
+	// BaseCoverage is the latest coverage of the target branch on PR-triggered tasks
+	BaseCoverage *float64                 `bson:"base_coverage,omitempty" json:"base_coverage,omitempty"`
+	Delta        *float64                 `bson:"delta,omitempty"         json:"delta,omitempty"`
+	Files        []*coverage.FileCoverage `bson:"files"                   json:"files,omitempty"`
+	CreateTime   int64                    `bson:"create_time"             json:"create_time"`
+}
+
+func (TestCoverage) TableName() string {
+	return "test_coverage"
+}
@@ -46,6 +46,10 @@ type Testing struct {
 	TestReportPath string `bson:"test_report_path"         json:"test_report_path"`
 	Threshold      int    `bson:"threshold"                json:"threshold"`
 	TestType       string `bson:"test_type"                json:"test_type"`
+	// 代码覆盖率报告
+	Coverage *CoverageSetting `bson:"coverage,omitempty"       json:"coverage,omitempty"`
+	// 测试结果格式: junit, tap, trx, gotest, 为空时为 junit
+	TestResultFormat string `bson:"test_result_format"       json:"test_result_format"`
 
 	// TODO: Deprecated.
 	Caches []string `bson:"caches"                   json:"caches"`
@@ -73,6 +77,18 @@ type Testing struct {
 	Outputs                  []*Output `bson:"outputs"                   json:"outputs"`
 }
 
+// CoverageSetting collects the code coverage report after testing and checks the coverage gate
+type CoverageSetting struct {
+	Enabled bool `bson:"enabled"     json:"enabled"`
+	// Format is one of cobertura, jacoco, go and lcov
+	Format     string `bson:"format"      json:"format"`
+	ReportPath string `bson:"report_path" json:"report_path"`
+	// Threshold is the minimum total coverage percentage, 0 means no limit
+	Threshold float64 `bson:"threshold"   json:"threshold"`
+	// MaxDrop is the maximum coverage drop in percentage points compared with the target branch on PR-triggered tasks, 0 means no limit
+	MaxDrop float64 `bson:"max_drop"    json:"max_drop"`
+}
+
 type TestingHookCtrl struct {
 	Enabled bool           `bson:"enabled" json:"enabled"`
 	Items   []*TestingHook `bson:"items" json:"items"`
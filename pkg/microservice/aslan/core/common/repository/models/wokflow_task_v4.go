@@ -56,12 +56,16 @@ type WorkflowTask struct {
 	IsArchived          bool                          `bson:"is_archived"               json:"is_archived"`
 	Error               string                        `bson:"error,omitempty"           json:"error,omitempty"`
 	IsRestart           bool                          `bson:"is_restart"                json:"is_restart"`
+	RetryFromTaskID     int64                         `bson:"retry_from_task_id,omitempty" json:"retry_from_task_id,omitempty"`
+	RetryFromJob        string                        `bson:"retry_from_job,omitempty"  json:"retry_from_job,omitempty"`
 	IsDebug             bool                          `bson:"is_debug"                  json:"is_debug"`
 	ShareStorages       []*ShareStorage               `bson:"share_storages"            json:"share_storages"`
 	Type                config.CustomWorkflowTaskType `bson:"type"                      json:"type"`
 	Hash                string                        `bson:"hash"                      json:"hash"`
 	ApprovalTicketID    string                        `bson:"approval_ticket_id"        json:"approval_ticket_id"`
 	ApprovalID          string                        `bson:"approval_id"               json:"approval_id"`
+	Priority            int                           `bson:"priority,omitempty"        json:"priority,omitempty"`
+	ConcurrencyGroup    string                        `bson:"concurrency_group,omitempty" json:"concurrency_group,omitempty"`
 }
 
 func (WorkflowTask) TableName() string {
@@ -82,6 +86,11 @@ type StageTask struct {
 	ManualExec *ManualExec   `bson:"manual_exec"     json:"manual_exec,omitempty"`
 	Jobs       []*JobTask    `bson:"jobs"            json:"jobs,omitempty"`
 	Error      string        `bson:"error"           json:"error"`
+	When       string        `bson:"when,omitempty"  json:"when,omitempty"`
+	// Timeout of the stage in minutes, 0 means no limit
+	Timeout              int64 `bson:"timeout,omitempty"                json:"timeout,omitempty"`
+	Finally              bool  `bson:"finally,omitempty"                json:"finally,omitempty"`
+	FinallyAffectsStatus bool  `bson:"finally_affects_status,omitempty" json:"finally_affects_status,omitempty"`
 }
 
 type JobTask struct {
@@ -120,6 +129,20 @@ type JobTask struct {
 
 	RetryCount int  `bson:"retry_count" json:"retry_count" yaml:"retry_count"`
 	Reverted   bool `bson:"reverted"    json:"reverted"    yaml:"reverted"`
+
+	// When is the condition expression of the job, SkipReason records why the job is skipped by it.
+	When       string `bson:"when,omitempty"        json:"when,omitempty"        yaml:"when,omitempty"`
+	SkipReason string `bson:"skip_reason,omitempty" json:"skip_reason,omitempty" yaml:"skip_reason,omitempty"`
+	// Needs is the origin name list of the jobs this job depends on
+	Needs []string `bson:"needs,omitempty"       json:"needs,omitempty"       yaml:"needs,omitempty"`
+	// Matrix is set when the job task is expanded from a job matrix
+	Matrix *JobTaskMatrix `bson:"matrix,omitempty"      json:"matrix,omitempty"      yaml:"matrix,omitempty"`
+}
+
+type JobTaskMatrix struct {
+	// Values is the matrix combination of this job task
+	Values   map[string]string `bson:"values"    json:"values"    yaml:"values"`
+	FailFast bool              `bson:"fail_fast" json:"fail_fast" yaml:"fail_fast"`
 }
 
 type TaskJobInfo struct {
@@ -402,6 +425,18 @@ type JobIstioReleaseSpec struct {
 	Replicas          int64           `bson:"replicas"           json:"replicas"           yaml:"replicas"`
 	Targets           *IstioJobTarget `bson:"targets"            json:"targets"            yaml:"targets"`
 	Event             []*Event        `bson:"event"              json:"event"              yaml:"event"`
+	// Steps is the progressive release history, empty if the weight is shifted at once
+	Steps        []*IstioReleaseStep `bson:"steps,omitempty"         json:"steps,omitempty"         yaml:"steps,omitempty"`
+	HealthChecks []*IstioHealthCheck `bson:"health_checks,omitempty" json:"health_checks,omitempty" yaml:"health_checks,omitempty"`
+}
+
+type IstioReleaseStep struct {
+	Weight    int64         `bson:"weight"     json:"weight"     yaml:"weight"`
+	Interval  int64         `bson:"interval"   json:"interval"   yaml:"interval"`
+	Status    config.Status `bson:"status"     json:"status"     yaml:"status"`
+	StartTime int64         `bson:"start_time" json:"start_time" yaml:"start_time"`
+	EndTime   int64         `bson:"end_time"   json:"end_time"   yaml:"end_time"`
+	Message   string        `bson:"message"    json:"message"    yaml:"message"`
 }
 
 type JobIstioRollbackSpec struct {
@@ -480,6 +515,7 @@ type JobTaskSQLSpec struct {
 	ID      string                `bson:"id" json:"id" yaml:"id"`
 	Type    config.DBInstanceType `bson:"type" json:"type" yaml:"type"`
 	SQL     string                `bson:"sql" json:"sql" yaml:"sql"`
+	DryRun  bool                  `bson:"dry_run" json:"dry_run" yaml:"dry_run"`
 	Results []*SQLExecResult      `bson:"results" json:"results" yaml:"results"`
 }
 
@@ -488,6 +524,7 @@ type SQLExecResult struct {
 	ElapsedTime  int64                 `bson:"elapsed_time" json:"elapsed_time" yaml:"elapsed_time"`
 	RowsAffected int64                 `bson:"rows_affected" json:"rows_affected" yaml:"rows_affected"`
 	Status       setting.SQLExecStatus `bson:"status" json:"status" yaml:"status"`
+	Error        string                `bson:"error,omitempty" json:"error,omitempty" yaml:"error,omitempty"`
 }
 
 type JobTaskApolloSpec struct {
@@ -543,6 +580,17 @@ type JobTaskApprovalSpec struct {
 	WorkWXApproval   *WorkWXApproval     `bson:"workwx_approval"             yaml:"workwx_approval,omitempty"     json:"workwx_approval,omitempty"`
 }
 
+type JobTaskCallWorkflowSpec struct {
+	ProjectName         string        `bson:"project_name"          json:"project_name"          yaml:"project_name"`
+	WorkflowName        string        `bson:"workflow_name"         json:"workflow_name"         yaml:"workflow_name"`
+	WorkflowDisplayName string        `bson:"workflow_display_name" json:"workflow_display_name" yaml:"workflow_display_name"`
+	Inputs              []*Param      `bson:"inputs"                json:"inputs"                yaml:"inputs"`
+	TaskID              int64         `bson:"task_id"               json:"task_id"               yaml:"task_id"`
+	TaskStatus          config.Status `bson:"task_status"           json:"task_status"           yaml:"task_status"`
+	// Outputs are the resolved outputs of the called workflow task
+	Outputs []*KeyVal `bson:"outputs"               json:"outputs"               yaml:"outputs"`
+}
+
 type JobTaskWorkflowTriggerSpec struct {
 	TriggerType           config.WorkflowTriggerType `bson:"trigger_type" json:"trigger_type" yaml:"trigger_type"`
 	IsEnableCheck         bool                       `bson:"is_enable_check" json:"is_enable_check" yaml:"is_enable_check"`
@@ -582,6 +630,18 @@ type JobTaskGrafanaSpec struct {
 	Alerts    []*GrafanaAlert `bson:"alerts" json:"alerts" yaml:"alerts"`
 }
 
+type JobTaskMetricCheckSpec struct {
+	ID   string `bson:"id" json:"id" yaml:"id"`
+	Name string `bson:"name" json:"name" yaml:"name"`
+	// CheckTime minute
+	CheckTime int64 `bson:"check_time" json:"check_time" yaml:"check_time"`
+	// CheckInterval second
+	CheckInterval int64          `bson:"check_interval" json:"check_interval" yaml:"check_interval"`
+	FailureLimit  int            `bson:"failure_limit" json:"failure_limit" yaml:"failure_limit"`
+	Failures      int            `bson:"failures" json:"failures" yaml:"failures"`
+	Metrics       []*MetricCheck `bson:"metrics" json:"metrics" yaml:"metrics"`
+}
+
 type JobTaskGuanceyunCheckSpec struct {
 	ID   string `bson:"id" json:"id" yaml:"id"`
 	Name string `bson:"name" json:"name" yaml:"name"`
@@ -723,6 +783,8 @@ type WorkflowTaskCtx struct {
 	WorkflowTaskCreatorEmail    string
 	WorkflowTaskCreatorMobile   string
 	WorkflowKeyVals             []*KeyVal
+	Params                      []*Param
+	ChangedFiles                []string
 	GlobalContextGetAll         func() map[string]string
 	GlobalContextGet            func(key string) (string, bool)
 	GlobalContextSet            func(key, value string)
@@ -483,6 +483,8 @@ type HookPayload struct {
 	DeliveryID     string `bson:"delivery_id"      json:"delivery_id,omitempty"`
 	CodehostID     int    `bson:"codehost_id"      json:"codehost_id"`
 	EventType      string `bson:"event_type"       json:"event_type"`
+	// ChangedFiles is only collected for gitlab and github push/pr events
+	ChangedFiles []string `bson:"changed_files,omitempty" json:"changed_files,omitempty"`
 }
 
 type TargetArgs struct {
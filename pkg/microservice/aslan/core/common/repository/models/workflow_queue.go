@@ -34,6 +34,8 @@ type WorkflowQueue struct {
 	TaskRevoker         string                        `bson:"task_revoker,omitempty"                     json:"task_revoker,omitempty"`
 	CreateTime          int64                         `bson:"create_time"                                json:"create_time,omitempty"`
 	Type                config.CustomWorkflowTaskType `bson:"type"                                       json:"type,omitempty"`
+	Priority            int                           `bson:"priority"                                   json:"priority"`
+	ConcurrencyGroup    string                        `bson:"concurrency_group,omitempty"                json:"concurrency_group,omitempty"`
 }
 
 func (WorkflowQueue) TableName() string {
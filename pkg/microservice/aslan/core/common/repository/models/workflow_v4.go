@@ -70,6 +70,29 @@ type WorkflowV4 struct {
 	ConcurrencyLimit     int          `bson:"concurrency_limit"      yaml:"concurrency_limit"      json:"concurrency_limit"`
 	CustomField          *CustomField `bson:"custom_field"           yaml:"-"                      json:"custom_field"`
 	EnableApprovalTicket bool         `bson:"enable_approval_ticket" yaml:"enable_approval_ticket" json:"enable_approval_ticket"`
+	// Outputs are exposed to the workflows calling this workflow by a call-workflow job
+	Outputs []*WorkflowOutput `bson:"outputs,omitempty"      yaml:"outputs,omitempty"      json:"outputs,omitempty"`
+	// Priority of the tasks in the queue, tasks with higher priority are run first, it can be overridden when running the workflow
+	Priority int `bson:"priority,omitempty"     yaml:"priority,omitempty"     json:"priority,omitempty"`
+	// Timeout of the workflow task in minutes, the running jobs are stopped and the task is set to timeout when it is reached
+	// 0 means no limit
+	Timeout int64 `bson:"timeout,omitempty"      yaml:"timeout,omitempty"      json:"timeout,omitempty"`
+	// ConcurrencyGroup makes the tasks in the same group of the project run one by one
+	ConcurrencyGroup *ConcurrencyGroup `bson:"concurrency_group,omitempty" yaml:"concurrency_group,omitempty" json:"concurrency_group,omitempty"`
+}
+
+type ConcurrencyGroup struct {
+	// Key is rendered with the workflow params when a task is created, e.g. pr-{{.workflow.params.pr}}
+	Key string `bson:"key"                yaml:"key"                json:"key"`
+	// CancelInProgress cancels the unfinished tasks in the group when a new task is created, instead of queueing the new one behind them
+	CancelInProgress bool `bson:"cancel_in_progress" yaml:"cancel_in_progress" json:"cancel_in_progress"`
+}
+
+type WorkflowOutput struct {
+	Name        string `bson:"name"        yaml:"name"        json:"name"`
+	Description string `bson:"description" yaml:"description" json:"description"`
+	// Value refers to the job outputs of this workflow, e.g. {{.job.build.output.VERSION}}
+	Value string `bson:"value"       yaml:"value"       json:"value"`
 }
 
 func (w *WorkflowV4) UpdateHash() {
@@ -78,7 +101,7 @@ func (w *WorkflowV4) UpdateHash() {
 
 func (w *WorkflowV4) CalculateHash() [md5.Size]byte {
 	fieldList := make(map[string]interface{})
-	ignoringFieldList := []string{"CreatedBy", "CreateTime", "UpdatedBy", "UpdateTime", "Description", "Hash", "DisplayName", "HookCtls", "JiraHookCtls", "MeegoHookCtls", "GeneralHookCtls", "ConcurrencyLimit", "ShareStorages", "NotifyCtls"}
+	ignoringFieldList := []string{"CreatedBy", "CreateTime", "UpdatedBy", "UpdateTime", "Description", "Hash", "DisplayName", "HookCtls", "JiraHookCtls", "MeegoHookCtls", "GeneralHookCtls", "ConcurrencyLimit", "ConcurrencyGroup", "Priority", "ShareStorages", "NotifyCtls"}
 	ignoringFields := sets.NewString(ignoringFieldList...)
 
 	val := reflect.ValueOf(*w)
@@ -113,6 +136,14 @@ type WorkflowStage struct {
 	Approval   *Approval   `bson:"approval"           yaml:"approval"          json:"approval"`
 	ManualExec *ManualExec `bson:"manual_exec"        yaml:"manual_exec"       json:"manual_exec"`
 	Jobs       []*Job      `bson:"jobs"               yaml:"jobs"              json:"jobs"`
+	// When is a condition expression, all jobs in the stage will be skipped if it is evaluated to false.
+	When string `bson:"when,omitempty"     yaml:"when,omitempty"    json:"when,omitempty"`
+	// Timeout of the stage in minutes, 0 means no limit
+	Timeout int64 `bson:"timeout,omitempty"  yaml:"timeout,omitempty" json:"timeout,omitempty"`
+	// Finally stage is the last stage of the workflow, it always runs after the other stages are finished, no matter what the result is.
+	Finally bool `bson:"finally,omitempty"  yaml:"finally,omitempty" json:"finally,omitempty"`
+	// FinallyAffectsStatus makes the failure of the finally stage fail the workflow task
+	FinallyAffectsStatus bool `bson:"finally_affects_status,omitempty" yaml:"finally_affects_status,omitempty" json:"finally_affects_status,omitempty"`
 }
 
 type ManualExec struct {
@@ -255,6 +286,11 @@ type Job struct {
 	RunPolicy      config.JobRunPolicy      `bson:"run_policy"           yaml:"run_policy"           json:"run_policy"`
 	ErrorPolicy    *JobErrorPolicy          `bson:"error_policy"         yaml:"error_policy"         json:"error_policy"`
 	ServiceModules []*WorkflowServiceModule `bson:"service_modules"                                  json:"service_modules"`
+	// When is a condition expression evaluated right before the job runs, the job will be skipped if it is evaluated to false.
+	When string `bson:"when,omitempty"       yaml:"when,omitempty"       json:"when,omitempty"`
+	// Needs is the name list of the jobs this job depends on, a job with needs starts as soon as all the needed jobs are done
+	// instead of waiting for the previous stage.
+	Needs []string `bson:"needs,omitempty"      yaml:"needs,omitempty"      json:"needs,omitempty"`
 }
 
 type JobErrorPolicy struct {
@@ -312,6 +348,19 @@ type FreestyleJobSpec struct {
 	Services      []*FreeStyleServiceInfo `bson:"services"             yaml:"services"            json:"services"`
 	Steps         []*Step                 `bson:"steps"                yaml:"steps"               json:"steps"`
 	Outputs       []*Output               `bson:"outputs"              yaml:"outputs"             json:"outputs"`
+	Matrix        *JobMatrix              `bson:"matrix,omitempty"     yaml:"matrix,omitempty"    json:"matrix,omitempty"`
+}
+
+// JobMatrix expands one job into a job task for every combination of the axis values,
+// the values of a combination are injected into the job task as env vars named by the axis.
+type JobMatrix struct {
+	Axes map[string][]string `bson:"axes"      yaml:"axes"      json:"axes"`
+	// Include adds the key/values to every combination that does not conflict with it, or adds a new combination if none matches
+	Include []map[string]string `bson:"include"   yaml:"include"   json:"include"`
+	// Exclude removes the combinations that match all the key/values of an entry
+	Exclude []map[string]string `bson:"exclude"   yaml:"exclude"   json:"exclude"`
+	// FailFast cancels the other job tasks of the matrix once one of them fails
+	FailFast bool `bson:"fail_fast" yaml:"fail_fast" json:"fail_fast"`
 }
 
 type FreeStyleServiceInfo struct {
@@ -336,6 +385,26 @@ type ZadigBuildJobSpec struct {
 	DefaultServiceAndBuilds []*ServiceAndBuild      `bson:"default_service_and_builds"     yaml:"default_service_and_builds"         json:"default_service_and_builds"`
 	ServiceAndBuilds        []*ServiceAndBuild      `bson:"service_and_builds"     yaml:"service_and_builds"         json:"service_and_builds"`
 	ServiceAndBuildsOptions []*ServiceAndBuild      `bson:"-"                      yaml:"service_and_builds_options" json:"service_and_builds_options"`
+	Matrix                  *JobMatrix              `bson:"matrix,omitempty"       yaml:"matrix,omitempty"            json:"matrix,omitempty"`
+	SBOM                    *BuildSBOMSetting       `bson:"sbom,omitempty"         yaml:"sbom,omitempty"              json:"sbom,omitempty"`
+	Signing                 *BuildSigningSetting    `bson:"signing,omitempty"      yaml:"signing,omitempty"           json:"signing,omitempty"`
+}
+
+// BuildSigningSetting signs every image built by the build job with cosign
+type BuildSigningSetting struct {
+	Enabled      bool   `bson:"enabled"        yaml:"enabled"        json:"enabled"`
+	SigningKeyID string `bson:"signing_key_id" yaml:"signing_key_id" json:"signing_key_id"`
+	// SignerVersion is the cosign version installed before signing, the cosign in the build image is used if empty
+	SignerVersion string `bson:"signer_version" yaml:"signer_version" json:"signer_version"`
+}
+
+// BuildSBOMSetting generates a sbom for every image built by the build job
+type BuildSBOMSetting struct {
+	Enabled bool `bson:"enabled" yaml:"enabled" json:"enabled"`
+	// Format is one of cyclonedx and spdx-json
+	Format string `bson:"format" yaml:"format" json:"format"`
+	// GeneratorVersion is the trivy version installed before generating, the trivy in the build image is used if empty
+	GeneratorVersion string `bson:"generator_version" yaml:"generator_version" json:"generator_version"`
 }
 
 type ServiceAndBuild struct {
@@ -527,6 +596,37 @@ type DistributeTarget struct {
 	UpdateTag bool `bson:"update_tag"                yaml:"update_tag"                json:"update_tag"`
 }
 
+type ImageScanJobSpec struct {
+	// fromjob/runtime, `runtime` means runtime input, `fromjob` means that it is obtained from the upstream build job
+	Source config.DeploySourceType `bson:"source"   yaml:"source"   json:"source"`
+	// required when source is `fromjob`, specify which upstream build job the images come from
+	JobName string `bson:"job_name" yaml:"job_name" json:"job_name"`
+	// not required when source is fromjob, directly obtained from upstream build job information
+	RegistryID string             `bson:"registry_id" yaml:"registry_id" json:"registry_id"`
+	Targets    []*ImageScanTarget `bson:"targets"     yaml:"targets"     json:"targets"`
+	// ScannerVersion is the version of the trivy package installed by the tool install step,
+	// the scanner is expected to be in the job image if empty
+	ScannerVersion string `bson:"scanner_version" yaml:"scanner_version" json:"scanner_version"`
+	// Severity is the lowest severity counted by the quality gate, one of CRITICAL, HIGH, MEDIUM, LOW and UNKNOWN
+	Severity string `bson:"severity"  yaml:"severity"  json:"severity"`
+	// Threshold is the number of counted vulnerabilities allowed per image
+	Threshold int `bson:"threshold" yaml:"threshold" json:"threshold"`
+	// unit is minute.
+	Timeout       int64  `bson:"timeout"        yaml:"timeout"        json:"timeout"`
+	ClusterID     string `bson:"cluster_id"     yaml:"cluster_id"     json:"cluster_id"`
+	ClusterSource string `bson:"cluster_source" yaml:"cluster_source" json:"cluster_source"`
+	StrategyID    string `bson:"strategy_id"    yaml:"strategy_id"    json:"strategy_id"`
+
+	CustomAnnotations []*util.KeyValue `bson:"custom_annotations" json:"custom_annotations" yaml:"custom_annotations"`
+	CustomLabels      []*util.KeyValue `bson:"custom_labels"      json:"custom_labels"      yaml:"custom_labels"`
+}
+
+type ImageScanTarget struct {
+	ServiceName   string `bson:"service_name"    yaml:"service_name"    json:"service_name"`
+	ServiceModule string `bson:"service_module"  yaml:"service_module"  json:"service_module"`
+	Image         string `bson:"image,omitempty" yaml:"image,omitempty" json:"image,omitempty"`
+}
+
 type ZadigTestingJobSpec struct {
 	TestType      config.TestModuleType   `bson:"test_type"         yaml:"test_type"         json:"test_type"`
 	Source        config.DeploySourceType `bson:"source"            yaml:"source"            json:"source"`
@@ -539,6 +639,7 @@ type ZadigTestingJobSpec struct {
 	TestModules []*TestModule `bson:"test_modules"      yaml:"test_modules"      json:"test_modules"`
 	// in config: this is the test infos for all the services
 	ServiceAndTests []*ServiceAndTest `bson:"service_and_tests" yaml:"service_and_tests" json:"service_and_tests"`
+	Matrix          *JobMatrix        `bson:"matrix,omitempty"  yaml:"matrix,omitempty"  json:"matrix,omitempty"`
 }
 
 type ServiceAndTest struct {
@@ -820,6 +921,27 @@ type IstioJobSpec struct {
 	Weight            int64             `bson:"weight"             json:"weight"             yaml:"weight"`
 	Targets           []*IstioJobTarget `bson:"targets"            json:"targets"            yaml:"targets"`
 	TargetOptions     []*IstioJobTarget `bson:"-"                  json:"target_options"     yaml:"target_options"`
+	// ProgressiveSteps shifts the traffic step by step instead of to Weight at once,
+	// the weight of the last step is used as the release weight of the job
+	ProgressiveSteps []*IstioProgressiveStep `bson:"progressive_steps,omitempty" json:"progressive_steps,omitempty" yaml:"progressive_steps,omitempty"`
+	// HealthChecks gates every progressive step, a failed check reverts the virtual service to the weights before the job
+	HealthChecks []*IstioHealthCheck `bson:"health_checks,omitempty" json:"health_checks,omitempty" yaml:"health_checks,omitempty"`
+}
+
+type IstioProgressiveStep struct {
+	// Weight is the percentage of traffic routed to the new version in this step
+	Weight int64 `bson:"weight" json:"weight" yaml:"weight"`
+	// Interval is the seconds to wait before checking the health of this step
+	Interval int64 `bson:"interval" json:"interval" yaml:"interval"`
+}
+
+type IstioHealthCheck struct {
+	Type config.IstioHealthCheckType `bson:"type" json:"type" yaml:"type"`
+	// MaxRestarts is the total container restarts of the new version pods tolerated in restart_count check
+	MaxRestarts int32 `bson:"max_restarts,omitempty" json:"max_restarts,omitempty" yaml:"max_restarts,omitempty"`
+	// ObservabilityID and Metric are used in metric check, ObservabilityID is the id of a prometheus integration
+	ObservabilityID string       `bson:"observability_id,omitempty" json:"observability_id,omitempty" yaml:"observability_id,omitempty"`
+	Metric          *MetricCheck `bson:"metric,omitempty" json:"metric,omitempty" yaml:"metric,omitempty"`
 }
 
 type IstioRollBackJobSpec struct {
@@ -846,6 +968,8 @@ type SQLJobSpec struct {
 	Type   config.DBInstanceType `bson:"type" json:"type" yaml:"type"`
 	SQL    string                `bson:"sql" json:"sql" yaml:"sql"`
 	Source string                `bson:"source" json:"source" yaml:"source"`
+	// DryRun executes the statements in a transaction and rolls it back
+	DryRun bool `bson:"dry_run" json:"dry_run" yaml:"dry_run"`
 }
 
 type ApolloJobSpec struct {
@@ -912,6 +1036,36 @@ type GrafanaAlert struct {
 	Url    string `bson:"url,omitempty" json:"url,omitempty" yaml:"url,omitempty"`
 }
 
+type MetricCheckJobSpec struct {
+	// ID is the id of the prometheus observability integration
+	ID   string `bson:"id" json:"id" yaml:"id"`
+	Name string `bson:"name" json:"name" yaml:"name"`
+	// CheckTime minute
+	CheckTime int64 `bson:"check_time" json:"check_time" yaml:"check_time"`
+	// CheckInterval second, the metrics are queried once per interval
+	CheckInterval int64 `bson:"check_interval" json:"check_interval" yaml:"check_interval"`
+	// FailureLimit is the number of failed checks tolerated before the job fails
+	FailureLimit int            `bson:"failure_limit" json:"failure_limit" yaml:"failure_limit"`
+	Metrics      []*MetricCheck `bson:"metrics" json:"metrics" yaml:"metrics"`
+}
+
+type MetricCheck struct {
+	Name  string                 `bson:"name" json:"name" yaml:"name"`
+	Query string                 `bson:"query" json:"query" yaml:"query"`
+	Mode  config.MetricCheckMode `bson:"mode" json:"mode" yaml:"mode"`
+	// BaselineQuery is required in canary mode, e.g. the same metric of the stable version
+	BaselineQuery string `bson:"baseline_query" json:"baseline_query" yaml:"baseline_query"`
+	// Operator is one of <, <=, >, >= and ==
+	Operator string `bson:"operator" json:"operator" yaml:"operator"`
+	// Threshold is the value to compare with in absolute mode,
+	// and the ratio applied to the baseline value in baseline and canary mode
+	Threshold float64 `bson:"threshold" json:"threshold" yaml:"threshold"`
+	Value     float64 `bson:"value,omitempty" json:"value,omitempty" yaml:"value,omitempty"`
+	Baseline  float64 `bson:"baseline,omitempty" json:"baseline,omitempty" yaml:"baseline,omitempty"`
+	Status    string  `bson:"status,omitempty" json:"status,omitempty" yaml:"status,omitempty"`
+	Error     string  `bson:"error,omitempty" json:"error,omitempty" yaml:"error,omitempty"`
+}
+
 type GuanceyunCheckJobSpec struct {
 	ID   string `bson:"id" json:"id" yaml:"id"`
 	Name string `bson:"name" json:"name" yaml:"name"`
@@ -1245,6 +1399,15 @@ type NacosJobSpec struct {
 	DataFixed         bool                 `bson:"data_fixed"          json:"data_fixed"          yaml:"data_fixed"`
 }
 
+type CallWorkflowJobSpec struct {
+	ProjectName  string `bson:"project_name"  yaml:"project_name"  json:"project_name"`
+	WorkflowName string `bson:"workflow_name" yaml:"workflow_name" json:"workflow_name"`
+	// Inputs are the values of the params of the called workflow, the type of an input must be the same as the param
+	Inputs []*Param `bson:"inputs"        yaml:"inputs"        json:"inputs"`
+	// Outputs is the output list of the called workflow, only for frontend display
+	Outputs []*WorkflowOutput `bson:"outputs"       yaml:"outputs"       json:"outputs"`
+}
+
 type WorkflowTriggerJobSpec struct {
 	IsEnableCheck bool                       `bson:"is_enable_check" json:"is_enable_check" yaml:"is_enable_check"`
 	TriggerType   config.WorkflowTriggerType `bson:"trigger_type" json:"trigger_type" yaml:"trigger_type"`
@@ -123,6 +123,8 @@ func (c *DBInstanceColl) Update(id string, args *models.DBInstance) error {
 		"projects":   args.Projects,
 		"username":   args.Username,
 		"password":   args.Password,
+		"database":   args.Database,
+		"ssl_mode":   args.SSLMode,
 		"update_by":  args.UpdateBy,
 		"updated_at": time.Now().Unix(),
 	}}
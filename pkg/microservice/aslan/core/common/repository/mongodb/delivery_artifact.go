@@ -29,6 +29,7 @@ import (
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
 	mongotool "github.com/koderover/zadig/v2/pkg/tool/mongo"
+	"github.com/koderover/zadig/v2/pkg/tool/trivy"
 )
 
 type DeliveryArtifactArgs struct {
@@ -202,6 +203,28 @@ func (c *DeliveryArtifactColl) Update(args *DeliveryArtifactArgs) error {
 	return err
 }
 
+// UpdateVulnerabilitySummary attaches the image scan result to the image artifacts of the given image
+func (c *DeliveryArtifactColl) UpdateVulnerabilitySummary(image string, summary *trivy.Summary, scanTime int64) error {
+	query := bson.M{"image": image, "type": string(config.Image)}
+	change := bson.M{"$set": bson.M{
+		"vulnerability_summary": summary,
+		"scan_time":             scanTime,
+	}}
+	_, err := c.UpdateMany(context.TODO(), query, change)
+	return err
+}
+
+// UpdateSBOM links the sbom to the image artifacts of the given image
+func (c *DeliveryArtifactColl) UpdateSBOM(image, sbomID, format string) error {
+	query := bson.M{"image": image, "type": string(config.Image)}
+	change := bson.M{"$set": bson.M{
+		"sbom_id":     sbomID,
+		"sbom_format": format,
+	}}
+	_, err := c.UpdateMany(context.TODO(), query, change)
+	return err
+}
+
 func (c *DeliveryArtifactColl) ListTars(args *DeliveryArtifactArgs) ([]*models.DeliveryArtifact, error) {
 	if args == nil {
 		return nil, errors.New("nil delivery_artifact args")
@@ -0,0 +1,191 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	mongotool "github.com/koderover/zadig/v2/pkg/tool/mongo"
+)
+
+type EnvDriftColl struct {
+	*mongo.Collection
+
+	coll string
+}
+
+type EnvDriftListOption struct {
+	ProductName string
+	EnvName     string
+	Production  bool
+	ServiceName string
+	Status      []config.EnvDriftStatus
+}
+
+func NewEnvDriftColl() *EnvDriftColl {
+	name := models.EnvDrift{}.TableName()
+	return &EnvDriftColl{
+		Collection: mongotool.Database(config.MongoDatabase()).Collection(name),
+		coll:       name,
+	}
+}
+
+func (c *EnvDriftColl) GetCollectionName() string {
+	return c.coll
+}
+
+func (c *EnvDriftColl) EnsureIndex(ctx context.Context) error {
+	mod := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				bson.E{Key: "product_name", Value: 1},
+				bson.E{Key: "env_name", Value: 1},
+				bson.E{Key: "production", Value: 1},
+				bson.E{Key: "status", Value: 1},
+			},
+			Options: options.Index().SetUnique(false),
+		},
+	}
+
+	_, err := c.Indexes().CreateMany(ctx, mod)
+
+	return err
+}
+
+func (c *EnvDriftColl) Create(args *models.EnvDrift) error {
+	if args == nil {
+		return errors.New("nil env drift")
+	}
+	now := time.Now().Unix()
+	args.DetectTime = now
+	args.UpdateTime = now
+
+	res, err := c.InsertOne(context.TODO(), args)
+	if err != nil {
+		return err
+	}
+	if oid, ok := res.InsertedID.(primitive.ObjectID); ok {
+		args.ID = oid
+	}
+	return nil
+}
+
+func (c *EnvDriftColl) GetByID(id string) (*models.EnvDrift, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(models.EnvDrift)
+	err = c.FindOne(context.TODO(), bson.M{"_id": oid}).Decode(resp)
+	return resp, err
+}
+
+// List lists the drifts of the env, latest first
+func (c *EnvDriftColl) List(opt *EnvDriftListOption) ([]*models.EnvDrift, error) {
+	query := bson.M{
+		"product_name": opt.ProductName,
+		"env_name":     opt.EnvName,
+		"production":   opt.Production,
+	}
+	if opt.ServiceName != "" {
+		query["service_name"] = opt.ServiceName
+	}
+	if len(opt.Status) > 0 {
+		query["status"] = bson.M{"$in": opt.Status}
+	}
+
+	resp := make([]*models.EnvDrift, 0)
+	cursor, err := c.Collection.Find(context.TODO(), query, options.Find().SetSort(bson.D{{Key: "update_time", Value: -1}}))
+	if err != nil {
+		return nil, err
+	}
+	err = cursor.All(context.TODO(), &resp)
+	return resp, err
+}
+
+// UpdateDiffs refreshes the diffs of an open drift which is found again
+func (c *EnvDriftColl) UpdateDiffs(id primitive.ObjectID, diffs []*models.EnvDriftFieldDiff) error {
+	change := bson.M{"$set": bson.M{
+		"diffs":       diffs,
+		"update_time": time.Now().Unix(),
+	}}
+	_, err := c.UpdateByID(context.TODO(), id, change)
+	return err
+}
+
+func (c *EnvDriftColl) UpdateStatus(id primitive.ObjectID, status config.EnvDriftStatus, resolveBy string) error {
+	change := bson.M{"$set": bson.M{
+		"status":       status,
+		"resolve_by":   resolveBy,
+		"resolve_time": time.Now().Unix(),
+	}}
+	_, err := c.UpdateByID(context.TODO(), id, change)
+	return err
+}
+
+// CountOpenByEnv returns the number of open drifts of each env in the project
+func (c *EnvDriftColl) CountOpenByEnv(productName string, production bool) (map[string]int, error) {
+	pipeline := []bson.M{
+		{"$match": bson.M{
+			"product_name": productName,
+			"production":   production,
+			"status":       config.EnvDriftStatusOpen,
+		}},
+		{"$group": bson.M{
+			"_id":   "$env_name",
+			"count": bson.M{"$sum": 1},
+		}},
+	}
+
+	cursor, err := c.Aggregate(context.TODO(), pipeline)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]struct {
+		EnvName string `bson:"_id"`
+		Count   int    `bson:"count"`
+	}, 0)
+	if err := cursor.All(context.TODO(), &result); err != nil {
+		return nil, err
+	}
+
+	resp := make(map[string]int)
+	for _, r := range result {
+		resp[r.EnvName] = r.Count
+	}
+	return resp, nil
+}
+
+func (c *EnvDriftColl) DeleteByEnv(productName, envName string, production bool) error {
+	query := bson.M{
+		"product_name": productName,
+		"env_name":     envName,
+		"production":   production,
+	}
+	_, err := c.DeleteMany(context.TODO(), query)
+	return err
+}
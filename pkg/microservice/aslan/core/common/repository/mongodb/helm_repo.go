@@ -109,6 +109,7 @@ func (c *HelmRepoColl) Update(id string, args *models.HelmRepo) error {
 		"password":     args.Password,
 		"projects":     args.Projects,
 		"enable_proxy": args.EnableProxy,
+		"registry_id":  args.RegistryID,
 		"update_by":    args.UpdateBy,
 		"updated_at":   time.Now().Unix(),
 	}}
@@ -0,0 +1,135 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mongodb
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	mongotool "github.com/koderover/zadig/v2/pkg/tool/mongo"
+)
+
+type ImageSBOMColl struct {
+	*mongo.Collection
+
+	coll string
+}
+
+func NewImageSBOMColl() *ImageSBOMColl {
+	name := models.ImageSBOM{}.TableName()
+	return &ImageSBOMColl{
+		Collection: mongotool.Database(config.MongoDatabase()).Collection(name),
+		coll:       name,
+	}
+}
+
+func (c *ImageSBOMColl) GetCollectionName() string {
+	return c.coll
+}
+
+func (c *ImageSBOMColl) EnsureIndex(ctx context.Context) error {
+	mod := []mongo.IndexModel{
+		{
+			Keys:    bson.D{bson.E{Key: "image", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{
+				bson.E{Key: "packages.name", Value: 1},
+				bson.E{Key: "packages.version", Value: 1},
+			},
+			Options: options.Index().SetUnique(false),
+		},
+	}
+
+	_, err := c.Indexes().CreateMany(ctx, mod)
+
+	return err
+}
+
+// Upsert saves the sbom of an image, an image rebuilt with the same tag replaces the previous sbom
+func (c *ImageSBOMColl) Upsert(args *models.ImageSBOM) error {
+	if args == nil {
+		return errors.New("nil image_sbom args")
+	}
+
+	query := bson.M{"image": args.Image}
+	args.ID = primitive.NilObjectID
+	_, err := c.ReplaceOne(context.TODO(), query, args, options.Replace().SetUpsert(true))
+	return err
+}
+
+func (c *ImageSBOMColl) GetByID(id string) (*models.ImageSBOM, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(models.ImageSBOM)
+	err = c.FindOne(context.TODO(), bson.M{"_id": oid}).Decode(resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *ImageSBOMColl) GetByImage(image string) (*models.ImageSBOM, error) {
+	resp := new(models.ImageSBOM)
+	err := c.FindOne(context.TODO(), bson.M{"image": image}).Decode(resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ListByPackage lists the sboms containing the package, only the first matched package is returned in Packages
+func (c *ImageSBOMColl) ListByPackage(name, version string) ([]*models.ImageSBOM, error) {
+	if name == "" {
+		return nil, errors.New("empty package name")
+	}
+
+	match := bson.M{"name": name}
+	if version != "" {
+		match["version"] = version
+	}
+	query := bson.M{"packages": bson.M{"$elemMatch": match}}
+	projection := bson.M{"packages": bson.M{"$elemMatch": match}}
+	for _, field := range []string{"image", "service_name", "service_module", "project_name", "workflow_name", "task_id", "format", "object_storage_id", "object_key", "created_time"} {
+		projection[field] = 1
+	}
+	opt := options.Find().
+		SetProjection(projection).
+		SetSort(bson.D{{Key: "created_time", Value: -1}})
+
+	resp := make([]*models.ImageSBOM, 0)
+	cursor, err := c.Collection.Find(context.TODO(), query, opt)
+	if err != nil {
+		return nil, err
+	}
+	err = cursor.All(context.TODO(), &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
@@ -70,6 +70,9 @@ type ProductListOptions struct {
 	IstioGrayscaleBaseEnv *string
 
 	Production *bool
+
+	// WithTTL lists the envs with a ttl only
+	WithTTL bool
 }
 
 type projectEnvs struct {
@@ -277,6 +280,9 @@ func (c *ProductColl) List(opt *ProductListOptions) ([]*models.Product, error) {
 	if opt.IstioGrayscaleBaseEnv != nil {
 		query["istio_grayscale.base_env"] = *opt.IstioGrayscaleBaseEnv
 	}
+	if opt.WithTTL {
+		query["ttl"] = bson.M{"$ne": nil}
+	}
 	if opt.Production != nil {
 		if *opt.Production {
 			query["production"] = true
@@ -600,6 +606,55 @@ func (c *ProductColl) UpdateProductAlias(envName, productName, alias string) err
 	return err
 }
 
+func (c *ProductColl) UpdateSignaturePolicy(envName, productName string, policy *models.ImageSignaturePolicy) error {
+	query := bson.M{"env_name": envName, "product_name": productName}
+
+	change := bson.M{"$set": bson.M{
+		"signature_policy": policy,
+	}}
+	_, err := c.UpdateOne(context.TODO(), query, change)
+
+	return err
+}
+
+func (c *ProductColl) UpdateDriftDetection(envName, productName string, detection *models.EnvDriftDetection) error {
+	query := bson.M{"env_name": envName, "product_name": productName}
+
+	change := bson.M{"$set": bson.M{
+		"drift_detection": detection,
+	}}
+	_, err := c.UpdateOne(context.TODO(), query, change)
+
+	return err
+}
+
+func (c *ProductColl) UpdateTTL(envName, productName string, ttl *models.EnvTTL) error {
+	query := bson.M{"env_name": envName, "product_name": productName}
+
+	change := bson.M{"$set": bson.M{
+		"ttl": ttl,
+	}}
+	_, err := c.UpdateOne(context.TODO(), query, change)
+
+	return err
+}
+
+// ListBySigningKey lists the environments trusting the image signing key
+func (c *ProductColl) ListBySigningKey(keyID string) ([]*models.Product, error) {
+	var ret []*models.Product
+	query := bson.M{"signature_policy.trusted_key_ids": keyID}
+
+	cursor, err := c.Collection.Find(context.TODO(), query)
+	if err != nil {
+		return nil, err
+	}
+	err = cursor.All(context.TODO(), &ret)
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
 func (c *ProductColl) UpdateIsPublic(envName, productName string, isPublic bool) error {
 	query := bson.M{"env_name": envName, "product_name": productName}
 	change := bson.M{"$set": bson.M{
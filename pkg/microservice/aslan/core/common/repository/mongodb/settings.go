@@ -100,6 +100,16 @@ func (c *SystemSettingColl) UpdatePrivacySetting(improvementPlan bool) error {
 	return err
 }
 
+func (c *SystemSettingColl) UpdateWorkflowSchedulingSetting(scheduling *models.WorkflowSchedulingSettings) error {
+	id, _ := primitive.ObjectIDFromHex(setting.LocalClusterID)
+	change := bson.M{"$set": bson.M{
+		"workflow_scheduling": scheduling,
+	}}
+	query := bson.M{"_id": id}
+	_, err := c.UpdateOne(context.TODO(), query, change)
+	return err
+}
+
 func (c *SystemSettingColl) InitSystemSettings() error {
 	_, err := c.Get()
 	// if we didn't find anything
@@ -0,0 +1,235 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	mongotool "github.com/koderover/zadig/v2/pkg/tool/mongo"
+)
+
+// testCaseHistoryRetention is how long the test case histories are kept
+const testCaseHistoryRetention = 90 * 24 * time.Hour
+
+type TestCaseHistoryColl struct {
+	*mongo.Collection
+
+	coll string
+}
+
+type TestCaseHistoryListOption struct {
+	ProjectName string
+	TestName    string
+	ClassName   string
+	CaseName    string
+	Limit       int64
+}
+
+func NewTestCaseHistoryColl() *TestCaseHistoryColl {
+	name := models.TestCaseHistory{}.TableName()
+	return &TestCaseHistoryColl{
+		Collection: mongotool.Database(config.MongoDatabase()).Collection(name),
+		coll:       name,
+	}
+}
+
+func (c *TestCaseHistoryColl) GetCollectionName() string {
+	return c.coll
+}
+
+func (c *TestCaseHistoryColl) EnsureIndex(ctx context.Context) error {
+	mod := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				bson.E{Key: "project_name", Value: 1},
+				bson.E{Key: "test_name", Value: 1},
+				bson.E{Key: "create_time", Value: -1},
+			},
+			Options: options.Index().SetUnique(false),
+		},
+		{
+			Keys: bson.D{
+				bson.E{Key: "project_name", Value: 1},
+				bson.E{Key: "test_name", Value: 1},
+				bson.E{Key: "class_name", Value: 1},
+				bson.E{Key: "case_name", Value: 1},
+				bson.E{Key: "create_time", Value: -1},
+			},
+			Options: options.Index().SetUnique(false),
+		},
+		{
+			Keys:    bson.M{"created_at": 1},
+			Options: options.Index().SetExpireAfterSeconds(int32(testCaseHistoryRetention / time.Second)),
+		},
+	}
+
+	_, err := c.Indexes().CreateMany(ctx, mod)
+
+	return err
+}
+
+func (c *TestCaseHistoryColl) BulkCreate(args []*models.TestCaseHistory) error {
+	if len(args) == 0 {
+		return nil
+	}
+
+	var ois []interface{}
+	for _, arg := range args {
+		ois = append(ois, arg)
+	}
+	_, err := c.InsertMany(context.TODO(), ois)
+	return err
+}
+
+func (c *TestCaseHistoryColl) List(opt *TestCaseHistoryListOption) ([]*models.TestCaseHistory, error) {
+	query := bson.M{
+		"project_name": opt.ProjectName,
+		"test_name":    opt.TestName,
+	}
+	if opt.CaseName != "" {
+		query["class_name"] = opt.ClassName
+		query["case_name"] = opt.CaseName
+	}
+
+	findOption := options.Find().SetSort(bson.D{{Key: "create_time", Value: -1}})
+	if opt.Limit > 0 {
+		findOption.SetLimit(opt.Limit)
+	}
+
+	resp := make([]*models.TestCaseHistory, 0)
+	cursor, err := c.Collection.Find(context.TODO(), query, findOption)
+	if err != nil {
+		return nil, err
+	}
+	err = cursor.All(context.TODO(), &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ListStats aggregates the histories of the testing module created after startTime by test case.
+func (c *TestCaseHistoryColl) ListStats(projectName, testName string, startTime int64) ([]*models.TestCaseHistoryStat, error) {
+	skipped := bson.M{"$eq": bson.A{"$status", models.TestCaseStatusSkipped}}
+	failed := bson.M{"$in": bson.A{"$status", bson.A{models.TestCaseStatusFailed, models.TestCaseStatusError}}}
+	pipeline := []bson.M{
+		{
+			"$match": bson.M{
+				"project_name": projectName,
+				"test_name":    testName,
+				"create_time":  bson.M{"$gte": startTime},
+			},
+		},
+		{
+			"$sort": bson.M{"create_time": -1},
+		},
+		// group the results of each case by commit first to find out the commits on which the case is flaky
+		{
+			"$group": bson.M{
+				"_id": bson.M{
+					"class_name": "$class_name",
+					"case_name":  "$case_name",
+					"commit_id":  "$commit_id",
+				},
+				"runs":           bson.M{"$sum": 1},
+				"skipped":        bson.M{"$sum": bson.M{"$cond": bson.A{skipped, 1, 0}}},
+				"failed":         bson.M{"$sum": bson.M{"$cond": bson.A{failed, 1, 0}}},
+				"total_duration": bson.M{"$sum": bson.M{"$cond": bson.A{skipped, 0, "$duration"}}},
+				"max_duration":   bson.M{"$max": bson.M{"$cond": bson.A{skipped, 0, "$duration"}}},
+				"last_status":    bson.M{"$first": "$status"},
+				"last_run_time":  bson.M{"$first": "$create_time"},
+			},
+		},
+		{
+			"$addFields": bson.M{
+				"passed": bson.M{"$subtract": bson.A{"$runs", bson.M{"$add": bson.A{"$skipped", "$failed"}}}},
+			},
+		},
+		{
+			"$sort": bson.M{"last_run_time": -1},
+		},
+		{
+			"$group": bson.M{
+				"_id": bson.M{
+					"class_name": "$_id.class_name",
+					"case_name":  "$_id.case_name",
+				},
+				"runs":           bson.M{"$sum": "$runs"},
+				"passed":         bson.M{"$sum": "$passed"},
+				"failed":         bson.M{"$sum": "$failed"},
+				"skipped":        bson.M{"$sum": "$skipped"},
+				"total_duration": bson.M{"$sum": "$total_duration"},
+				"max_duration":   bson.M{"$max": "$max_duration"},
+				"last_status":    bson.M{"$first": "$last_status"},
+				"last_run_time":  bson.M{"$first": "$last_run_time"},
+				// results without commit can not tell whether the code changed between runs
+				"commits": bson.M{"$sum": bson.M{"$cond": bson.A{
+					bson.M{"$and": bson.A{
+						bson.M{"$ne": bson.A{"$_id.commit_id", ""}},
+						bson.M{"$gt": bson.A{bson.M{"$add": bson.A{"$passed", "$failed"}}, 0}},
+					}}, 1, 0,
+				}}},
+				"flaky_commits": bson.M{"$sum": bson.M{"$cond": bson.A{
+					bson.M{"$and": bson.A{
+						bson.M{"$ne": bson.A{"$_id.commit_id", ""}},
+						bson.M{"$gt": bson.A{"$passed", 0}},
+						bson.M{"$gt": bson.A{"$failed", 0}},
+					}}, 1, 0,
+				}}},
+			},
+		},
+		{
+			"$project": bson.M{
+				"_id":            0,
+				"class_name":     "$_id.class_name",
+				"case_name":      "$_id.case_name",
+				"runs":           1,
+				"passed":         1,
+				"failed":         1,
+				"skipped":        1,
+				"total_duration": 1,
+				"max_duration":   1,
+				"commits":        1,
+				"flaky_commits":  1,
+				"last_status":    1,
+				"last_run_time":  1,
+			},
+		},
+	}
+
+	resp := make([]*models.TestCaseHistoryStat, 0)
+	cursor, err := c.Aggregate(context.TODO(), pipeline, options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		return nil, err
+	}
+	if err := cursor.All(context.TODO(), &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *TestCaseHistoryColl) DeleteByTestName(projectName, testName string) error {
+	_, err := c.DeleteMany(context.TODO(), bson.M{"project_name": projectName, "test_name": testName})
+	return err
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mongodb
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	mongotool "github.com/koderover/zadig/v2/pkg/tool/mongo"
+)
+
+type TestCaseQuarantineColl struct {
+	*mongo.Collection
+
+	coll string
+}
+
+func NewTestCaseQuarantineColl() *TestCaseQuarantineColl {
+	name := models.TestCaseQuarantine{}.TableName()
+	return &TestCaseQuarantineColl{
+		Collection: mongotool.Database(config.MongoDatabase()).Collection(name),
+		coll:       name,
+	}
+}
+
+func (c *TestCaseQuarantineColl) GetCollectionName() string {
+	return c.coll
+}
+
+func (c *TestCaseQuarantineColl) EnsureIndex(ctx context.Context) error {
+	mod := mongo.IndexModel{
+		Keys: bson.D{
+			bson.E{Key: "project_name", Value: 1},
+			bson.E{Key: "test_name", Value: 1},
+			bson.E{Key: "class_name", Value: 1},
+			bson.E{Key: "case_name", Value: 1},
+		},
+		Options: options.Index().SetUnique(true),
+	}
+
+	_, err := c.Indexes().CreateOne(ctx, mod)
+
+	return err
+}
+
+func (c *TestCaseQuarantineColl) Create(args *models.TestCaseQuarantine) error {
+	if args == nil {
+		return errors.New("nil test_case_quarantine args")
+	}
+
+	_, err := c.InsertOne(context.TODO(), args)
+	return err
+}
+
+func (c *TestCaseQuarantineColl) List(projectName, testName string) ([]*models.TestCaseQuarantine, error) {
+	query := bson.M{"project_name": projectName, "test_name": testName}
+	opt := options.Find().SetSort(bson.D{{Key: "create_time", Value: -1}})
+
+	resp := make([]*models.TestCaseQuarantine, 0)
+	cursor, err := c.Collection.Find(context.TODO(), query, opt)
+	if err != nil {
+		return nil, err
+	}
+	err = cursor.All(context.TODO(), &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *TestCaseQuarantineColl) Delete(projectName, testName, id string) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.DeleteOne(context.TODO(), bson.M{"_id": oid, "project_name": projectName, "test_name": testName})
+	return err
+}
+
+func (c *TestCaseQuarantineColl) DeleteByTestName(projectName, testName string) error {
+	_, err := c.DeleteMany(context.TODO(), bson.M{"project_name": projectName, "test_name": testName})
+	return err
+}
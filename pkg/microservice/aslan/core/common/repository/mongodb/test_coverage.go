@@ -0,0 +1,152 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mongodb
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	mongotool "github.com/koderover/zadig/v2/pkg/tool/mongo"
+)
+
+type TestCoverageColl struct {
+	*mongo.Collection
+
+	coll string
+}
+
+func NewTestCoverageColl() *TestCoverageColl {
+	name := models.TestCoverage{}.TableName()
+	return &TestCoverageColl{
+		Collection: mongotool.Database(config.MongoDatabase()).Collection(name),
+		coll:       name,
+	}
+}
+
+func (c *TestCoverageColl) GetCollectionName() string {
+	return c.coll
+}
+
+func (c *TestCoverageColl) EnsureIndex(ctx context.Context) error {
+	mod := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				bson.E{Key: "project_name", Value: 1},
+				bson.E{Key: "test_name", Value: 1},
+				bson.E{Key: "branch", Value: 1},
+				bson.E{Key: "pr", Value: 1},
+				bson.E{Key: "create_time", Value: -1},
+			},
+			Options: options.Index().SetUnique(false),
+		},
+		{
+			Keys: bson.D{
+				bson.E{Key: "workflow_name", Value: 1},
+				bson.E{Key: "task_id", Value: 1},
+			},
+			Options: options.Index().SetUnique(false),
+		},
+	}
+
+	_, err := c.Indexes().CreateMany(ctx, mod)
+
+	return err
+}
+
+func (c *TestCoverageColl) Create(args *models.TestCoverage) error {
+	if args == nil {
+		return errors.New("nil test_coverage args")
+	}
+
+	_, err := c.InsertOne(context.TODO(), args)
+	return err
+}
+
+// GetLatestOfBranch finds the latest coverage of the branch which is not triggered by PR
+func (c *TestCoverageColl) GetLatestOfBranch(projectName, testName, branch string) (*models.TestCoverage, error) {
+	query := bson.M{
+		"project_name": projectName,
+		"test_name":    testName,
+		"branch":       branch,
+		"pr":           0,
+	}
+	opt := options.FindOne().
+		SetSort(bson.D{{Key: "create_time", Value: -1}}).
+		SetProjection(bson.M{"files": 0})
+
+	resp := new(models.TestCoverage)
+	err := c.FindOne(context.TODO(), query, opt).Decode(resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *TestCoverageColl) ListByTask(workflowName string, taskID int64, withFiles bool) ([]*models.TestCoverage, error) {
+	query := bson.M{"workflow_name": workflowName, "task_id": taskID}
+	opt := options.Find().SetSort(bson.D{{Key: "create_time", Value: 1}})
+	if !withFiles {
+		opt.SetProjection(bson.M{"files": 0})
+	}
+
+	resp := make([]*models.TestCoverage, 0)
+	cursor, err := c.Collection.Find(context.TODO(), query, opt)
+	if err != nil {
+		return nil, err
+	}
+	err = cursor.All(context.TODO(), &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ListByTest lists the coverage trend of the testing without file details
+func (c *TestCoverageColl) ListByTest(projectName, testName, branch string, limit int64) ([]*models.TestCoverage, error) {
+	query := bson.M{"project_name": projectName, "test_name": testName}
+	if branch != "" {
+		query["branch"] = branch
+	}
+	opt := options.Find().
+		SetSort(bson.D{{Key: "create_time", Value: -1}}).
+		SetProjection(bson.M{"files": 0})
+	if limit > 0 {
+		opt.SetLimit(limit)
+	}
+
+	resp := make([]*models.TestCoverage, 0)
+	cursor, err := c.Collection.Find(context.TODO(), query, opt)
+	if err != nil {
+		return nil, err
+	}
+	err = cursor.All(context.TODO(), &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *TestCoverageColl) DeleteByTestName(projectName, testName string) error {
+	_, err := c.DeleteMany(context.TODO(), bson.M{"project_name": projectName, "test_name": testName})
+	return err
+}
@@ -18,18 +18,15 @@ package service
 
 import (
 	"database/sql"
-	"fmt"
-
-	_ "github.com/go-sql-driver/mysql"
 
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
-	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
 	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
 	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
 	commonutil "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/util"
 	"github.com/koderover/zadig/v2/pkg/tool/crypto"
+	"github.com/koderover/zadig/v2/pkg/tool/sqldb"
 )
 
 func ListDBInstances(encryptedKey string, log *zap.SugaredLogger) ([]*commonmodels.DBInstance, error) {
@@ -102,25 +99,23 @@ func ValidateDBInstance(args *commonmodels.DBInstance) error {
 	if args == nil {
 		return errors.New("nil DBInstance")
 	}
-	switch args.Type {
-	case config.DBInstanceTypeMySQL, config.DBInstanceTypeMariaDB:
-		return validateMySQLInstance(args)
-	default:
-		return errors.Errorf("invalid db type %s", args.Type)
-	}
-}
-
-func validateMySQLInstance(args *commonmodels.DBInstance) error {
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/?charset=utf8&parseTime=True&loc=Local", args.Username, args.Password, args.Host, args.Port)
-
-	db, err := sql.Open("mysql", dsn)
+	db, err := OpenDBInstance(args)
 	if err != nil {
-		return errors.Errorf("connect mysql failed, err: %s", err)
+		return errors.Errorf("connect %s failed, err: %s", args.Type, err)
 	}
 	defer db.Close()
 
 	if err = db.Ping(); err != nil {
-		return errors.Errorf("ping mysql failed, err: %s", err)
+		return errors.Errorf("ping %s failed, err: %s", args.Type, err)
 	}
 	return nil
 }
+
+// OpenDBInstance opens the db instance with the driver of its type
+func OpenDBInstance(info *commonmodels.DBInstance) (*sql.DB, error) {
+	driver, err := sqldb.GetDriver(string(info.Type))
+	if err != nil {
+		return nil, err
+	}
+	return driver.Open(info.Host, info.Port, info.Username, info.Password, info.Database, info.SSLMode)
+}
@@ -865,6 +865,8 @@ func getJobTaskTplExec(tplcontent string, args *jobTaskNotification) (string, er
 				return "代码扫描"
 			case string(config.JobZadigDistributeImage):
 				return "镜像分发"
+			case string(config.JobImageScan):
+				return "镜像扫描"
 			case string(config.JobK8sBlueGreenDeploy):
 				return "蓝绿部署"
 			case string(config.JobK8sBlueGreenRelease):
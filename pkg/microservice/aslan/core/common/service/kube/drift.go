@@ -0,0 +1,362 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utiljson "k8s.io/apimachinery/pkg/util/json"
+	"sigs.k8s.io/yaml"
+
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models/template"
+	"github.com/koderover/zadig/v2/pkg/setting"
+	"github.com/koderover/zadig/v2/pkg/tool/log"
+	"github.com/koderover/zadig/v2/pkg/util"
+)
+
+// DiffLiveResource compares the resource running in the cluster with the manifest rendered by Zadig.
+// Only the fields defined in the manifest are compared, fields only existing in the live object are ignored
+// since they are usually defaulted by the apiserver or filled by controllers.
+func DiffLiveResource(expected, live *unstructured.Unstructured) []*commonmodels.EnvDriftFieldDiff {
+	diffs := make([]*commonmodels.EnvDriftFieldDiff, 0)
+	if live == nil {
+		return append(diffs, &commonmodels.EnvDriftFieldDiff{Path: ".", Expected: "present", Actual: "absent"})
+	}
+
+	for key, value := range expected.Object {
+		switch key {
+		case "apiVersion", "kind", "status":
+			continue
+		case "stringData":
+			// stringData of secrets is write-only, it's merged into data by the apiserver
+			continue
+		case "data":
+			if expected.GetKind() == setting.Secret {
+				diffs = diffSecretData(value, live.Object[key], diffs)
+				continue
+			}
+			diffs = diffField(key, value, live.Object[key], diffs)
+		case "metadata":
+			// only labels and annotations are compared, the others are identifiers or maintained by the apiserver
+			for _, field := range []string{"labels", "annotations"} {
+				expectedField, _, _ := unstructured.NestedFieldNoCopy(expected.Object, "metadata", field)
+				liveField, _, _ := unstructured.NestedFieldNoCopy(live.Object, "metadata", field)
+				diffs = diffField("metadata."+field, expectedField, liveField, diffs)
+			}
+		case "spec":
+			expectedSpec, ok := value.(map[string]interface{})
+			if !ok {
+				diffs = diffField(key, value, live.Object[key], diffs)
+				continue
+			}
+			liveSpec, _ := live.Object[key].(map[string]interface{})
+			for specKey, specValue := range expectedSpec {
+				// replicas are changed by scaling the workloads in Zadig and hpa, which is not considered as drift
+				if specKey == "replicas" && (expected.GetKind() == setting.Deployment || expected.GetKind() == setting.StatefulSet) {
+					continue
+				}
+				diffs = diffField(key+"."+specKey, specValue, liveSpec[specKey], diffs)
+			}
+		default:
+			diffs = diffField(key, value, live.Object[key], diffs)
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].Path < diffs[j].Path
+	})
+	return diffs
+}
+
+func diffField(path string, expected, live interface{}, diffs []*commonmodels.EnvDriftFieldDiff) []*commonmodels.EnvDriftFieldDiff {
+	switch expectedValue := expected.(type) {
+	case nil:
+		return diffs
+	case map[string]interface{}:
+		liveValue, ok := live.(map[string]interface{})
+		if !ok {
+			if live == nil && len(expectedValue) == 0 {
+				return diffs
+			}
+			return append(diffs, newFieldDiff(path, expected, live))
+		}
+		for key, value := range expectedValue {
+			diffs = diffField(path+"."+key, value, liveValue[key], diffs)
+		}
+		return diffs
+	case []interface{}:
+		liveValue, ok := live.([]interface{})
+		if !ok {
+			if live == nil && len(expectedValue) == 0 {
+				return diffs
+			}
+			return append(diffs, newFieldDiff(path, expected, live))
+		}
+		if len(expectedValue) != len(liveValue) {
+			return append(diffs, newFieldDiff(path, expected, live))
+		}
+		for i := range expectedValue {
+			diffs = diffField(fmt.Sprintf("%s[%d]", path, i), expectedValue[i], liveValue[i], diffs)
+		}
+		return diffs
+	default:
+		if !leafEqual(path, expected, live) {
+			return append(diffs, newFieldDiff(path, expected, live))
+		}
+		return diffs
+	}
+}
+
+// diffSecretData only reports the keys of the changed secret data, the values are not recorded since the drifts
+// are visible to everyone who can view the env
+func diffSecretData(expected, live interface{}, diffs []*commonmodels.EnvDriftFieldDiff) []*commonmodels.EnvDriftFieldDiff {
+	expectedData, _ := expected.(map[string]interface{})
+	liveData, _ := live.(map[string]interface{})
+	keys := make([]string, 0, len(expectedData))
+	for key := range expectedData {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		liveValue, ok := liveData[key]
+		if ok && fmt.Sprint(expectedData[key]) == fmt.Sprint(liveValue) {
+			continue
+		}
+		diff := &commonmodels.EnvDriftFieldDiff{Path: "data." + key, Expected: setting.MaskValue, Actual: "changed"}
+		if !ok {
+			diff.Actual = "absent"
+		}
+		diffs = append(diffs, diff)
+	}
+	return diffs
+}
+
+func leafEqual(path string, expected, live interface{}) bool {
+	if fmt.Sprint(expected) == fmt.Sprint(live) {
+		return true
+	}
+	// quantities are converted to the canonical form by the apiserver, e.g. 0.5 to 500m
+	if strings.Contains(path, ".resources.") {
+		expectedQuantity, err := resource.ParseQuantity(fmt.Sprint(expected))
+		if err != nil {
+			return false
+		}
+		liveQuantity, err := resource.ParseQuantity(fmt.Sprint(live))
+		if err != nil {
+			return false
+		}
+		return expectedQuantity.Cmp(liveQuantity) == 0
+	}
+	return false
+}
+
+func newFieldDiff(path string, expected, live interface{}) *commonmodels.EnvDriftFieldDiff {
+	return &commonmodels.EnvDriftFieldDiff{
+		Path:     path,
+		Expected: driftValueString(expected),
+		Actual:   driftValueString(live),
+	}
+}
+
+func driftValueString(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+// ResolveDriftPath splits the path of a drifted field into the keys and list indexes of the object, the keys may
+// contain dots, e.g. labels like app.kubernetes.io/name, so the path is matched with the keys existing in the object.
+func ResolveDriftPath(obj interface{}, path string) ([]string, bool) {
+	if path == "" {
+		return []string{}, true
+	}
+	switch value := obj.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		// try the longest key first, e.g. a.b before a
+		sort.Slice(keys, func(i, j int) bool {
+			return len(keys[i]) > len(keys[j])
+		})
+		for _, key := range keys {
+			if !strings.HasPrefix(path, key) {
+				continue
+			}
+			rest := path[len(key):]
+			if rest != "" && rest[0] != '.' && rest[0] != '[' {
+				continue
+			}
+			if subPath, ok := ResolveDriftPath(value[key], strings.TrimPrefix(rest, ".")); ok {
+				return append([]string{key}, subPath...), true
+			}
+		}
+	case []interface{}:
+		end := strings.Index(path, "]")
+		if !strings.HasPrefix(path, "[") || end < 0 {
+			return nil, false
+		}
+		index, ok := parseListIndex(path[:end+1])
+		if !ok || index >= len(value) {
+			return nil, false
+		}
+		if subPath, ok := ResolveDriftPath(value[index], strings.TrimPrefix(path[end+1:], ".")); ok {
+			return append([]string{path[:end+1]}, subPath...), true
+		}
+	}
+	return nil, false
+}
+
+// GetDriftField returns the field at the path resolved by ResolveDriftPath
+func GetDriftField(obj interface{}, path []string) (interface{}, bool) {
+	current := obj
+	for _, key := range path {
+		switch value := current.(type) {
+		case map[string]interface{}:
+			next, ok := value[key]
+			if !ok {
+				return nil, false
+			}
+			current = next
+		case []interface{}:
+			index, ok := parseListIndex(key)
+			if !ok || index >= len(value) {
+				return nil, false
+			}
+			current = value[index]
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// setDriftField sets the field at the path, or removes it if remove is true. Missing keys are created,
+// it returns false if the path can not be set, e.g. the list is shorter since the service template is changed.
+func setDriftField(obj map[string]interface{}, path []string, value interface{}, remove bool) bool {
+	if len(path) == 0 {
+		return false
+	}
+	var current interface{} = obj
+	for i, key := range path {
+		last := i == len(path)-1
+		switch parent := current.(type) {
+		case map[string]interface{}:
+			if last {
+				if remove {
+					delete(parent, key)
+				} else {
+					parent[key] = value
+				}
+				return true
+			}
+			next, ok := parent[key]
+			if !ok || next == nil {
+				if remove {
+					return true
+				}
+				next = make(map[string]interface{})
+				parent[key] = next
+			}
+			current = next
+		case []interface{}:
+			index, ok := parseListIndex(key)
+			if !ok || index >= len(parent) {
+				return false
+			}
+			if last {
+				if remove {
+					return false
+				}
+				parent[index] = value
+				return true
+			}
+			current = parent[index]
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+func parseListIndex(key string) (int, bool) {
+	if !strings.HasPrefix(key, "[") || !strings.HasSuffix(key, "]") {
+		return 0, false
+	}
+	index, err := strconv.Atoi(key[1 : len(key)-1])
+	if err != nil || index < 0 {
+		return 0, false
+	}
+	return index, true
+}
+
+// ApplyAdoptedFields overrides the fields of the rendered manifests with the fields adopted into the env,
+// the fields which can not be set any more are skipped.
+func ApplyAdoptedFields(manifest string, svcRender *template.ServiceRender) (string, error) {
+	if svcRender == nil || len(svcRender.AdoptedFields) == 0 {
+		return manifest, nil
+	}
+
+	yamls := util.SplitYaml(manifest)
+	for i, yamlStr := range yamls {
+		obj := &unstructured.Unstructured{}
+		if err := yaml.Unmarshal([]byte(yamlStr), &obj.Object); err != nil {
+			return "", fmt.Errorf("failed to decode yaml, error: %s", err)
+		}
+
+		changed := false
+		for _, field := range svcRender.AdoptedFields {
+			if field.Kind != obj.GetKind() || field.Name != obj.GetName() {
+				continue
+			}
+			var value interface{}
+			if field.Value != "" {
+				// numbers are decoded as int64 if possible
+				if err := utiljson.Unmarshal([]byte(field.Value), &value); err != nil {
+					return "", fmt.Errorf("failed to decode the value of %s/%s field %s, error: %s", field.Kind, field.Name, strings.Join(field.Path, "."), err)
+				}
+			}
+			if setDriftField(obj.Object, field.Path, value, field.Value == "") {
+				changed = true
+			} else {
+				log.Warnf("failed to set the adopted field %s of %s/%s, skip", strings.Join(field.Path, "."), field.Kind, field.Name)
+			}
+		}
+		if !changed {
+			continue
+		}
+
+		updatedYaml, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal %s/%s, error: %s", obj.GetKind(), obj.GetName(), err)
+		}
+		yamls[i] = string(updatedYaml)
+	}
+	return util.JoinYamls(yamls), nil
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models/template"
+	"github.com/koderover/zadig/v2/pkg/setting"
+)
+
+var driftExpectedDeployment = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: nginx
+  labels:
+    app: nginx
+spec:
+  replicas: 1
+  template:
+    spec:
+      containers:
+      - name: nginx
+        image: nginx:1.25
+        resources:
+          limits:
+            cpu: 0.5
+            memory: 1Gi
+`
+
+var driftLiveDeployment = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: nginx
+  namespace: dev
+  uid: 6a1c4b56
+  labels:
+    app: nginx
+    s-product: demo
+spec:
+  replicas: 3
+  progressDeadlineSeconds: 600
+  template:
+    spec:
+      containers:
+      - name: nginx
+        image: nginx:1.26
+        imagePullPolicy: IfNotPresent
+        resources:
+          limits:
+            cpu: 500m
+            memory: 1024Mi
+status:
+  replicas: 3
+`
+
+func TestDiffLiveResource(t *testing.T) {
+	expected, live := &unstructured.Unstructured{}, &unstructured.Unstructured{}
+	assert.NoError(t, yaml.Unmarshal([]byte(driftExpectedDeployment), &expected.Object))
+	assert.NoError(t, yaml.Unmarshal([]byte(driftLiveDeployment), &live.Object))
+
+	diffs := DiffLiveResource(expected, live)
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, "spec.template.spec.containers[0].image", diffs[0].Path)
+	assert.Equal(t, "nginx:1.25", diffs[0].Expected)
+	assert.Equal(t, "nginx:1.26", diffs[0].Actual)
+
+	assert.Empty(t, DiffLiveResource(expected, expected))
+
+	diffs = DiffLiveResource(expected, nil)
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, "absent", diffs[0].Actual)
+}
+
+var driftExpectedSecret = `
+apiVersion: v1
+kind: Secret
+metadata:
+  name: db
+data:
+  password: cGFzc3dvcmQ=
+  user: cm9vdA==
+`
+
+var driftLiveSecret = `
+apiVersion: v1
+kind: Secret
+metadata:
+  name: db
+data:
+  password: czNjcjN0
+`
+
+func TestDiffLiveSecret(t *testing.T) {
+	expected, live := &unstructured.Unstructured{}, &unstructured.Unstructured{}
+	assert.NoError(t, yaml.Unmarshal([]byte(driftExpectedSecret), &expected.Object))
+	assert.NoError(t, yaml.Unmarshal([]byte(driftLiveSecret), &live.Object))
+
+	diffs := DiffLiveResource(expected, live)
+	assert.Len(t, diffs, 2)
+	assert.Equal(t, "data.password", diffs[0].Path)
+	assert.Equal(t, setting.MaskValue, diffs[0].Expected)
+	assert.Equal(t, "changed", diffs[0].Actual)
+	assert.Equal(t, "data.user", diffs[1].Path)
+	assert.Equal(t, "absent", diffs[1].Actual)
+}
+
+func TestResolveDriftPath(t *testing.T) {
+	obj := map[string]interface{}{}
+	assert.NoError(t, yaml.Unmarshal([]byte(`
+metadata:
+  labels:
+    app: nginx
+    app.kubernetes.io/name: nginx
+spec:
+  template:
+    spec:
+      containers:
+      - name: nginx
+        resources:
+          limits:
+            cpu: 500m
+`), &obj))
+
+	path, ok := ResolveDriftPath(obj, "metadata.labels.app.kubernetes.io/name")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"metadata", "labels", "app.kubernetes.io/name"}, path)
+
+	path, ok = ResolveDriftPath(obj, "spec.template.spec.containers[0].resources.limits.cpu")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"spec", "template", "spec", "containers", "[0]", "resources", "limits", "cpu"}, path)
+	value, found := GetDriftField(obj, path)
+	assert.True(t, found)
+	assert.Equal(t, "500m", value)
+
+	_, ok = ResolveDriftPath(obj, "spec.template.spec.containers[1].name")
+	assert.False(t, ok)
+}
+
+func TestApplyAdoptedFields(t *testing.T) {
+	svcRender := &template.ServiceRender{
+		AdoptedFields: []*template.AdoptedField{
+			{Kind: "Deployment", Name: "nginx", Path: []string{"spec", "template", "spec", "containers", "[0]", "resources", "limits", "memory"}, Value: `"2Gi"`},
+			{Kind: "Deployment", Name: "nginx", Path: []string{"spec", "progressDeadlineSeconds"}, Value: `600`},
+			{Kind: "Deployment", Name: "nginx", Path: []string{"metadata", "labels", "app"}},
+			{Kind: "Deployment", Name: "other", Path: []string{"spec", "paused"}, Value: `true`},
+		},
+	}
+	manifest, err := ApplyAdoptedFields(driftExpectedDeployment, svcRender)
+	assert.NoError(t, err)
+
+	obj := map[string]interface{}{}
+	assert.NoError(t, yaml.Unmarshal([]byte(manifest), &obj))
+	value, _ := GetDriftField(obj, svcRender.AdoptedFields[0].Path)
+	assert.Equal(t, "2Gi", value)
+	value, _ = GetDriftField(obj, svcRender.AdoptedFields[1].Path)
+	assert.EqualValues(t, 600, value)
+	_, found := GetDriftField(obj, svcRender.AdoptedFields[2].Path)
+	assert.False(t, found)
+	_, found = GetDriftField(obj, []string{"spec", "paused"})
+	assert.False(t, found)
+
+	manifest, err = ApplyAdoptedFields(driftExpectedDeployment, &template.ServiceRender{})
+	assert.NoError(t, err)
+	assert.Equal(t, driftExpectedDeployment, manifest)
+}
@@ -583,7 +583,7 @@ func DeploySingleHelmRelease(product *commonmodels.Product, productSvc *commonmo
 			return fmt.Errorf("failed to gene merged values, err: %s", err)
 		}
 
-		chartRepo, err := commonrepo.NewHelmRepoColl().Find(&commonrepo.HelmRepoFindOption{RepoName: chartInfo.ChartRepo})
+		chartRepo, err := commonutil.FindChartRepo(chartInfo.ChartRepo, product.ProductName)
 		if err != nil {
 			return fmt.Errorf("failed to query chart-repo info, productName: %s, repoName: %s", product.ProductName, chartInfo.ChartRepo)
 		}
@@ -688,7 +688,7 @@ func DeployMultiHelmRelease(productResp *commonmodels.Product, helmClient *helmt
 		}()
 
 		if !param.ProdService.FromZadig() {
-			chartRepo, err := commonrepo.NewHelmRepoColl().Find(&commonrepo.HelmRepoFindOption{RepoName: param.RenderChart.ChartRepo})
+			chartRepo, err := commonutil.FindChartRepo(param.RenderChart.ChartRepo, productResp.ProductName)
 			if err != nil {
 				return fmt.Errorf("failed to query chart-repo info, productName: %s, repoName: %s", productResp.ProductName, param.RenderChart.ChartRepo)
 			}
@@ -0,0 +1,54 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube
+
+import (
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	"github.com/koderover/zadig/v2/pkg/setting"
+	"github.com/koderover/zadig/v2/pkg/tool/kustomize"
+)
+
+// ServiceTemplateYaml returns the origin yaml of the service template in the environment,
+// kustomize services are built with the overlay of the environment
+func ServiceTemplateYaml(svcTmpl *commonmodels.Service, envName string) (string, error) {
+	if svcTmpl.Source != setting.SourceFromKustomize || svcTmpl.Kustomize == nil {
+		return svcTmpl.Yaml, nil
+	}
+	return BuildKustomizeYaml(svcTmpl.Kustomize, envName)
+}
+
+// BuildKustomizeYaml builds the base kustomization if no overlay is configured for the environment
+func BuildKustomizeYaml(config *commonmodels.KustomizeConfig, envName string) (string, error) {
+	files := make(map[string][]byte, len(config.Files))
+	for _, file := range config.Files {
+		files[file.Path] = []byte(file.Content)
+	}
+
+	dir := config.BasePath
+	var images []*kustomize.Image
+	if overlay := config.GetOverlay(envName); overlay != nil {
+		dir = overlay.Path
+		for _, image := range overlay.Images {
+			images = append(images, &kustomize.Image{
+				Name:    image.Name,
+				NewName: image.NewName,
+				NewTag:  image.NewTag,
+			})
+		}
+	}
+	return kustomize.Build(files, dir, images)
+}
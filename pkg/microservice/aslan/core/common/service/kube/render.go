@@ -355,18 +355,30 @@ func FetchCurrentAppliedYaml(option *GeneSvcYamlOption) (string, int, error) {
 		return "", 0, errors.Wrapf(err, "failed to find service %s with revision %d", option.ServiceName, curProductSvc.Revision)
 	}
 
-	fullRenderedYaml, err := RenderServiceYaml(prodSvcTemplate.Yaml, option.ProductName, option.ServiceName, curProductSvc.GetServiceRender())
+	originYaml, err := ServiceTemplateYaml(prodSvcTemplate, productInfo.EnvName)
+	if err != nil {
+		return "", 0, err
+	}
+	fullRenderedYaml, err := RenderServiceYaml(originYaml, option.ProductName, option.ServiceName, curProductSvc.GetServiceRender())
 	if err != nil {
 		return "", 0, err
 	}
 	fullRenderedYaml = ParseSysKeys(productInfo.Namespace, productInfo.EnvName, option.ProductName, option.ServiceName, fullRenderedYaml)
+	fullRenderedYaml, err = ApplyAdoptedFields(fullRenderedYaml, curProductSvc.GetServiceRender())
+	if err != nil {
+		return "", 0, err
+	}
 	mergedContainers := mergeContainers(prodSvcTemplate.Containers, curProductSvc.Containers)
 	fullRenderedYaml, _, err = ReplaceWorkloadImages(fullRenderedYaml, mergedContainers)
 	return fullRenderedYaml, 0, nil
 }
 
 func fetchImportedManifests(option *GeneSvcYamlOption, productInfo *models.Product, serviceTmp *models.Service, svcRender *template.ServiceRender) (string, []*WorkloadResource, error) {
-	fullRenderedYaml, err := RenderServiceYaml(serviceTmp.Yaml, option.ProductName, option.ServiceName, svcRender)
+	originYaml, err := ServiceTemplateYaml(serviceTmp, productInfo.EnvName)
+	if err != nil {
+		return "", nil, err
+	}
+	fullRenderedYaml, err := RenderServiceYaml(originYaml, option.ProductName, option.ServiceName, svcRender)
 	if err != nil {
 		return "", nil, err
 	}
@@ -526,11 +538,19 @@ func GenerateRenderedYaml(option *GeneSvcYamlOption) (string, int, []*WorkloadRe
 
 	serviceRender.OverrideYaml.YamlContent = mergedYaml
 
-	fullRenderedYaml, err := RenderServiceYaml(latestSvcTemplate.Yaml, option.ProductName, option.ServiceName, serviceRender)
+	originYaml, err := ServiceTemplateYaml(latestSvcTemplate, productInfo.EnvName)
+	if err != nil {
+		return "", 0, nil, err
+	}
+	fullRenderedYaml, err := RenderServiceYaml(originYaml, option.ProductName, option.ServiceName, serviceRender)
 	if err != nil {
 		return "", 0, nil, err
 	}
 	fullRenderedYaml = ParseSysKeys(productInfo.Namespace, productInfo.EnvName, option.ProductName, option.ServiceName, fullRenderedYaml)
+	fullRenderedYaml, err = ApplyAdoptedFields(fullRenderedYaml, serviceRender)
+	if err != nil {
+		return "", 0, nil, err
+	}
 
 	// service may not be deployed in environment, we need to extract containers again, since image related variables may be changed
 	latestSvcTemplate.KubeYamls = util.SplitYaml(fullRenderedYaml)
@@ -569,12 +589,21 @@ func RenderEnvService(prod *commonmodels.Product, serviceRender *template.Servic
 
 func RenderEnvServiceWithTempl(prod *commonmodels.Product, serviceRender *template.ServiceRender, service *commonmodels.ProductService, svcTmpl *commonmodels.Service) (yaml string, err error) {
 	// Note only the keys in TemplateService.ServiceVar can work
-	parsedYaml, err := RenderServiceYaml(svcTmpl.Yaml, prod.ProductName, svcTmpl.ServiceName, serviceRender)
+	originYaml, err := ServiceTemplateYaml(svcTmpl, prod.EnvName)
+	if err != nil {
+		log.Errorf("failed to build service yaml, err: %s", err)
+		return "", err
+	}
+	parsedYaml, err := RenderServiceYaml(originYaml, prod.ProductName, svcTmpl.ServiceName, serviceRender)
 	if err != nil {
 		log.Errorf("failed to render service yaml, err: %s", err)
 		return "", err
 	}
 	parsedYaml = ParseSysKeys(prod.Namespace, prod.EnvName, prod.ProductName, service.ServiceName, parsedYaml)
+	parsedYaml, err = ApplyAdoptedFields(parsedYaml, serviceRender)
+	if err != nil {
+		return "", err
+	}
 	parsedYaml, _, err = ReplaceWorkloadImages(parsedYaml, service.Containers)
 	return parsedYaml, err
 }
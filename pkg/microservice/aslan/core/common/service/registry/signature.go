@@ -0,0 +1,89 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"fmt"
+
+	"github.com/docker/distribution"
+	_ "github.com/docker/distribution/manifest/manifestlist"
+	_ "github.com/docker/distribution/manifest/ocischema"
+	"github.com/docker/distribution/registry/client"
+	"github.com/opencontainers/go-digest"
+	"go.uber.org/zap"
+
+	"github.com/koderover/zadig/v2/pkg/tool/cosign"
+)
+
+type GetImageSignaturesOption struct {
+	Endpoint
+	TLSEnabled bool
+	TLSCert    string
+	// RepoName is the repository path of the image in the registry
+	RepoName string
+	// Reference is the tag or the digest of the image
+	Reference string
+}
+
+// GetImageSignatures returns the manifest digest of the image and the cosign signatures attached to it,
+// the registry is accessed with the docker registry v2 api no matter which provider it is.
+func GetImageSignatures(option GetImageSignaturesOption, log *zap.SugaredLogger) (string, []*cosign.Signature, error) {
+	s := &v2RegistryService{EnableHTTPS: option.TLSEnabled, CustomCert: option.TLSCert}
+	cli, err := s.createClient(option.Endpoint, log)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to connect to registry %s: %s", option.Addr, err)
+	}
+	repo, err := cli.getRepository(option.RepoName)
+	if err != nil {
+		return "", nil, err
+	}
+	manifestService, err := repo.Manifests(cli.ctx)
+	if err != nil {
+		return "", nil, err
+	}
+
+	imageDigest, err := digest.Parse(option.Reference)
+	if err != nil {
+		if _, err := manifestService.Get(cli.ctx, "", distribution.WithTag(option.Reference), client.ReturnContentDigest(&imageDigest)); err != nil {
+			return "", nil, fmt.Errorf("failed to get manifest of %s:%s: %s", option.RepoName, option.Reference, err)
+		}
+	}
+
+	signatureTag, err := cosign.SignatureTag(imageDigest.String())
+	if err != nil {
+		return "", nil, err
+	}
+	m, err := manifestService.Get(cli.ctx, "", distribution.WithTag(signatureTag))
+	if err != nil {
+		log.Infof("no signature found for %s@%s: %s", option.RepoName, imageDigest, err)
+		return imageDigest.String(), nil, nil
+	}
+
+	signatures := make([]*cosign.Signature, 0)
+	for _, layer := range m.References() {
+		base64Signature, ok := layer.Annotations[cosign.SignatureAnnotation]
+		if !ok {
+			continue
+		}
+		payload, err := repo.Blobs(cli.ctx).Get(cli.ctx, layer.Digest)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to get signature payload %s: %s", layer.Digest, err)
+		}
+		signatures = append(signatures, &cosign.Signature{Payload: payload, Base64Signature: base64Signature})
+	}
+	return imageDigest.String(), signatures, nil
+}
@@ -516,6 +516,7 @@ func (s *Service) UpdateWebhookCommentForWorkflowV4(task *models.WorkflowTask, l
 			if task.Type == config.WorkflowTaskTypeTesting {
 				scmTask.TestName = task.WorkflowDisplayName
 			}
+			scmTask.Coverages = getNotificationCoverages(task.WorkflowName, task.TaskID, logger)
 
 			tasks = append(tasks, scmTask)
 			taskExist = true
@@ -547,6 +548,7 @@ func (s *Service) UpdateWebhookCommentForWorkflowV4(task *models.WorkflowTask, l
 		if task.Type == config.WorkflowTaskTypeTesting {
 			scmTask.TestName = task.WorkflowDisplayName
 		}
+		scmTask.Coverages = getNotificationCoverages(task.WorkflowName, task.TaskID, logger)
 
 		tasks = append(tasks, scmTask)
 
@@ -570,6 +572,23 @@ func (s *Service) UpdateWebhookCommentForWorkflowV4(task *models.WorkflowTask, l
 	return nil
 }
 
+func getNotificationCoverages(workflowName string, taskID int64, logger *zap.SugaredLogger) []*models.NotificationCoverage {
+	coverages, err := mongodb.NewTestCoverageColl().ListByTask(workflowName, taskID, false)
+	if err != nil {
+		logger.Warnf("failed to list coverage of workflow %s task %d: %v", workflowName, taskID, err)
+		return nil
+	}
+	resp := make([]*models.NotificationCoverage, 0, len(coverages))
+	for _, coverage := range coverages {
+		resp = append(resp, &models.NotificationCoverage{
+			Name:     coverage.TestName,
+			Coverage: coverage.Coverage,
+			Delta:    coverage.Delta,
+		})
+	}
+	return resp
+}
+
 func (s *Service) UpdatePipelineWebhookComment(task *task.Task, logger *zap.SugaredLogger) (err error) {
 	if task.TaskArgs == nil {
 		logger.Warnf("taskArgs of %s is nil", task.PipelineName)
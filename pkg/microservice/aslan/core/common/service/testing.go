@@ -80,6 +80,18 @@ func Delete(name, productName string, log *zap.SugaredLogger) error {
 		log.Errorf("[TestTaskStat.Delete] %s error: %v", name, err)
 	}
 
+	if err := mongodb.NewTestCaseHistoryColl().DeleteByTestName(productName, name); err != nil {
+		log.Errorf("[TestCaseHistory.Delete] %s error: %v", name, err)
+	}
+
+	if err := mongodb.NewTestCaseQuarantineColl().DeleteByTestName(productName, name); err != nil {
+		log.Errorf("[TestCaseQuarantine.Delete] %s error: %v", name, err)
+	}
+
+	if err := mongodb.NewTestCoverageColl().DeleteByTestName(productName, name); err != nil {
+		log.Errorf("[TestCoverage.Delete] %s error: %v", name, err)
+	}
+
 	pipelineName := fmt.Sprintf("%s-%s", name, "job")
 	counterName := fmt.Sprintf(setting.TestTaskFmt, pipelineName)
 	if err := mongodb.NewCounterColl().Delete(counterName); err != nil {
@@ -0,0 +1,288 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflowcontroller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/workflowcontroller/jobcontroller"
+)
+
+type dagNode struct {
+	job     *commonmodels.JobTask
+	stage   *commonmodels.StageTask
+	deps    []*dagNode
+	started bool
+	done    bool
+}
+
+func stagesHaveNeeds(stages []*commonmodels.StageTask) bool {
+	for _, stage := range stages {
+		for _, job := range stage.Jobs {
+			if len(job.Needs) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func jobOriginName(job *commonmodels.JobTask) string {
+	if job.OriginName != "" {
+		return job.OriginName
+	}
+	return job.Name
+}
+
+// buildJobDAG resolves the dependencies of every job task.
+// a job with needs depends on all the job tasks split from the needed jobs,
+// a job without needs keeps the stage semantics: it depends on the previous job in a serial stage,
+// or on all the jobs of the previous stage.
+func buildJobDAG(stages []*commonmodels.StageTask) []*dagNode {
+	nodes := make([]*dagNode, 0)
+	originNodes := make(map[string][]*dagNode)
+	stageNodes := make([][]*dagNode, 0, len(stages))
+	for _, stage := range stages {
+		current := make([]*dagNode, 0, len(stage.Jobs))
+		for _, job := range stage.Jobs {
+			node := &dagNode{
+				job:   job,
+				stage: stage,
+				// should skip passed job when workflow task be restarted
+				done: job.Status == config.StatusPassed || job.Status == config.StatusSkipped,
+			}
+			current = append(current, node)
+			nodes = append(nodes, node)
+			originNodes[jobOriginName(job)] = append(originNodes[jobOriginName(job)], node)
+		}
+		stageNodes = append(stageNodes, current)
+	}
+
+	var previous []*dagNode
+	for i, stage := range stages {
+		for j, node := range stageNodes[i] {
+			switch {
+			case len(node.job.Needs) > 0:
+				for _, need := range node.job.Needs {
+					node.deps = append(node.deps, originNodes[need]...)
+				}
+			case !stage.Parallel && j > 0:
+				node.deps = []*dagNode{stageNodes[i][j-1]}
+			default:
+				node.deps = previous
+			}
+		}
+		if len(stageNodes[i]) > 0 {
+			previous = stageNodes[i]
+		}
+	}
+	return nodes
+}
+
+func dagJobSucceeded(status config.Status) bool {
+	return status == config.StatusPassed || status == config.StatusUnstable || status == config.StatusSkipped
+}
+
+func (n *dagNode) ready() bool {
+	for _, dep := range n.deps {
+		if !dep.done {
+			return false
+		}
+	}
+	return true
+}
+
+// runStagesDAG starts every job as soon as all the jobs it depends on are done, the stages are kept only for display.
+// the scheduler stops starting new jobs once a job is not succeeded or the workflow is cancelled, and waits for the running ones.
+func runStagesDAG(ctx context.Context, stages []*commonmodels.StageTask, workflowCtx *commonmodels.WorkflowTaskCtx, concurrency int, logger *zap.SugaredLogger, ack func()) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	nodes := buildJobDAG(stages)
+
+	started := make(map[*commonmodels.StageTask]bool)
+	finished := make(map[*commonmodels.StageTask]bool)
+	for _, stage := range stages {
+		if stage.Status == config.StatusPassed || stage.Status == config.StatusSkipped {
+			started[stage] = true
+			finished[stage] = true
+		}
+	}
+
+	stageDone := func(stage *commonmodels.StageTask) bool {
+		for _, node := range nodes {
+			if node.stage == stage && !node.done {
+				return false
+			}
+		}
+		return true
+	}
+	originDone := func(originName string) bool {
+		for _, node := range nodes {
+			if jobOriginName(node.job) == originName && !node.done {
+				return false
+			}
+		}
+		return true
+	}
+
+	// every stage has its own context to apply the stage timeout
+	stageCtxs := make(map[*commonmodels.StageTask]context.Context)
+	stageCancels := make(map[*commonmodels.StageTask]context.CancelFunc)
+	defer func() {
+		for _, cancel := range stageCancels {
+			cancel()
+		}
+	}()
+	stageCtx := func(stage *commonmodels.StageTask) context.Context {
+		if c, ok := stageCtxs[stage]; ok {
+			return c
+		}
+		return ctx
+	}
+
+	// startStage returns false if the stage should not run its jobs
+	stopped := false
+	startStage := func(stage *commonmodels.StageTask) bool {
+		if started[stage] {
+			return stage.Status == config.StatusRunning
+		}
+		started[stage] = true
+		if stage.When != "" {
+			run, err := jobcontroller.EvaluateCondition(stage.When, workflowCtx)
+			if err != nil {
+				stage.Status = config.StatusFailed
+				stage.Error = err.Error()
+				stage.StartTime = time.Now().Unix()
+				stage.EndTime = stage.StartTime
+				finished[stage] = true
+				stopped = true
+				logger.Errorf("finish stage: %s,status: %s error: %s", stage.Name, stage.Status, stage.Error)
+				ack()
+				return false
+			}
+			if !run {
+				skipStage(stage, fmt.Sprintf("stage condition %s is evaluated to false", stage.When))
+				finished[stage] = true
+				for _, node := range nodes {
+					if node.stage == stage {
+						node.done = true
+					}
+				}
+				logger.Infof("skip stage: %s,status: %s", stage.Name, stage.Status)
+				ack()
+				return false
+			}
+		}
+		stage.Status = config.StatusRunning
+		stage.StartTime = time.Now().Unix()
+		stageCtxs[stage], stageCancels[stage] = withStageTimeout(ctx, stage)
+		logger.Infof("start stage: %s,status: %s", stage.Name, stage.Status)
+		ack()
+		return true
+	}
+	finishStage := func(stage *commonmodels.StageTask) {
+		updateStageStatus(stageCtx(stage), stage)
+		stage.EndTime = time.Now().Unix()
+		finished[stage] = true
+		logger.Infof("finish stage: %s,status: %s", stage.Name, stage.Status)
+		ack()
+	}
+
+	// the other job tasks of a matrix without fail fast still run after one of them fails
+	draining := make(map[string]bool)
+	cancelled := false
+	doneChan := make(chan *dagNode, len(nodes))
+	running := 0
+	for {
+		if !cancelled {
+			select {
+			case <-ctx.Done():
+				cancelled = true
+				stopped = true
+			default:
+			}
+		}
+		// a skipped stage marks its jobs as done, which may make other jobs ready, so scan again until nothing changes
+		for rescan := !cancelled; rescan; {
+			rescan = false
+			for _, node := range nodes {
+				if cancelled || running >= concurrency {
+					break
+				}
+				if node.done || node.started || !node.ready() {
+					continue
+				}
+				if stopped && !draining[jobOriginName(node.job)] {
+					continue
+				}
+				if !startStage(node.stage) {
+					if node.stage.Status == config.StatusSkipped {
+						rescan = true
+					}
+					continue
+				}
+				node.started = true
+				running++
+				go func(ctx context.Context, node *dagNode) {
+					jobcontroller.RunJob(ctx, node.job, workflowCtx, logger, ack)
+					doneChan <- node
+				}(stageCtx(node.stage), node)
+			}
+		}
+		if running == 0 {
+			break
+		}
+
+		node := <-doneChan
+		running--
+		node.done = true
+		if !dagJobSucceeded(node.job.Status) {
+			if jobcontroller.MatrixContinueOnError(node.job) {
+				draining[jobOriginName(node.job)] = true
+			}
+			stopped = true
+		}
+		// set IMAGES workflow variable after all the job tasks split from the same job are done
+		if originName := jobOriginName(node.job); originDone(originName) {
+			if images, ok := collectJobImages(workflowCtx)[originName]; ok {
+				setJobImages(workflowCtx, originName, images)
+			}
+		}
+		if stageDone(node.stage) && !finished[node.stage] {
+			finishStage(node.stage)
+		}
+	}
+
+	for _, node := range nodes {
+		if !node.done && !stopped {
+			logger.Errorf("job %s can not be scheduled, its dependencies are never done", node.job.Name)
+			break
+		}
+	}
+	for _, stage := range stages {
+		if started[stage] && !finished[stage] {
+			finishStage(stage)
+		}
+	}
+}
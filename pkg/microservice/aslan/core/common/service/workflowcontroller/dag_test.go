@@ -0,0 +1,134 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflowcontroller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+)
+
+func TestBuildJobDAG(t *testing.T) {
+	tests := []struct {
+		name   string
+		stages []*commonmodels.StageTask
+		// done are the jobs finished before checking which jobs are ready
+		done  []string
+		ready map[string]bool
+	}{
+		{
+			name: "job starts once its needs pass while an unrelated job of the previous stage is running",
+			stages: []*commonmodels.StageTask{
+				{Name: "build", Parallel: true, Jobs: []*commonmodels.JobTask{{Name: "build-a"}, {Name: "build-b"}}},
+				{Name: "deploy", Parallel: true, Jobs: []*commonmodels.JobTask{{Name: "deploy-a", Needs: []string{"build-a"}}, {Name: "deploy-b"}}},
+			},
+			done:  []string{"build-a"},
+			ready: map[string]bool{"build-b": true, "deploy-a": true, "deploy-b": false},
+		},
+		{
+			name: "job without needs keeps the stage semantics",
+			stages: []*commonmodels.StageTask{
+				{Name: "build", Jobs: []*commonmodels.JobTask{{Name: "build-a"}, {Name: "build-b"}}},
+				{Name: "deploy", Jobs: []*commonmodels.JobTask{{Name: "deploy-a"}}},
+			},
+			ready: map[string]bool{"build-a": true, "build-b": false, "deploy-a": false},
+		},
+		{
+			name: "job needs all the job tasks split from the needed job",
+			stages: []*commonmodels.StageTask{
+				{Name: "build", Parallel: true, Jobs: []*commonmodels.JobTask{
+					{Name: "build-0", OriginName: "build"},
+					{Name: "build-1", OriginName: "build"},
+				}},
+				{Name: "deploy", Jobs: []*commonmodels.JobTask{{Name: "deploy", Needs: []string{"build"}}}},
+			},
+			done:  []string{"build-0"},
+			ready: map[string]bool{"build-1": true, "deploy": false},
+		},
+		{
+			name: "passed jobs of a restarted task are done",
+			stages: []*commonmodels.StageTask{
+				{Name: "build", Jobs: []*commonmodels.JobTask{{Name: "build", Status: config.StatusPassed}}},
+				{Name: "deploy", Jobs: []*commonmodels.JobTask{{Name: "deploy", Needs: []string{"build"}}}},
+			},
+			ready: map[string]bool{"deploy": true},
+		},
+		{
+			name: "cycle is never ready",
+			stages: []*commonmodels.StageTask{
+				{Name: "build", Parallel: true, Jobs: []*commonmodels.JobTask{
+					{Name: "build-a", Needs: []string{"build-b"}},
+					{Name: "build-b", Needs: []string{"build-a"}},
+				}},
+			},
+			ready: map[string]bool{"build-a": false, "build-b": false},
+		},
+		{
+			name: "need on a job in a later stage waits for it",
+			stages: []*commonmodels.StageTask{
+				{Name: "build", Jobs: []*commonmodels.JobTask{{Name: "build", Needs: []string{"deploy"}}}},
+				{Name: "deploy", Jobs: []*commonmodels.JobTask{{Name: "deploy"}}},
+			},
+			ready: map[string]bool{"build": false, "deploy": false},
+		},
+		{
+			name: "self need is never ready",
+			stages: []*commonmodels.StageTask{
+				{Name: "build", Jobs: []*commonmodels.JobTask{{Name: "build", Needs: []string{"build"}}}},
+			},
+			ready: map[string]bool{"build": false},
+		},
+		{
+			name: "need on an unknown job adds no dependency",
+			stages: []*commonmodels.StageTask{
+				{Name: "build", Jobs: []*commonmodels.JobTask{{Name: "build"}}},
+				{Name: "deploy", Jobs: []*commonmodels.JobTask{{Name: "deploy", Needs: []string{"unknown"}}}},
+			},
+			ready: map[string]bool{"build": true, "deploy": true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodes := buildJobDAG(tt.stages)
+			nodeMap := make(map[string]*dagNode, len(nodes))
+			for _, node := range nodes {
+				nodeMap[node.job.Name] = node
+			}
+			for _, name := range tt.done {
+				nodeMap[name].done = true
+			}
+			for name, ready := range tt.ready {
+				assert.Equal(t, ready, nodeMap[name].ready(), name)
+			}
+		})
+	}
+}
+
+func TestStagesHaveNeeds(t *testing.T) {
+	stages := []*commonmodels.StageTask{
+		{Name: "build", Jobs: []*commonmodels.JobTask{{Name: "build"}}},
+		{Name: "deploy", Jobs: []*commonmodels.JobTask{{Name: "deploy"}}},
+	}
+	assert.False(t, stagesHaveNeeds(stages))
+
+	stages[1].Jobs[0].Needs = []string{"build"}
+	assert.True(t, stagesHaveNeeds(stages))
+}
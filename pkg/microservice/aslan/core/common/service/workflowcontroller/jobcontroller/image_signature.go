@@ -0,0 +1,163 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobcontroller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/docker/distribution/reference"
+	"go.uber.org/zap"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/registry"
+	commonutil "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/util"
+	"github.com/koderover/zadig/v2/pkg/tool/cosign"
+	"github.com/koderover/zadig/v2/pkg/types/step"
+)
+
+// verifyImageSignatures checks the images against the signature policy of the environment,
+// an error is returned if any image is not signed by a key trusted by the environment.
+func verifyImageSignatures(env *models.Product, images []string, log *zap.SugaredLogger) error {
+	if env.SignaturePolicy == nil || !env.SignaturePolicy.Enabled {
+		return nil
+	}
+
+	keys, err := mongodb.NewImageSigningKeyColl().ListByIDs(context.Background(), env.SignaturePolicy.TrustedKeyIDs)
+	if err != nil {
+		return fmt.Errorf("failed to find the trusted keys of environment %s: %s", env.EnvName, err)
+	}
+	publicKeys := make([]string, 0, len(keys))
+	for _, key := range keys {
+		publicKeys = append(publicKeys, key.PublicKey)
+	}
+
+	registries, err := mongodb.NewRegistryNamespaceColl().FindAll(&mongodb.FindRegOps{})
+	if err != nil {
+		return fmt.Errorf("failed to list registries: %s", err)
+	}
+
+	failed := make([]string, 0)
+	verified := make(map[string]bool)
+	// only the registries hosting the images are decoded, a broken credential of an unrelated registry does not block the deployment
+	decoded := make(map[*models.RegistryNamespace]error)
+	for _, image := range images {
+		if image == "" || verified[image] {
+			continue
+		}
+		verified[image] = true
+		if err := verifyImageSignature(image, publicKeys, registries, decoded, log); err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %s", image, err))
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("image signature verification failed for environment %s:\n%s", env.EnvName, strings.Join(failed, "\n"))
+	}
+	return nil
+}
+
+func verifyImageSignature(image string, publicKeys []string, registries []*models.RegistryNamespace, decoded map[*models.RegistryNamespace]error, log *zap.SugaredLogger) error {
+	named, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return fmt.Errorf("invalid image: %s", err)
+	}
+	named = reference.TagNameOnly(named)
+	option := registry.GetImageSignaturesOption{
+		RepoName: reference.Path(named),
+	}
+	if digested, ok := named.(reference.Digested); ok {
+		option.Reference = digested.Digest().String()
+	} else if tagged, ok := named.(reference.Tagged); ok {
+		option.Reference = tagged.Tag()
+	}
+
+	domain := reference.Domain(named)
+	if reg := findImageRegistry(domain, option.RepoName, registries); reg != nil {
+		// the registry is decoded in place, so it is decoded only once for all the images it hosts
+		decodeErr, ok := decoded[reg]
+		if !ok {
+			_, decodeErr = commonutil.DecodeRegistry(reg)
+			decoded[reg] = decodeErr
+		}
+		if decodeErr != nil {
+			return fmt.Errorf("failed to get the credential of registry %s: %s", reg.RegAddr, decodeErr)
+		}
+		option.Endpoint = registry.Endpoint{Addr: reg.RegAddr, Ak: reg.AccessKey, Sk: reg.SecretKey, Namespace: reg.Namespace}
+		if reg.AdvancedSetting != nil {
+			option.TLSEnabled = reg.AdvancedSetting.TLSEnabled
+			option.TLSCert = reg.AdvancedSetting.TLSCert
+		}
+	} else {
+		// anonymous access to a registry not integrated with zadig
+		if domain == "docker.io" {
+			domain = "registry-1.docker.io"
+		}
+		option.Endpoint = registry.Endpoint{Addr: "https://" + domain}
+		option.TLSEnabled = true
+	}
+
+	digest, signatures, err := registry.GetImageSignatures(option, log)
+	if err != nil {
+		return err
+	}
+	return cosign.Verify(publicKeys, signatures, digest)
+}
+
+// findImageRegistry finds the registry hosting the repo, the one with the matched namespace is preferred
+func findImageRegistry(domain, repoName string, registries []*models.RegistryNamespace) *models.RegistryNamespace {
+	var resp *models.RegistryNamespace
+	for _, reg := range registries {
+		host := strings.TrimPrefix(strings.TrimPrefix(reg.RegAddr, "http://"), "https://")
+		if strings.TrimSuffix(host, "/") != domain {
+			continue
+		}
+		if reg.Namespace != "" && strings.HasPrefix(repoName, reg.Namespace+"/") {
+			return reg
+		}
+		if resp == nil {
+			resp = reg
+		}
+	}
+	return resp
+}
+
+// imageSignSecretEnvs returns the secret envs passing the signing key to the image sign step of the job,
+// they are only put into the job context so the private key is never saved in the task.
+func imageSignSecretEnvs(steps []*models.StepTask) ([]string, error) {
+	for _, stepTask := range steps {
+		if stepTask.StepType != config.StepImageSign {
+			continue
+		}
+		spec := &step.StepImageSignSpec{}
+		if err := models.IToi(stepTask.Spec, spec); err != nil {
+			return nil, fmt.Errorf("failed to convert image sign spec: %s", err)
+		}
+		key, err := mongodb.NewImageSigningKeyColl().GetByID(context.Background(), spec.SigningKeyID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find image signing key %s: %s", spec.SigningKeyID, err)
+		}
+		// a job signs one image at most, so the envs of the first image sign step are used
+		return []string{
+			fmt.Sprintf("%s=%s", step.ImageSignPrivateKeyEnv, key.PrivateKey),
+			fmt.Sprintf("%s=%s", step.ImageSignPasswordEnv, key.Password),
+		}, nil
+	}
+	return nil, nil
+}
@@ -97,6 +97,8 @@ func initJobCtl(job *commonmodels.JobTask, workflowCtx *commonmodels.WorkflowTas
 		jobCtl = NewGuanceyunCheckJobCtl(job, workflowCtx, ack, logger)
 	case string(config.JobGrafana):
 		jobCtl = NewGrafanaJobCtl(job, workflowCtx, ack, logger)
+	case string(config.JobMetricCheck):
+		jobCtl = NewMetricCheckJobCtl(job, workflowCtx, ack, logger)
 	case string(config.JobJenkins):
 		jobCtl = NewJenkinsJobCtl(job, workflowCtx, ack, logger)
 	case string(config.JobSQL):
@@ -109,6 +111,8 @@ func initJobCtl(job *commonmodels.JobTask, workflowCtx *commonmodels.WorkflowTas
 		jobCtl = NewNotificationJobCtl(job, workflowCtx, ack, logger)
 	case string(config.JobSAEDeploy):
 		jobCtl = NewSAEDeployJobCtl(job, workflowCtx, ack, logger)
+	case string(config.JobCallWorkflow):
+		jobCtl = NewCallWorkflowJobCtl(job, workflowCtx, ack, logger)
 	default:
 		jobCtl = NewFreestyleJobCtl(job, workflowCtx, ack, logger)
 	}
@@ -137,6 +141,23 @@ func runJob(ctx context.Context, job *commonmodels.JobTask, workflowCtx *commonm
 		}
 		return true
 	})
+	if job.When != "" {
+		run, err := EvaluateCondition(job.When, workflowCtx)
+		if err != nil || !run {
+			job.StartTime = time.Now().Unix()
+			job.EndTime = job.StartTime
+			if err != nil {
+				job.Status = config.StatusFailed
+				job.Error = err.Error()
+			} else {
+				job.Status = config.StatusSkipped
+				job.SkipReason = fmt.Sprintf("condition %s is evaluated to false", job.When)
+			}
+			logger.Infof("finish job: %s,status: %s", job.Name, job.Status)
+			ack()
+			return
+		}
+	}
 	job.Status = config.StatusPrepare
 	job.StartTime = time.Now().Unix()
 	job.K8sJobName = getJobName(workflowCtx.WorkflowName, workflowCtx.TaskID)
@@ -247,10 +268,18 @@ func waitForManualErrorHandling(ctx context.Context, workflowName string, taskID
 
 func RunJobs(ctx context.Context, jobs []*commonmodels.JobTask, workflowCtx *commonmodels.WorkflowTaskCtx, concurrency int, logger *zap.SugaredLogger, ack func()) {
 	if concurrency == 1 {
+		// the other job tasks of a matrix without fail fast still run after one of them fails
+		failedMatrix := ""
 		for _, job := range jobs {
+			if failedMatrix != "" && (job.OriginName != failedMatrix || !MatrixContinueOnError(job)) {
+				return
+			}
 			runJob(ctx, job, workflowCtx, logger, ack)
 			if jobStatusFailed(job.Status) {
-				return
+				if !MatrixContinueOnError(job) {
+					return
+				}
+				failedMatrix = job.OriginName
 			}
 		}
 		return
@@ -259,6 +288,48 @@ func RunJobs(ctx context.Context, jobs []*commonmodels.JobTask, workflowCtx *com
 	jobPool.Run()
 }
 
+// MatrixContinueOnError returns true if the job task is expanded from a matrix without fail fast.
+func MatrixContinueOnError(job *commonmodels.JobTask) bool {
+	return job.Matrix != nil && !job.Matrix.FailFast
+}
+
+// MatrixFailFast returns true if the job task is expanded from a matrix with fail fast.
+func MatrixFailFast(job *commonmodels.JobTask) bool {
+	return job.Matrix != nil && job.Matrix.FailFast
+}
+
+// RunJob runs a single job task, it is used by the scheduler that does not run jobs stage by stage.
+func RunJob(ctx context.Context, job *commonmodels.JobTask, workflowCtx *commonmodels.WorkflowTaskCtx, logger *zap.SugaredLogger, ack func()) {
+	runJob(ctx, job, workflowCtx, logger, ack)
+}
+
+// EvaluateCondition evaluates the condition expression of a job or a stage with the workflow params and the global context.
+func EvaluateCondition(condition string, workflowCtx *commonmodels.WorkflowTaskCtx) (bool, error) {
+	variables := map[string]string{
+		"project":               workflowCtx.ProjectName,
+		"workflow.name":         workflowCtx.WorkflowName,
+		"workflow.task.id":      fmt.Sprintf("%d", workflowCtx.TaskID),
+		"workflow.task.creator": workflowCtx.WorkflowTaskCreatorUsername,
+	}
+	for _, param := range workflowCtx.Params {
+		value := param.Value
+		if param.ParamsType == string(commonmodels.MultiSelectType) {
+			value = strings.Join(param.ChoiceValue, ",")
+		}
+		variables["workflow.params."+param.Name] = value
+	}
+	if workflowCtx.GlobalContextGetAll != nil {
+		// global context keys are stored in the form of {{.job.xxx.output.xxx}}
+		for k, v := range workflowCtx.GlobalContextGetAll() {
+			variables[strings.TrimSuffix(strings.TrimPrefix(k, "{{."), "}}")] = v
+		}
+	}
+	return workflowtool.EvaluateCondition(condition, &workflowtool.ConditionContext{
+		Variables:    variables,
+		ChangedFiles: workflowCtx.ChangedFiles,
+	})
+}
+
 func CleanWorkflowJobs(ctx context.Context, workflowTask *commonmodels.WorkflowTask, workflowCtx *commonmodels.WorkflowTaskCtx, logger *zap.SugaredLogger, ack func()) {
 	for _, stage := range workflowTask.Stages {
 		for _, job := range stage.Jobs {
@@ -279,19 +350,34 @@ type Pool struct {
 	ack         func()
 	ctx         context.Context
 	wg          sync.WaitGroup
+	// matrixCtxs is used to cancel the other job tasks of a fail fast matrix
+	matrixCtxs    map[string]context.Context
+	matrixCancels map[string]context.CancelFunc
 }
 
 // NewPool initializes a new pool with the given tasks and
 // at the given concurrency.
 func NewPool(ctx context.Context, jobs []*commonmodels.JobTask, workflowCtx *commonmodels.WorkflowTaskCtx, concurrency int, logger *zap.SugaredLogger, ack func()) *Pool {
+	matrixCtxs := make(map[string]context.Context)
+	matrixCancels := make(map[string]context.CancelFunc)
+	for _, job := range jobs {
+		if !MatrixFailFast(job) {
+			continue
+		}
+		if _, ok := matrixCtxs[job.OriginName]; !ok {
+			matrixCtxs[job.OriginName], matrixCancels[job.OriginName] = context.WithCancel(ctx)
+		}
+	}
 	return &Pool{
-		Jobs:        jobs,
-		concurrency: concurrency,
-		workflowCtx: workflowCtx,
-		jobsChan:    make(chan *commonmodels.JobTask),
-		logger:      logger,
-		ack:         ack,
-		ctx:         ctx,
+		Jobs:          jobs,
+		concurrency:   concurrency,
+		workflowCtx:   workflowCtx,
+		jobsChan:      make(chan *commonmodels.JobTask),
+		logger:        logger,
+		ack:           ack,
+		ctx:           ctx,
+		matrixCtxs:    matrixCtxs,
+		matrixCancels: matrixCancels,
 	}
 }
 
@@ -311,12 +397,33 @@ func (p *Pool) Run() {
 	close(p.jobsChan)
 
 	p.wg.Wait()
+	for _, cancel := range p.matrixCancels {
+		cancel()
+	}
 }
 
 // The work loop for any single goroutine.
 func (p *Pool) work() {
 	for job := range p.jobsChan {
-		runJob(p.ctx, job, p.workflowCtx, p.logger, p.ack)
+		ctx, ok := p.matrixCtxs[job.OriginName]
+		if !ok {
+			runJob(p.ctx, job, p.workflowCtx, p.logger, p.ack)
+			p.wg.Done()
+			continue
+		}
+
+		// the job tasks not started yet are left as they are once another job task of the fail fast matrix failed
+		if ctx.Err() == nil {
+			runJob(ctx, job, p.workflowCtx, p.logger, p.ack)
+			if job.Status == config.StatusCancelled && ctx.Err() != nil && p.ctx.Err() == nil {
+				// cancelled by the matrix instead of the workflow, keep the stage status as failed
+				job.Status = config.StatusFailed
+				job.Error = "cancelled since another job task of the matrix failed"
+				p.ack()
+			} else if jobStatusFailed(job.Status) {
+				p.matrixCancels[job.OriginName]()
+			}
+		}
 		p.wg.Done()
 	}
 }
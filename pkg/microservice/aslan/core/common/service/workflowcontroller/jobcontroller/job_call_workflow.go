@@ -0,0 +1,197 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobcontroller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	systemconfig "github.com/koderover/zadig/v2/pkg/config"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/setting"
+	"github.com/koderover/zadig/v2/pkg/shared/client/aslan"
+	"github.com/koderover/zadig/v2/pkg/shared/client/user"
+	"github.com/koderover/zadig/v2/pkg/types"
+	"github.com/koderover/zadig/v2/pkg/types/job"
+)
+
+type CallWorkflowJobCtl struct {
+	job         *commonmodels.JobTask
+	workflowCtx *commonmodels.WorkflowTaskCtx
+	logger      *zap.SugaredLogger
+	jobTaskSpec *commonmodels.JobTaskCallWorkflowSpec
+	ack         func()
+}
+
+func NewCallWorkflowJobCtl(job *commonmodels.JobTask, workflowCtx *commonmodels.WorkflowTaskCtx, ack func(), logger *zap.SugaredLogger) *CallWorkflowJobCtl {
+	jobTaskSpec := &commonmodels.JobTaskCallWorkflowSpec{}
+	if err := commonmodels.IToi(job.Spec, jobTaskSpec); err != nil {
+		logger.Error(err)
+	}
+	job.Spec = jobTaskSpec
+	return &CallWorkflowJobCtl{
+		job:         job,
+		workflowCtx: workflowCtx,
+		logger:      logger,
+		ack:         ack,
+		jobTaskSpec: jobTaskSpec,
+	}
+}
+
+func (c *CallWorkflowJobCtl) Clean(ctx context.Context) {}
+
+func (c *CallWorkflowJobCtl) Run(ctx context.Context) {
+	c.job.Status = config.StatusRunning
+	c.ack()
+
+	permitted, err := c.checkPermission()
+	if err != nil {
+		logError(c.job, fmt.Sprintf("failed to check the permission to run workflow %s, error: %v", c.jobTaskSpec.WorkflowName, err), c.logger)
+		return
+	}
+	if !permitted {
+		logError(c.job, fmt.Sprintf("user %s is not permitted to run workflow %s in project %s", c.workflowCtx.WorkflowTaskCreatorUsername, c.jobTaskSpec.WorkflowName, c.jobTaskSpec.ProjectName), c.logger)
+		return
+	}
+
+	workflow, err := mongodb.NewWorkflowV4Coll().Find(c.jobTaskSpec.WorkflowName)
+	if err != nil {
+		logError(c.job, fmt.Sprintf("find workflow %s err: %v", c.jobTaskSpec.WorkflowName, err), c.logger)
+		return
+	}
+	if workflow.Project != c.jobTaskSpec.ProjectName {
+		logError(c.job, fmt.Sprintf("project %s workflow %s not found", c.jobTaskSpec.ProjectName, c.jobTaskSpec.WorkflowName), c.logger)
+		return
+	}
+	workflow.Params = c.jobTaskSpec.Inputs
+	c.jobTaskSpec.WorkflowDisplayName = workflow.DisplayName
+
+	client := aslan.New(systemconfig.AslanServiceAddress())
+	resp, err := client.CreateWorkflowTaskV4(&aslan.CreateWorkflowTaskV4Req{
+		Workflow: workflow,
+		UserName: setting.WorkflowTriggerTaskCreator,
+	})
+	if err != nil {
+		logError(c.job, fmt.Sprintf("create workflow task %s err: %v", workflow.Name, err), c.logger)
+		return
+	}
+	c.jobTaskSpec.TaskID = resp.TaskID
+	c.jobTaskSpec.TaskStatus = config.StatusCreated
+	c.ack()
+
+	for {
+		time.Sleep(time.Second)
+		select {
+		case <-ctx.Done():
+			if err := client.CancelWorkflowTaskV4(setting.WorkflowTriggerTaskCreator, workflow.Name, resp.TaskID); err != nil {
+				c.logger.Errorf("cancel called workflow task %s-%d err: %v", workflow.Name, resp.TaskID, err)
+			}
+			c.jobTaskSpec.TaskStatus = config.StatusCancelled
+			c.job.Status = config.StatusCancelled
+			c.ack()
+			return
+		default:
+		}
+
+		task, err := mongodb.NewworkflowTaskv4Coll().Find(workflow.Name, resp.TaskID)
+		if err != nil {
+			logError(c.job, fmt.Sprintf("get workflow task %s-%d err: %v", workflow.Name, resp.TaskID, err), c.logger)
+			return
+		}
+		if task.Status != c.jobTaskSpec.TaskStatus {
+			c.jobTaskSpec.TaskStatus = task.Status
+			c.ack()
+		}
+		switch task.Status {
+		case config.StatusPassed:
+			c.setOutputs(workflow.Outputs, task.GlobalContext)
+			c.job.Status = config.StatusPassed
+			return
+		case config.StatusFailed, config.StatusCancelled, config.StatusReject, config.StatusTimeout:
+			logError(c.job, fmt.Sprintf("called workflow task %s-%d finished with status %s", workflow.Name, resp.TaskID, task.Status), c.logger)
+			return
+		}
+	}
+}
+
+// checkPermission checks if the task creator can run the called workflow, a task triggered by the system can only call workflows in the same project.
+func (c *CallWorkflowJobCtl) checkPermission() (bool, error) {
+	uid := c.workflowCtx.WorkflowTaskCreatorUserID
+	if uid == "" {
+		return c.jobTaskSpec.ProjectName == c.workflowCtx.ProjectName, nil
+	}
+
+	authInfo, err := user.New().GetUserAuthInfo(uid)
+	if err != nil {
+		return false, err
+	}
+	if authInfo.IsSystemAdmin {
+		return true, nil
+	}
+	projectAuthInfo, ok := authInfo.ProjectAuthInfo[c.jobTaskSpec.ProjectName]
+	if !ok {
+		return false, nil
+	}
+	if projectAuthInfo.IsProjectAdmin || (projectAuthInfo.Workflow != nil && projectAuthInfo.Workflow.Execute) {
+		return true, nil
+	}
+	return user.New().CheckUserAuthInfoForCollaborationMode(uid, c.jobTaskSpec.ProjectName, types.ResourceTypeWorkflow, c.jobTaskSpec.WorkflowName, types.WorkflowActionRun)
+}
+
+// setOutputs renders the outputs declared by the called workflow with its global context,
+// and sets them as the outputs of this job.
+func (c *CallWorkflowJobCtl) setOutputs(outputs []*commonmodels.WorkflowOutput, globalContext map[string]string) {
+	c.jobTaskSpec.Outputs = make([]*commonmodels.KeyVal, 0, len(outputs))
+	for _, output := range outputs {
+		value := renderWorkflowOutput(output.Value, globalContext)
+		if strings.Contains(value, "{{.") {
+			c.logger.Warnf("output %s of workflow %s is not fully rendered: %s", output.Name, c.jobTaskSpec.WorkflowName, value)
+		}
+		c.jobTaskSpec.Outputs = append(c.jobTaskSpec.Outputs, &commonmodels.KeyVal{Key: output.Name, Value: value})
+		c.workflowCtx.GlobalContextSet(job.GetJobOutputKey(c.job.Key, output.Name), value)
+	}
+	c.ack()
+}
+
+// renderWorkflowOutput replaces the variables in the output value with the global context of the called workflow task,
+// the keys of which are stored with "." replaced by "@?" since mongo does not support dot in keys.
+func renderWorkflowOutput(value string, globalContext map[string]string) string {
+	for k, v := range globalContext {
+		value = strings.ReplaceAll(value, strings.ReplaceAll(k, "@?", "."), v)
+	}
+	return value
+}
+
+func (c *CallWorkflowJobCtl) SaveInfo(ctx context.Context) error {
+	return mongodb.NewJobInfoColl().Create(context.TODO(), &commonmodels.JobInfo{
+		Type:                c.job.JobType,
+		WorkflowName:        c.workflowCtx.WorkflowName,
+		WorkflowDisplayName: c.workflowCtx.WorkflowDisplayName,
+		TaskID:              c.workflowCtx.TaskID,
+		ProductName:         c.workflowCtx.ProjectName,
+		StartTime:           c.job.StartTime,
+		EndTime:             c.job.EndTime,
+		Duration:            c.job.EndTime - c.job.StartTime,
+		Status:              string(c.job.Status),
+	})
+}
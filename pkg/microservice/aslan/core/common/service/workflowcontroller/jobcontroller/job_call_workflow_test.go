@@ -0,0 +1,59 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobcontroller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderWorkflowOutput(t *testing.T) {
+	// keys as stored in the global context of a workflow task
+	globalContext := map[string]string{
+		"{{@?job@?build@?service@?module@?output@?IMAGE}}": "koderover.io/service:v1",
+		"{{@?workflow@?task@?id}}":                         "12",
+	}
+
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{
+			name:  "job output",
+			value: "{{.job.build.service.module.output.IMAGE}}",
+			want:  "koderover.io/service:v1",
+		},
+		{
+			name:  "mixed with text",
+			value: "task-{{.workflow.task.id}}:{{.job.build.service.module.output.IMAGE}}",
+			want:  "task-12:koderover.io/service:v1",
+		},
+		{
+			name:  "unknown variable",
+			value: "{{.job.deploy.output.IMAGE}}",
+			want:  "{{.job.deploy.output.IMAGE}}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, renderWorkflowOutput(tt.value, globalContext))
+		})
+	}
+}
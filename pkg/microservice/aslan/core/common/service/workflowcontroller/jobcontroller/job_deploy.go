@@ -127,6 +127,8 @@ func (c *DeployJobCtl) run(ctx context.Context) error {
 		return errors.New(msg)
 	}
 
+	snapshotEnvBeforeDeploy(env, c.workflowCtx, c.logger)
+
 	c.namespace = env.Namespace
 	c.jobTaskSpec.ClusterID = env.ClusterID
 
@@ -185,6 +187,16 @@ func (c *DeployJobCtl) run(ctx context.Context) error {
 			})
 		}
 	}
+	if len(containers) > 0 {
+		images := make([]string, 0, len(containers))
+		for _, container := range containers {
+			images = append(images, container.Image)
+		}
+		if err := verifyImageSignatures(env, images, c.logger); err != nil {
+			logError(c.job, err.Error(), c.logger)
+			return err
+		}
+	}
 
 	option := &kube.GeneSvcYamlOption{
 		ProductName:           env.ProductName,
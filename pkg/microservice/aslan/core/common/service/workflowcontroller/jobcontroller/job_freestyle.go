@@ -174,7 +174,12 @@ func (c *FreestyleJobCtl) run(ctx context.Context) error {
 
 	c.jobTaskSpec.Properties.DockerHost = dockerHost
 
-	jobCtxBytes, err := yaml.Marshal(BuildJobExcutorContext(c.jobTaskSpec, c.job, c.workflowCtx, c.logger))
+	jobCtx, err := BuildJobExcutorContext(c.jobTaskSpec, c.job, c.workflowCtx, c.logger)
+	if err != nil {
+		logError(c.job, err.Error(), c.logger)
+		return err
+	}
+	jobCtxBytes, err := yaml.Marshal(jobCtx)
 	if err != nil {
 		msg := fmt.Sprintf("cannot Jobexcutor.Context data: %v", err)
 		logError(c.job, msg, c.logger)
@@ -251,7 +256,12 @@ func (c *FreestyleJobCtl) run(ctx context.Context) error {
 }
 
 func (c *FreestyleJobCtl) runVMJob(ctx context.Context) (string, error) {
-	jobCtxBytes, err := yaml.Marshal(BuildJobExcutorContext(c.jobTaskSpec, c.job, c.workflowCtx, c.logger))
+	jobCtx, err := BuildJobExcutorContext(c.jobTaskSpec, c.job, c.workflowCtx, c.logger)
+	if err != nil {
+		logError(c.job, err.Error(), c.logger)
+		return "", err
+	}
+	jobCtxBytes, err := yaml.Marshal(jobCtx)
 	if err != nil {
 
 		msg := fmt.Sprintf("cannot Jobexcutor.Context data: %v", err)
@@ -410,7 +420,7 @@ func getVMJobOutputFromJobDB(jobID, jobName string, job *commonmodels.JobTask, w
 	return nil
 }
 
-func BuildJobExcutorContext(jobTaskSpec *commonmodels.JobTaskFreestyleSpec, job *commonmodels.JobTask, workflowCtx *commonmodels.WorkflowTaskCtx, logger *zap.SugaredLogger) *JobContext {
+func BuildJobExcutorContext(jobTaskSpec *commonmodels.JobTaskFreestyleSpec, job *commonmodels.JobTask, workflowCtx *commonmodels.WorkflowTaskCtx, logger *zap.SugaredLogger) (*JobContext, error) {
 	var envVars, secretEnvVars []string
 	for _, env := range jobTaskSpec.Properties.Envs {
 		if env.IsCredential {
@@ -419,6 +429,11 @@ func BuildJobExcutorContext(jobTaskSpec *commonmodels.JobTaskFreestyleSpec, job
 		}
 		envVars = append(envVars, strings.Join([]string{env.Key, env.Value}, "="))
 	}
+	signEnvs, err := imageSignSecretEnvs(jobTaskSpec.Steps)
+	if err != nil {
+		return nil, err
+	}
+	secretEnvVars = append(secretEnvVars, signEnvs...)
 
 	outputs := []string{}
 	for _, output := range job.Outputs {
@@ -449,7 +464,7 @@ func BuildJobExcutorContext(jobTaskSpec *commonmodels.JobTaskFreestyleSpec, job
 		}
 	}
 
-	return jobContext
+	return jobContext, nil
 }
 
 func (c *FreestyleJobCtl) SaveInfo(ctx context.Context) error {
@@ -31,6 +31,7 @@ import (
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models/template"
 	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/kube"
+	commonutil "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/util"
 	"github.com/koderover/zadig/v2/pkg/setting"
 )
 
@@ -81,6 +82,8 @@ func (c *HelmChartDeployJobCtl) Run(ctx context.Context) {
 		return
 	}
 
+	snapshotEnvBeforeDeploy(productInfo, c.workflowCtx, c.logger)
+
 	c.namespace = productInfo.Namespace
 	c.jobTaskSpec.ClusterID = productInfo.ClusterID
 
@@ -121,9 +124,11 @@ func (c *HelmChartDeployJobCtl) Run(ctx context.Context) {
 	}
 
 	valuesYaml := deploy.ValuesYaml
-	chartInfo.ChartRepo = deploy.ChartRepo
-	chartInfo.ChartName = deploy.ChartName
-	chartInfo.ChartVersion = deploy.ChartVersion
+	chartInfo.ChartRepo, chartInfo.ChartName, chartInfo.ChartVersion, err = commonutil.ResolveOCIChartRef(deploy.ChartRepo, deploy.ChartName, deploy.ChartVersion)
+	if err != nil {
+		logError(c.job, fmt.Sprintf("invalid chart reference %s, err: %s", deploy.ChartName, err), c.logger)
+		return
+	}
 	chartInfo.OverrideYaml.YamlContent = valuesYaml
 	c.ack()
 
@@ -90,6 +90,15 @@ func (c *HelmDeployJobCtl) Run(ctx context.Context) {
 		return
 	}
 
+	if slices.Contains(c.jobTaskSpec.DeployContents, config.DeployImage) {
+		if err := verifyImageSignatures(productInfo, c.jobTaskSpec.GetDeployImages(), c.logger); err != nil {
+			logError(c.job, err.Error(), c.logger)
+			return
+		}
+	}
+
+	snapshotEnvBeforeDeploy(productInfo, c.workflowCtx, c.logger)
+
 	c.namespace = productInfo.Namespace
 	c.jobTaskSpec.ClusterID = productInfo.ClusterID
 
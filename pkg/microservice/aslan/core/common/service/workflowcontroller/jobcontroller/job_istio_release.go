@@ -24,6 +24,7 @@ import (
 	"go.uber.org/zap"
 	networkingv1alpha3 "istio.io/api/networking/v1alpha3"
 	"istio.io/client-go/pkg/apis/networking/v1alpha3"
+	"istio.io/client-go/pkg/clientset/versioned"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -34,8 +35,10 @@ import (
 	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
 	"github.com/koderover/zadig/v2/pkg/setting"
+	"github.com/koderover/zadig/v2/pkg/shared/kube/wrapper"
 	"github.com/koderover/zadig/v2/pkg/tool/kube/getter"
 	"github.com/koderover/zadig/v2/pkg/tool/kube/updater"
+	"github.com/koderover/zadig/v2/pkg/tool/prometheus"
 )
 
 const (
@@ -117,6 +120,12 @@ func (c *IstioReleaseJobCtl) Run(ctx context.Context) {
 		return
 	}
 
+	// in progressive mode the traffic is shifted to the weight of the first step, then step by step
+	weight := int32(c.jobTaskSpec.Weight)
+	if len(c.jobTaskSpec.Steps) > 0 {
+		weight = int32(c.jobTaskSpec.Steps[0].Weight)
+	}
+
 	// ==================================================================
 	//                     Deployment modification
 	// ==================================================================
@@ -310,7 +319,7 @@ func (c *IstioReleaseJobCtl) Run(ctx context.Context) {
 					Subset: ZadigIstioLabelOriginal,
 					Port:   vs.Spec.Http[0].Route[0].Destination.Port,
 				},
-				Weight: 100 - int32(c.jobTaskSpec.Weight),
+				Weight: 100 - weight,
 			})
 			newHTTPRoutingRules = append(newHTTPRoutingRules, &networkingv1alpha3.HTTPRouteDestination{
 				Destination: &networkingv1alpha3.Destination{
@@ -318,7 +327,7 @@ func (c *IstioReleaseJobCtl) Run(ctx context.Context) {
 					Subset: ZadigIstioLabelDuplicate,
 					Port:   vs.Spec.Http[0].Route[0].Destination.Port,
 				},
-				Weight: int32(c.jobTaskSpec.Weight),
+				Weight: weight,
 			})
 			routeByte, err := json.Marshal(vs.Spec.Http[0].Route)
 			if err != nil {
@@ -349,14 +358,14 @@ func (c *IstioReleaseJobCtl) Run(ctx context.Context) {
 					Host:   c.jobTaskSpec.Targets.Host,
 					Subset: ZadigIstioLabelOriginal,
 				},
-				Weight: 100 - int32(c.jobTaskSpec.Weight),
+				Weight: 100 - weight,
 			})
 			newHTTPRoutingRules = append(newHTTPRoutingRules, &networkingv1alpha3.HTTPRouteDestination{
 				Destination: &networkingv1alpha3.Destination{
 					Host:   c.jobTaskSpec.Targets.Host,
 					Subset: ZadigIstioLabelDuplicate,
 				},
-				Weight: int32(c.jobTaskSpec.Weight),
+				Weight: weight,
 			})
 
 			// prepare exactly 2 httpRouteDestination for the vs
@@ -412,6 +421,15 @@ func (c *IstioReleaseJobCtl) Run(ctx context.Context) {
 				}
 			}
 		}
+
+		vsName := c.jobTaskSpec.Targets.VirtualServiceName
+		if vsName == "" {
+			vsName = fmt.Sprintf(VirtualServiceNameTemplate, c.jobTaskSpec.Targets.WorkloadName)
+		}
+		// no traffic goes to the new version before the first release job
+		if !c.runProgressiveSteps(ctx, istioClient, vsName, 0) {
+			return
+		}
 	} else {
 		// Otherwise there are 2 cases, either this is a finishing move, or not.
 		// When it is NOT a finishing move, simply modify the weight of the vs destination rule, and we are done
@@ -428,6 +446,13 @@ func (c *IstioReleaseJobCtl) Run(ctx context.Context) {
 			return
 		}
 
+		previousWeight := int32(0)
+		for _, route := range vs.Spec.Http[0].Route {
+			if route.Destination != nil && route.Destination.Subset == ZadigIstioLabelDuplicate {
+				previousWeight = route.Weight
+			}
+		}
+
 		newHTTPRoutingRules := make([]*networkingv1alpha3.HTTPRouteDestination, 0)
 		newHTTPRoutingRules = append(newHTTPRoutingRules, &networkingv1alpha3.HTTPRouteDestination{
 			Destination: &networkingv1alpha3.Destination{
@@ -435,7 +460,7 @@ func (c *IstioReleaseJobCtl) Run(ctx context.Context) {
 				Subset: ZadigIstioLabelOriginal,
 				Port:   vs.Spec.Http[0].Route[0].Destination.Port,
 			},
-			Weight: 100 - int32(c.jobTaskSpec.Weight),
+			Weight: 100 - weight,
 		})
 		newHTTPRoutingRules = append(newHTTPRoutingRules, &networkingv1alpha3.HTTPRouteDestination{
 			Destination: &networkingv1alpha3.Destination{
@@ -443,7 +468,7 @@ func (c *IstioReleaseJobCtl) Run(ctx context.Context) {
 				Subset: ZadigIstioLabelDuplicate,
 				Port:   vs.Spec.Http[0].Route[0].Destination.Port,
 			},
-			Weight: int32(c.jobTaskSpec.Weight),
+			Weight: weight,
 		})
 		vs.Spec.Http[0].Route = newHTTPRoutingRules
 		c.Infof("Modifying Virtual Service: %s", c.jobTaskSpec.Targets.VirtualServiceName)
@@ -454,6 +479,10 @@ func (c *IstioReleaseJobCtl) Run(ctx context.Context) {
 			return
 		}
 
+		if !c.runProgressiveSteps(ctx, istioClient, newVSName, previousWeight) {
+			return
+		}
+
 		// If this is a finishing move, following additional steps will have to be done
 		// 1. edit the old deployment
 		//   a. change the image to the new one
@@ -559,6 +588,126 @@ func (c *IstioReleaseJobCtl) Run(ctx context.Context) {
 	c.job.Status = config.StatusPassed
 }
 
+// runProgressiveSteps shifts the traffic to the new version step by step, the weight of the first step has been applied by the caller.
+// Every step waits for its interval and runs the health checks, once a check fails the virtual service
+// is reverted to previousWeight and the job fails.
+func (c *IstioReleaseJobCtl) runProgressiveSteps(ctx context.Context, istioClient *versioned.Clientset, vsName string, previousWeight int32) bool {
+	for i, step := range c.jobTaskSpec.Steps {
+		step.Status = config.StatusRunning
+		step.StartTime = time.Now().Unix()
+		failStep := func(err error) bool {
+			step.Status = config.StatusFailed
+			step.Message = err.Error()
+			step.EndTime = time.Now().Unix()
+			c.Infof("Reverting virtual service: %s to %d%% traffic for the new version", vsName, previousWeight)
+			if revertErr := c.setWeight(istioClient, vsName, previousWeight); revertErr != nil {
+				c.Errorf("failed to revert virtual service: %s, error: %s", vsName, revertErr)
+			}
+			c.Errorf("progressive release failed at step %d (%d%%): %s", i+1, step.Weight, err)
+			return false
+		}
+
+		if i > 0 {
+			if err := c.setWeight(istioClient, vsName, int32(step.Weight)); err != nil {
+				return failStep(err)
+			}
+		}
+		c.Infof("Shifted %d%% traffic to the new version, waiting %d seconds for health check", step.Weight, step.Interval)
+		c.ack()
+
+		select {
+		case <-ctx.Done():
+			step.Status = config.StatusCancelled
+			step.EndTime = time.Now().Unix()
+			c.job.Status = config.StatusCancelled
+			return false
+		case <-time.After(time.Duration(step.Interval) * time.Second):
+		}
+
+		if err := c.checkHealth(); err != nil {
+			return failStep(err)
+		}
+		step.Status = config.StatusPassed
+		step.EndTime = time.Now().Unix()
+		c.ack()
+	}
+	return true
+}
+
+func (c *IstioReleaseJobCtl) setWeight(istioClient *versioned.Clientset, vsName string, weight int32) error {
+	vs, err := istioClient.NetworkingV1alpha3().VirtualServices(c.jobTaskSpec.Namespace).Get(context.TODO(), vsName, v1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to find virtual service of name: %s, error is: %s", vsName, err)
+	}
+	if len(vs.Spec.Http) == 0 {
+		return fmt.Errorf("virtual service: %s has no http route", vsName)
+	}
+	for _, route := range vs.Spec.Http[0].Route {
+		if route.Destination == nil {
+			continue
+		}
+		switch route.Destination.Subset {
+		case ZadigIstioLabelOriginal:
+			route.Weight = 100 - weight
+		case ZadigIstioLabelDuplicate:
+			route.Weight = weight
+		}
+	}
+	_, err = istioClient.NetworkingV1alpha3().VirtualServices(c.jobTaskSpec.Namespace).Update(context.TODO(), vs, v1.UpdateOptions{})
+	return err
+}
+
+// checkHealth runs the health checks against the new version, which is the deployment copy created by the first release job
+func (c *IstioReleaseJobCtl) checkHealth() error {
+	name := fmt.Sprintf("%s-%s", c.jobTaskSpec.Targets.WorkloadName, config.ZadigIstioCopySuffix)
+	for _, check := range c.jobTaskSpec.HealthChecks {
+		switch check.Type {
+		case config.IstioHealthCheckPodReady:
+			deployment, found, err := getter.GetDeployment(c.jobTaskSpec.Namespace, name, c.kubeClient)
+			if err != nil || !found {
+				return fmt.Errorf("deployment: %s not found: %v", name, err)
+			}
+			if !wrapper.Deployment(deployment).Ready() {
+				return fmt.Errorf("pods of deployment: %s are not ready", name)
+			}
+		case config.IstioHealthCheckRestartCount:
+			deployment, found, err := getter.GetDeployment(c.jobTaskSpec.Namespace, name, c.kubeClient)
+			if err != nil || !found {
+				return fmt.Errorf("deployment: %s not found: %v", name, err)
+			}
+			selector, err := v1.LabelSelectorAsSelector(deployment.Spec.Selector)
+			if err != nil {
+				return fmt.Errorf("invalid selector of deployment: %s: %v", name, err)
+			}
+			pods, err := getter.ListPods(c.jobTaskSpec.Namespace, selector, c.kubeClient)
+			if err != nil {
+				return fmt.Errorf("failed to list pods of deployment: %s: %v", name, err)
+			}
+			restarts := int32(0)
+			for _, pod := range pods {
+				for _, status := range pod.Status.ContainerStatuses {
+					restarts += status.RestartCount
+				}
+			}
+			if restarts > check.MaxRestarts {
+				return fmt.Errorf("pods of deployment: %s restarted %d times, more than %d", name, restarts, check.MaxRestarts)
+			}
+		case config.IstioHealthCheckMetric:
+			info, err := mongodb.NewObservabilityColl().GetByID(context.Background(), check.ObservabilityID)
+			if err != nil {
+				return fmt.Errorf("get observability info error: %v", err)
+			}
+			client := prometheus.NewClient(info.Host, info.PrometheusToken)
+			if !checkMetric(client, check.Metric, c.workflowCtx.StartTime) {
+				return fmt.Errorf("metric check %s failed: %s", check.Metric.Name, check.Metric.Error)
+			}
+		default:
+			return fmt.Errorf("invalid health check type: %s", check.Type)
+		}
+	}
+	return nil
+}
+
 func (c *IstioReleaseJobCtl) Errorf(format string, a ...any) {
 	errMsg := fmt.Sprintf(format, a...)
 	logError(c.job, errMsg, c.logger)
@@ -0,0 +1,184 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobcontroller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/tool/prometheus"
+)
+
+type MetricCheckJobCtl struct {
+	job         *commonmodels.JobTask
+	workflowCtx *commonmodels.WorkflowTaskCtx
+	logger      *zap.SugaredLogger
+	jobTaskSpec *commonmodels.JobTaskMetricCheckSpec
+	ack         func()
+}
+
+func NewMetricCheckJobCtl(job *commonmodels.JobTask, workflowCtx *commonmodels.WorkflowTaskCtx, ack func(), logger *zap.SugaredLogger) *MetricCheckJobCtl {
+	jobTaskSpec := &commonmodels.JobTaskMetricCheckSpec{}
+	if err := commonmodels.IToi(job.Spec, jobTaskSpec); err != nil {
+		logger.Error(err)
+	}
+	job.Spec = jobTaskSpec
+	return &MetricCheckJobCtl{
+		job:         job,
+		workflowCtx: workflowCtx,
+		logger:      logger,
+		ack:         ack,
+		jobTaskSpec: jobTaskSpec,
+	}
+}
+
+func (c *MetricCheckJobCtl) Clean(ctx context.Context) {}
+
+func (c *MetricCheckJobCtl) Run(ctx context.Context) {
+	c.job.Status = config.StatusRunning
+	c.ack()
+
+	info, err := mongodb.NewObservabilityColl().GetByID(context.Background(), c.jobTaskSpec.ID)
+	if err != nil {
+		logError(c.job, fmt.Sprintf("get observability info error: %v", err), c.logger)
+		return
+	}
+	client := prometheus.NewClient(info.Host, info.PrometheusToken)
+
+	for _, metric := range c.jobTaskSpec.Metrics {
+		metric.Status = StatusChecking
+	}
+	c.ack()
+
+	interval := c.jobTaskSpec.CheckInterval
+	if interval <= 0 {
+		interval = 60
+	}
+	ticker := time.NewTicker(time.Duration(interval) * time.Second)
+	defer ticker.Stop()
+	timeout := time.After(time.Duration(c.jobTaskSpec.CheckTime) * time.Minute)
+
+	// check returns false once the failures exceed the failure limit
+	check := func() bool {
+		passed := true
+		for _, metric := range c.jobTaskSpec.Metrics {
+			if !checkMetric(client, metric, c.workflowCtx.StartTime) {
+				passed = false
+			}
+		}
+		if !passed {
+			c.jobTaskSpec.Failures++
+			if c.jobTaskSpec.Failures > c.jobTaskSpec.FailureLimit {
+				logError(c.job, fmt.Sprintf("metric check failed %d times, exceeding the failure limit %d", c.jobTaskSpec.Failures, c.jobTaskSpec.FailureLimit), c.logger)
+				return false
+			}
+		}
+		c.ack()
+		return true
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			c.job.Status = config.StatusCancelled
+			return
+		case <-timeout:
+			// the metrics are checked once more before passing, so the job never passes without querying them
+			if !check() {
+				return
+			}
+			// the metrics found abnormal by the last check keep their status
+			for _, metric := range c.jobTaskSpec.Metrics {
+				if metric.Status == StatusChecking {
+					metric.Status = StatusNormal
+				}
+			}
+			c.job.Status = config.StatusPassed
+			return
+		case <-ticker.C:
+			if !check() {
+				return
+			}
+		}
+	}
+}
+
+// checkMetric evaluates a single metric and records the result on it, a query error counts as a failed check.
+// startTime is the time the baseline mode compares with.
+func checkMetric(client *prometheus.Client, metric *commonmodels.MetricCheck, startTime time.Time) bool {
+	now := time.Now()
+	fail := func(err error) bool {
+		metric.Status = StatusAbnormal
+		metric.Error = err.Error()
+		return false
+	}
+
+	value, err := client.Query(metric.Query, now)
+	if err != nil {
+		return fail(err)
+	}
+	metric.Value = value
+
+	threshold := metric.Threshold
+	switch metric.Mode {
+	case config.MetricCheckModeBaseline:
+		baseline, err := client.Query(metric.Query, startTime)
+		if err != nil {
+			return fail(fmt.Errorf("query baseline error: %v", err))
+		}
+		metric.Baseline = baseline
+		threshold = baseline * metric.Threshold
+	case config.MetricCheckModeCanary:
+		baseline, err := client.Query(metric.BaselineQuery, now)
+		if err != nil {
+			return fail(fmt.Errorf("query baseline error: %v", err))
+		}
+		metric.Baseline = baseline
+		threshold = baseline * metric.Threshold
+	}
+
+	ok, err := prometheus.Compare(value, metric.Operator, threshold)
+	if err != nil {
+		return fail(err)
+	}
+	if !ok {
+		return fail(fmt.Errorf("%v %s %v not satisfied", value, metric.Operator, threshold))
+	}
+	metric.Status = StatusChecking
+	metric.Error = ""
+	return true
+}
+
+func (c *MetricCheckJobCtl) SaveInfo(ctx context.Context) error {
+	return mongodb.NewJobInfoColl().Create(context.TODO(), &commonmodels.JobInfo{
+		Type:                c.job.JobType,
+		WorkflowName:        c.workflowCtx.WorkflowName,
+		WorkflowDisplayName: c.workflowCtx.WorkflowDisplayName,
+		TaskID:              c.workflowCtx.TaskID,
+		ProductName:         c.workflowCtx.ProjectName,
+		StartTime:           c.job.StartTime,
+		EndTime:             c.job.EndTime,
+		Duration:            c.job.EndTime - c.job.StartTime,
+		Status:              string(c.job.Status),
+	})
+}
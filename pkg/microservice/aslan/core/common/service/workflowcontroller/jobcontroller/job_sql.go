@@ -19,8 +19,6 @@ package jobcontroller
 import (
 	"context"
 	"database/sql"
-	"fmt"
-	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -30,6 +28,7 @@ import (
 	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
 	"github.com/koderover/zadig/v2/pkg/setting"
+	"github.com/koderover/zadig/v2/pkg/tool/sqldb"
 )
 
 type SQLJobCtl struct {
@@ -69,14 +68,8 @@ func (c *SQLJobCtl) Run(ctx context.Context) {
 	}
 	c.dbInfo = info
 
-	switch info.Type {
-	case config.DBInstanceTypeMySQL, config.DBInstanceTypeMariaDB:
-		if err := c.ExecMySQLStatement(); err != nil {
-			logError(c.job, err.Error(), c.logger)
-			return
-		}
-	default:
-		logError(c.job, "invalid db type", c.logger)
+	if err := c.execStatements(ctx); err != nil {
+		logError(c.job, err.Error(), c.logger)
 		return
 	}
 
@@ -84,38 +77,63 @@ func (c *SQLJobCtl) Run(ctx context.Context) {
 	return
 }
 
-func (c *SQLJobCtl) ExecMySQLStatement() error {
+// execStatements executes the statements one by one and stops at the first failed one,
+// in dry-run mode they are executed in a transaction which is always rolled back.
+func (c *SQLJobCtl) execStatements(ctx context.Context) error {
 	info := c.dbInfo
 
-	db, err := sql.Open("mysql", fmt.Sprintf("%s:%s@tcp(%s:%s)/?charset=utf8&multiStatements=true", info.Username, info.Password, info.Host, info.Port))
+	driver, err := sqldb.GetDriver(string(info.Type))
+	if err != nil {
+		return err
+	}
+	db, err := driver.Open(info.Host, info.Port, info.Username, info.Password, info.Database, info.SSLMode)
 	if err != nil {
 		return errors.Errorf("connect db error: %v", err)
 	}
 	defer db.Close()
 
-	sqls := strings.SplitAfter(c.jobTaskSpec.SQL, ";")
-	for _, sql := range sqls {
-		if sql == "" {
-			continue
-		}
-
-		execResult := &commonmodels.SQLExecResult{}
-
-		execResult.SQL = strings.TrimSpace(sql)
-		execResult.Status = setting.SQLExecStatusNotExec
+	statements, err := driver.SplitStatements(c.jobTaskSpec.SQL)
+	if err != nil {
+		return errors.Errorf("split SQL statements error: %v", err)
+	}
+	for _, statement := range statements {
+		c.jobTaskSpec.Results = append(c.jobTaskSpec.Results, &commonmodels.SQLExecResult{
+			SQL:    statement,
+			Status: setting.SQLExecStatusNotExec,
+		})
+	}
 
-		c.jobTaskSpec.Results = append(c.jobTaskSpec.Results, execResult)
+	var execer interface {
+		ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	} = db
+	if c.jobTaskSpec.DryRun {
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			return errors.Errorf("begin transaction error: %v", err)
+		}
+		defer func() {
+			if err := tx.Rollback(); err != nil {
+				c.logger.Errorf("rollback dry-run transaction error: %v", err)
+			}
+		}()
+		execer = tx
 	}
 
 	for _, execResult := range c.jobTaskSpec.Results {
+		if c.jobTaskSpec.DryRun && !driver.CanRollback(execResult.SQL) {
+			execResult.Error = "the statement can not be rolled back, it is not executed in dry-run mode"
+			continue
+		}
+
 		now := time.Now()
-		result, err := db.Exec(execResult.SQL)
+		result, err := execer.ExecContext(ctx, execResult.SQL)
+		execResult.ElapsedTime = time.Now().Sub(now).Milliseconds()
 		if err != nil {
 			execResult.Status = setting.SQLExecStatusFailed
+			execResult.Error = err.Error()
 			return errors.Errorf("exec SQL \"%s\" error: %v", execResult.SQL, err)
 		}
 		execResult.Status = setting.SQLExecStatusSuccess
-		execResult.ElapsedTime = time.Now().Sub(now).Milliseconds()
 
 		rowsAffected, err := result.RowsAffected()
 		if err != nil {
@@ -0,0 +1,53 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobcontroller
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	commonutil "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/util"
+)
+
+// snapshotEnvBeforeDeploy takes a snapshot of the env before the workflow task deploys to it,
+// only the first deploy job of the task takes the snapshot so the env could be restored to the state before the whole task
+func snapshotEnvBeforeDeploy(env *commonmodels.Product, workflowCtx *commonmodels.WorkflowTaskCtx, logger *zap.SugaredLogger) {
+	exists, err := commonrepo.NewEnvSnapshotColl().ExistsByWorkflowTask(env.ProductName, env.EnvName, env.Production, workflowCtx.WorkflowName, workflowCtx.TaskID)
+	if err != nil {
+		logger.Errorf("failed to check snapshot of env %s/%s, error: %v", env.ProductName, env.EnvName, err)
+		return
+	}
+	if exists {
+		return
+	}
+
+	_, err = commonutil.CreateEnvSnapshot(env, &commonutil.CreateEnvSnapshotArgs{
+		Name:         fmt.Sprintf("%s-%d", workflowCtx.WorkflowName, workflowCtx.TaskID),
+		Source:       config.EnvSnapshotSourceWorkflow,
+		WorkflowName: workflowCtx.WorkflowName,
+		TaskID:       workflowCtx.TaskID,
+		CreateBy:     workflowCtx.WorkflowTaskCreatorUsername,
+	}, logger)
+	if err != nil {
+		// failing to take the snapshot should not block the deployment
+		logger.Errorf("failed to take snapshot of env %s/%s before deploy, error: %v", env.ProductName, env.EnvName, err)
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -61,6 +62,10 @@ func PendingTasks() []*commonmodels.WorkflowQueue {
 			tasks = append(tasks, t)
 		}
 	}
+	// tasks with higher priority are run first
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return tasks[i].Priority > tasks[j].Priority
+	})
 	return tasks
 }
 
@@ -159,7 +164,8 @@ func WorfklowTaskSender() {
 			mutex.Unlock()
 			continue
 		}
-		var t *commonmodels.WorkflowQueue
+		// tasks not limited by the concurrency of their workflows, the one to run is picked by priority and project quotas
+		candidates := make([]*commonmodels.WorkflowQueue, 0)
 		for _, task := range waitingTasks {
 			var concurrency int
 			workflow, err := commonrepo.NewWorkflowV4Coll().Find(task.WorkflowName)
@@ -214,8 +220,8 @@ func WorfklowTaskSender() {
 			}
 			// no concurrency limit, run task
 			if concurrency == -1 {
-				t = task
-				break
+				candidates = append(candidates, task)
+				continue
 			}
 			resp, err := RunningWorkflowTasks(task.WorkflowName)
 			if err != nil {
@@ -228,10 +234,12 @@ func WorfklowTaskSender() {
 				continue
 			}
 			if len(resp)+len(resp2) < concurrency {
-				t = task
-				break
+				candidates = append(candidates, task)
 			}
 		}
+		queueTasks := ListTasks()
+		candidates = filterConcurrencyGroups(candidates, queueTasks)
+		t := pickQueueTask(candidates, countProjectTasks(queueTasks), sysSetting.WorkflowScheduling)
 		// no task to run
 		if t == nil {
 			mutex.Unlock()
@@ -372,6 +380,8 @@ func ConvertTaskToQueue(task *commonmodels.WorkflowTask) *commonmodels.WorkflowQ
 		TaskRevoker:         task.TaskRevoker,
 		CreateTime:          task.CreateTime,
 		Type:                task.Type,
+		Priority:            task.Priority,
+		ConcurrencyGroup:    task.ConcurrencyGroup,
 	}
 }
 
@@ -0,0 +1,112 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflowcontroller
+
+import (
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+)
+
+// projectQuota returns the max number of running tasks and the weight of the project, 0 concurrency means no limit
+func projectQuota(scheduling *commonmodels.WorkflowSchedulingSettings, projectName string) (int, int) {
+	if scheduling == nil {
+		return 0, 1
+	}
+	concurrency, weight := scheduling.DefaultProjectConcurrency, 1
+	for _, project := range scheduling.Projects {
+		if project.ProjectName != projectName {
+			continue
+		}
+		if project.Concurrency > 0 {
+			concurrency = project.Concurrency
+		}
+		if project.Weight > 0 {
+			weight = project.Weight
+		}
+		break
+	}
+	return concurrency, weight
+}
+
+// countProjectTasks counts the tasks occupying the quota of every project
+func countProjectTasks(tasks []*commonmodels.WorkflowQueue) map[string]int {
+	resp := make(map[string]int)
+	for _, t := range tasks {
+		switch t.Status {
+		case config.StatusRunning, config.StatusQueued, config.StatusWaitingApprove:
+			resp[t.ProjectName]++
+		}
+	}
+	return resp
+}
+
+// pickQueueTask picks the next task to run from the candidates in queue order:
+// tasks of the projects reaching their quota are skipped, then the tasks with the highest priority are kept,
+// and the earliest task of the project with the least running tasks per weight is picked.
+func pickQueueTask(candidates []*commonmodels.WorkflowQueue, running map[string]int, scheduling *commonmodels.WorkflowSchedulingSettings) *commonmodels.WorkflowQueue {
+	var resp *commonmodels.WorkflowQueue
+	var respLoad float64
+	for _, t := range candidates {
+		concurrency, weight := projectQuota(scheduling, t.ProjectName)
+		if concurrency > 0 && running[t.ProjectName] >= concurrency {
+			continue
+		}
+		load := float64(running[t.ProjectName]) / float64(weight)
+		switch {
+		case resp == nil,
+			t.Priority > resp.Priority,
+			t.Priority == resp.Priority && load < respLoad:
+			resp, respLoad = t, load
+		}
+	}
+	return resp
+}
+
+// filterConcurrencyGroups removes the candidates whose concurrency group already has a started task in the project,
+// and the candidates which are not the earliest waiting task of their group, so that the tasks in a group run in queue order.
+// the tasks are expected to be sorted by the create time.
+func filterConcurrencyGroups(candidates, tasks []*commonmodels.WorkflowQueue) []*commonmodels.WorkflowQueue {
+	busy := make(map[string]bool)
+	heads := make(map[string]*commonmodels.WorkflowQueue)
+	for _, t := range tasks {
+		if t.ConcurrencyGroup == "" {
+			continue
+		}
+		group := t.ProjectName + "/" + t.ConcurrencyGroup
+		if t.Status != config.StatusWaiting {
+			busy[group] = true
+		} else if _, ok := heads[group]; !ok {
+			heads[group] = t
+		}
+	}
+	resp := make([]*commonmodels.WorkflowQueue, 0, len(candidates))
+	for _, t := range candidates {
+		if t.ConcurrencyGroup == "" {
+			resp = append(resp, t)
+			continue
+		}
+		group := t.ProjectName + "/" + t.ConcurrencyGroup
+		if busy[group] {
+			continue
+		}
+		if head, ok := heads[group]; ok && (head.WorkflowName != t.WorkflowName || head.TaskID != t.TaskID) {
+			continue
+		}
+		resp = append(resp, t)
+	}
+	return resp
+}
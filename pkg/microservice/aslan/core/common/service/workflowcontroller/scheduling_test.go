@@ -0,0 +1,78 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflowcontroller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+)
+
+func TestPickQueueTask(t *testing.T) {
+	a1 := &commonmodels.WorkflowQueue{ProjectName: "a", TaskID: 1}
+	a2 := &commonmodels.WorkflowQueue{ProjectName: "a", TaskID: 2}
+	b1 := &commonmodels.WorkflowQueue{ProjectName: "b", TaskID: 1}
+	hotfix := &commonmodels.WorkflowQueue{ProjectName: "a", TaskID: 3, Priority: 10}
+
+	// fifo without any setting
+	assert.Equal(t, a1, pickQueueTask([]*commonmodels.WorkflowQueue{a1, a2, b1}, map[string]int{}, nil))
+	// fair share: project a is already running a task
+	assert.Equal(t, b1, pickQueueTask([]*commonmodels.WorkflowQueue{a1, a2, b1}, map[string]int{"a": 1}, nil))
+	// priority goes first
+	assert.Equal(t, hotfix, pickQueueTask([]*commonmodels.WorkflowQueue{a1, b1, hotfix}, map[string]int{"a": 1}, nil))
+
+	scheduling := &commonmodels.WorkflowSchedulingSettings{
+		DefaultProjectConcurrency: 2,
+		Projects: []*commonmodels.ProjectSchedulingSetting{
+			{ProjectName: "a", Weight: 3},
+			{ProjectName: "b", Concurrency: 1},
+		},
+	}
+	// weight: the running task of a weighs less than the one of b
+	assert.Equal(t, a1, pickQueueTask([]*commonmodels.WorkflowQueue{b1, a1}, map[string]int{"a": 1, "b": 1}, &commonmodels.WorkflowSchedulingSettings{
+		Projects: []*commonmodels.ProjectSchedulingSetting{{ProjectName: "a", Weight: 3}},
+	}))
+	// quota: b reaches its own quota, a reaches the default one
+	assert.Nil(t, pickQueueTask([]*commonmodels.WorkflowQueue{a1, b1, hotfix}, map[string]int{"a": 2, "b": 1}, scheduling))
+	assert.Equal(t, hotfix, pickQueueTask([]*commonmodels.WorkflowQueue{a1, b1, hotfix}, map[string]int{"a": 1, "b": 1}, scheduling))
+}
+
+func TestCountProjectTasks(t *testing.T) {
+	counts := countProjectTasks([]*commonmodels.WorkflowQueue{
+		{ProjectName: "a", Status: config.StatusRunning},
+		{ProjectName: "a", Status: config.StatusWaiting},
+		{ProjectName: "b", Status: config.StatusWaitingApprove},
+	})
+	assert.Equal(t, map[string]int{"a": 1, "b": 1}, counts)
+}
+
+func TestFilterConcurrencyGroups(t *testing.T) {
+	running := &commonmodels.WorkflowQueue{ProjectName: "a", WorkflowName: "pr", TaskID: 1, ConcurrencyGroup: "pr-1", Status: config.StatusRunning}
+	pr1 := &commonmodels.WorkflowQueue{ProjectName: "a", WorkflowName: "pr", TaskID: 2, ConcurrencyGroup: "pr-1", Status: config.StatusWaiting}
+	pr2 := &commonmodels.WorkflowQueue{ProjectName: "a", WorkflowName: "pr", TaskID: 3, ConcurrencyGroup: "pr-2", Status: config.StatusWaiting}
+	pr2Next := &commonmodels.WorkflowQueue{ProjectName: "a", WorkflowName: "lint", TaskID: 1, ConcurrencyGroup: "pr-2", Status: config.StatusWaiting}
+	otherProject := &commonmodels.WorkflowQueue{ProjectName: "b", WorkflowName: "pr", TaskID: 1, ConcurrencyGroup: "pr-1", Status: config.StatusWaiting}
+	noGroup := &commonmodels.WorkflowQueue{ProjectName: "a", WorkflowName: "build", TaskID: 1, Status: config.StatusWaiting}
+
+	tasks := []*commonmodels.WorkflowQueue{running, pr1, pr2, pr2Next, otherProject, noGroup}
+	assert.Equal(t, []*commonmodels.WorkflowQueue{pr2, otherProject, noGroup}, filterConcurrencyGroups(tasks[1:], tasks))
+	// the later task waits for the earlier one even if the earlier one is not a candidate
+	assert.Empty(t, filterConcurrencyGroups([]*commonmodels.WorkflowQueue{pr2Next}, tasks))
+}
@@ -18,6 +18,7 @@ package workflowcontroller
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -26,6 +27,7 @@ import (
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
 	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
 	approvalservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/approval"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/workflowcontroller/jobcontroller"
 )
 
 type StageCtl interface {
@@ -33,6 +35,22 @@ type StageCtl interface {
 }
 
 func runStage(ctx context.Context, stage *commonmodels.StageTask, workflowCtx *commonmodels.WorkflowTaskCtx, concurrency int, logger *zap.SugaredLogger, ack func()) {
+	if stage.When != "" {
+		run, err := jobcontroller.EvaluateCondition(stage.When, workflowCtx)
+		if err != nil {
+			stage.Status = config.StatusFailed
+			stage.Error = err.Error()
+			logger.Errorf("finish stage: %s,status: %s error: %s", stage.Name, stage.Status, stage.Error)
+			ack()
+			return
+		}
+		if !run {
+			skipStage(stage, fmt.Sprintf("stage condition %s is evaluated to false", stage.When))
+			logger.Infof("skip stage: %s,status: %s", stage.Name, stage.Status)
+			ack()
+			return
+		}
+	}
 	stage.Status = config.StatusRunning
 	ack()
 	logger.Infof("start stage: %s,status: %s", stage.Name, stage.Status)
@@ -49,6 +67,8 @@ func runStage(ctx context.Context, stage *commonmodels.StageTask, workflowCtx *c
 		return
 	}
 
+	ctx, cancel := withStageTimeout(ctx, stage)
+	defer cancel()
 	defer func() {
 		updateStageStatus(ctx, stage)
 		stage.EndTime = time.Now().Unix()
@@ -64,11 +84,20 @@ func runStage(ctx context.Context, stage *commonmodels.StageTask, workflowCtx *c
 }
 
 func RunStages(ctx context.Context, stages []*commonmodels.StageTask, workflowCtx *commonmodels.WorkflowTaskCtx, concurrency int, logger *zap.SugaredLogger, ack func()) {
+	// jobs with needs are scheduled as a DAG instead of stage by stage
+	if stagesHaveNeeds(stages) {
+		runStagesDAG(ctx, stages, workflowCtx, concurrency, logger, ack)
+		return
+	}
 	for _, stage := range stages {
-		// should skip passed stage when workflow task be restarted
-		if stage.Status == config.StatusPassed {
+		// should skip passed or skipped stage when workflow task be restarted
+		if stage.Status == config.StatusPassed || stage.Status == config.StatusSkipped {
 			continue
 		}
+		// the workflow is cancelled or timed out before the stage starts
+		if ctx.Err() != nil {
+			return
+		}
 		runStage(ctx, stage, workflowCtx, concurrency, logger, ack)
 		if statusStopped(stage.Status) {
 			return
@@ -76,6 +105,92 @@ func RunStages(ctx context.Context, stages []*commonmodels.StageTask, workflowCt
 	}
 }
 
+// withStageTimeout returns a context which is done when the timeout of the stage is reached.
+func withStageTimeout(ctx context.Context, stage *commonmodels.StageTask) (context.Context, context.CancelFunc) {
+	if stage.Timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, time.Duration(stage.Timeout)*time.Minute)
+}
+
+// statusCompleted returns true if the job or stage is finished by itself rather than stopped.
+func statusCompleted(status config.Status) bool {
+	return status == config.StatusPassed || status == config.StatusSkipped || status == config.StatusUnstable ||
+		status == config.StatusFailed || status == config.StatusTimeout || status == config.StatusReject
+}
+
+// timeoutStage sets the stage and all of its unfinished jobs to timeout,
+// the jobs stopped by the deadline of the context are set to cancelled by their controllers.
+func timeoutStage(stage *commonmodels.StageTask, reason string) {
+	now := time.Now().Unix()
+	for _, job := range stage.Jobs {
+		if statusCompleted(job.Status) {
+			continue
+		}
+		job.Status = config.StatusTimeout
+		if job.Error == "" {
+			job.Error = reason
+		}
+		if job.EndTime == 0 {
+			job.EndTime = now
+		}
+	}
+	stage.Status = config.StatusTimeout
+	stage.Error = reason
+}
+
+// WorkflowStatusKey is the variable of the workflow status available to the finally stage
+const WorkflowStatusKey = "{{.workflow.status}}"
+
+func splitFinallyStages(stages []*commonmodels.StageTask) ([]*commonmodels.StageTask, []*commonmodels.StageTask) {
+	normalStages := make([]*commonmodels.StageTask, 0, len(stages))
+	finallyStages := make([]*commonmodels.StageTask, 0)
+	for _, stage := range stages {
+		if stage.Finally {
+			finallyStages = append(finallyStages, stage)
+			continue
+		}
+		normalStages = append(normalStages, stage)
+	}
+	return normalStages, finallyStages
+}
+
+// runFinallyStages runs the finally stages after the other stages are finished, whatever the result is.
+// the result of the other stages is set to the workflow.status variable,
+// and the finally stages are not stopped by the cancellation or the timeout of the workflow.
+func runFinallyStages(ctx context.Context, stages, finallyStages []*commonmodels.StageTask, workflowCtx *commonmodels.WorkflowTaskCtx, concurrency int, logger *zap.SugaredLogger, ack func()) {
+	if len(finallyStages) == 0 {
+		return
+	}
+	status := calculateWorkflowStatus(stages)
+	switch status {
+	case config.StatusPause:
+		// the workflow will be resumed by the manual execution, the finally stages run after that
+		return
+	case config.StatusUnstable:
+		status = config.StatusPassed
+	}
+	workflowCtx.GlobalContextSet(WorkflowStatusKey, string(status))
+
+	ctx = context.WithoutCancel(ctx)
+	for _, stage := range finallyStages {
+		runStage(ctx, stage, workflowCtx, concurrency, logger, ack)
+	}
+}
+
+func skipStage(stage *commonmodels.StageTask, reason string) {
+	now := time.Now().Unix()
+	for _, job := range stage.Jobs {
+		job.Status = config.StatusSkipped
+		job.SkipReason = reason
+		job.StartTime = now
+		job.EndTime = now
+	}
+	stage.Status = config.StatusSkipped
+	stage.StartTime = now
+	stage.EndTime = now
+}
+
 func ApproveStage(workflowName, jobName, userName, userID, comment string, taskID int64, approve bool) error {
 	approveKey := fmt.Sprintf("%s-%s-%d", workflowName, jobName, taskID)
 	_, err := approvalservice.GlobalApproveMap.DoApproval(approveKey, userName, userID, comment, approve)
@@ -110,6 +225,10 @@ func statusStopped(status config.Status) bool {
 func updateStageStatus(ctx context.Context, stage *commonmodels.StageTask) {
 	select {
 	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			timeoutStage(stage, "stopped because of timeout")
+			return
+		}
 		stage.Status = config.StatusCancelled
 		return
 	default:
@@ -63,18 +63,25 @@ func (c *CustomStageCtl) AfterRun() {
 	// set IMAGES workflow variable
 	// set after a stage has been done for build and some other type job maybe split to many job tasks in one stage
 	// after stage run, concurrent competition of workflowCtx.GlobalContext is not exist
+	for jobName, images := range collectJobImages(c.workflowCtx) {
+		setJobImages(c.workflowCtx, jobName, images)
+	}
+}
 
+func collectJobImages(workflowCtx *commonmodels.WorkflowTaskCtx) map[string][]string {
 	jobImages := map[string][]string{}
-	for k, v := range c.workflowCtx.GlobalContextGetAll() {
+	for k, v := range workflowCtx.GlobalContextGetAll() {
 		list := reg.FindStringSubmatch(k)
 		if len(list) > 0 {
 			jobImages[list[1]] = append(jobImages[list[1]], v)
 		}
 	}
-	for jobName, images := range jobImages {
-		key := fmt.Sprintf("{{.job.%s.IMAGES}}", jobName)
-		if _, ok := c.workflowCtx.GlobalContextGet(key); !ok {
-			c.workflowCtx.GlobalContextSet(key, strings.Join(images, ","))
-		}
+	return jobImages
+}
+
+func setJobImages(workflowCtx *commonmodels.WorkflowTaskCtx, jobName string, images []string) {
+	key := fmt.Sprintf("{{.job.%s.IMAGES}}", jobName)
+	if _, ok := workflowCtx.GlobalContextGet(key); !ok {
+		workflowCtx.GlobalContextSet(key, strings.Join(images, ","))
 	}
 }
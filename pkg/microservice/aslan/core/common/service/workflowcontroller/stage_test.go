@@ -0,0 +1,77 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflowcontroller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+)
+
+func TestUpdateStageStatusTimeout(t *testing.T) {
+	newStage := func() *commonmodels.StageTask {
+		return &commonmodels.StageTask{
+			Name: "build",
+			Jobs: []*commonmodels.JobTask{
+				{Name: "passed", Status: config.StatusPassed},
+				{Name: "running", Status: config.StatusCancelled},
+				{Name: "created", Status: config.StatusCreated},
+			},
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	<-ctx.Done()
+	stage := newStage()
+	updateStageStatus(ctx, stage)
+	assert.Equal(t, config.StatusTimeout, stage.Status)
+	assert.Equal(t, config.StatusPassed, stage.Jobs[0].Status)
+	assert.Equal(t, config.StatusTimeout, stage.Jobs[1].Status)
+	assert.Equal(t, config.StatusTimeout, stage.Jobs[2].Status)
+	assert.NotZero(t, stage.Jobs[2].EndTime)
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	stage = newStage()
+	updateStageStatus(ctx, stage)
+	assert.Equal(t, config.StatusCancelled, stage.Status)
+	assert.Equal(t, config.StatusCancelled, stage.Jobs[1].Status)
+}
+
+func TestCalculateWorkflowStatusWithFinally(t *testing.T) {
+	stages := []*commonmodels.StageTask{
+		{Name: "build", Status: config.StatusPassed},
+		{Name: "cleanup", Status: config.StatusFailed, Finally: true},
+	}
+	normalStages, finallyStages := splitFinallyStages(stages)
+	assert.Len(t, normalStages, 1)
+	assert.Len(t, finallyStages, 1)
+	assert.Equal(t, config.StatusPassed, calculateWorkflowStatus(stages))
+
+	stages[1].FinallyAffectsStatus = true
+	assert.Equal(t, config.StatusFailed, calculateWorkflowStatus(stages))
+
+	stages[0].Status = config.StatusCancelled
+	stages[1].Status = config.StatusPassed
+	assert.Equal(t, config.StatusCancelled, calculateWorkflowStatus(stages))
+}
@@ -101,6 +101,14 @@ func instantiateStepCtl(step *commonmodels.StepTask, workflowCtx *commonmodels.W
 		stepCtl, err = NewSonarGetMetricsCtl(step, workflowCtx, logger)
 	case config.StepDistributeImage:
 		stepCtl, err = NewDistributeCtl(step, workflowCtx, jobKey, logger)
+	case config.StepImageScan:
+		stepCtl, err = NewImageScanCtl(step, workflowCtx, logger)
+	case config.StepImageSign:
+		stepCtl, err = NewImageSignCtl(step, logger)
+	case config.StepSBOM:
+		stepCtl, err = NewSBOMCtl(step, workflowCtx, logger)
+	case config.StepCoverageReport:
+		stepCtl, err = NewCoverageReportCtl(step, logger)
 	case config.StepDebugBefore, config.StepDebugAfter:
 		stepCtl, err = NewDebugCtl()
 	default:
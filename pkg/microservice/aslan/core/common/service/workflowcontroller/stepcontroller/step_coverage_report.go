@@ -0,0 +1,140 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stepcontroller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v2"
+
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/tool/coverage"
+	s3tool "github.com/koderover/zadig/v2/pkg/tool/s3"
+	"github.com/koderover/zadig/v2/pkg/types/step"
+	"github.com/koderover/zadig/v2/pkg/util"
+)
+
+type coverageReportCtl struct {
+	step               *commonmodels.StepTask
+	coverageReportSpec *step.StepCoverageReportSpec
+	log                *zap.SugaredLogger
+}
+
+func NewCoverageReportCtl(stepTask *commonmodels.StepTask, log *zap.SugaredLogger) (*coverageReportCtl, error) {
+	yamlString, err := yaml.Marshal(stepTask.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("marshal coverage report spec error: %v", err)
+	}
+	coverageReportSpec := &step.StepCoverageReportSpec{}
+	if err := yaml.Unmarshal(yamlString, &coverageReportSpec); err != nil {
+		return nil, fmt.Errorf("unmarshal coverage report spec error: %v", err)
+	}
+	stepTask.Spec = coverageReportSpec
+	return &coverageReportCtl{coverageReportSpec: coverageReportSpec, log: log, step: stepTask}, nil
+}
+
+func (s *coverageReportCtl) PreRun(ctx context.Context) error {
+	if s.coverageReportSpec.S3Storage == nil {
+		modelS3, err := commonrepo.NewS3StorageColl().FindDefault()
+		if err != nil {
+			return err
+		}
+		s.coverageReportSpec.S3Storage = modelS3toS3(modelS3)
+	}
+
+	// compare with the latest coverage of the target branch on PR-triggered tasks
+	if s.coverageReportSpec.PR > 0 && s.coverageReportSpec.Branch != "" {
+		base, err := commonrepo.NewTestCoverageColl().GetLatestOfBranch(s.coverageReportSpec.TestProject, s.coverageReportSpec.TestName, s.coverageReportSpec.Branch)
+		if err == nil {
+			s.coverageReportSpec.BaseCoverage = &base.Coverage
+		} else {
+			s.log.Infof("no coverage of the target branch %s found for testing %s: %v", s.coverageReportSpec.Branch, s.coverageReportSpec.TestName, err)
+		}
+	}
+	s.step.Spec = s.coverageReportSpec
+	return nil
+}
+
+func (s *coverageReportCtl) AfterRun(ctx context.Context) error {
+	if s.coverageReportSpec.S3Storage == nil || s.coverageReportSpec.FileName == "" {
+		return nil
+	}
+
+	filename, err := util.GenerateTmpFile()
+	if err != nil {
+		s.log.Errorf("GenerateTmpFile err: %v", err)
+		return nil
+	}
+	defer os.Remove(filename)
+
+	storage := s.coverageReportSpec.S3Storage
+	client, err := s3tool.NewClient(storage.Endpoint, storage.Ak, storage.Sk, storage.Region, storage.Insecure, storage.Provider)
+	if err != nil {
+		s.log.Errorf("failed to create s3 client to download coverage report, err: %v", err)
+		return nil
+	}
+	objectKey := strings.TrimLeft(path.Join(storage.Subfolder, s.coverageReportSpec.S3DestDir, s.coverageReportSpec.FileName), "/")
+	if err := client.Download(storage.Bucket, objectKey, filename); err != nil {
+		// the report is not uploaded if the coverage report step failed before parsing
+		s.log.Warnf("failed to download coverage report %s: %v", objectKey, err)
+		return nil
+	}
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		s.log.Errorf("failed to read coverage report: %v", err)
+		return nil
+	}
+	report := new(coverage.Report)
+	if err := json.Unmarshal(content, report); err != nil {
+		s.log.Errorf("failed to unmarshal coverage report: %v", err)
+		return nil
+	}
+
+	testCoverage := &commonmodels.TestCoverage{
+		ProjectName:  s.coverageReportSpec.TestProject,
+		TestName:     s.coverageReportSpec.TestName,
+		WorkflowName: s.coverageReportSpec.SourceWorkflow,
+		JobTaskName:  s.coverageReportSpec.JobTaskName,
+		TaskID:       s.coverageReportSpec.TaskID,
+		Branch:       s.coverageReportSpec.Branch,
+		PR:           s.coverageReportSpec.PR,
+		CommitID:     s.coverageReportSpec.CommitID,
+		Format:       report.Format,
+		Covered:      report.Covered,
+		Total:        report.Total,
+		Coverage:     report.Rate(),
+		BaseCoverage: s.coverageReportSpec.BaseCoverage,
+		Files:        report.Files,
+		CreateTime:   time.Now().Unix(),
+	}
+	if testCoverage.BaseCoverage != nil {
+		delta := coverage.Delta(testCoverage.Coverage, *testCoverage.BaseCoverage)
+		testCoverage.Delta = &delta
+	}
+	if err := commonrepo.NewTestCoverageColl().Create(testCoverage); err != nil {
+		s.log.Errorf("failed to save coverage of testing %s: %v", s.coverageReportSpec.TestName, err)
+	}
+	return nil
+}
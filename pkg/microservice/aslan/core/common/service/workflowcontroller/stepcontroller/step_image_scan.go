@@ -0,0 +1,87 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stepcontroller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v2"
+
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/setting"
+	"github.com/koderover/zadig/v2/pkg/types/job"
+	"github.com/koderover/zadig/v2/pkg/types/step"
+)
+
+type imageScanCtl struct {
+	step          *commonmodels.StepTask
+	imageScanSpec *step.StepImageScanSpec
+	log           *zap.SugaredLogger
+	workflowCtx   *commonmodels.WorkflowTaskCtx
+}
+
+func NewImageScanCtl(stepTask *commonmodels.StepTask, workflowCtx *commonmodels.WorkflowTaskCtx, log *zap.SugaredLogger) (*imageScanCtl, error) {
+	yamlString, err := yaml.Marshal(stepTask.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("marshal image scan spec error: %v", err)
+	}
+	imageScanSpec := &step.StepImageScanSpec{}
+	if err := yaml.Unmarshal(yamlString, &imageScanSpec); err != nil {
+		return nil, fmt.Errorf("unmarshal image scan spec error: %v", err)
+	}
+	stepTask.Spec = imageScanSpec
+	return &imageScanCtl{imageScanSpec: imageScanSpec, log: log, step: stepTask, workflowCtx: workflowCtx}, nil
+}
+
+func (s *imageScanCtl) PreRun(ctx context.Context) error {
+	return nil
+}
+
+func (s *imageScanCtl) AfterRun(ctx context.Context) error {
+	key := job.GetJobOutputKey(s.step.JobKey, setting.WorkflowImageScanJobOutputKey)
+	value, ok := s.workflowCtx.GlobalContextGet(key)
+	if !ok {
+		err := fmt.Errorf("image scan job output %s not found", key)
+		s.log.Error(err)
+		return err
+	}
+
+	results := make([]*step.ImageScanResult, 0)
+	if err := json.Unmarshal([]byte(value), &results); err != nil {
+		err = fmt.Errorf("unmarshal image scan results error: %v", err)
+		s.log.Error(err)
+		return err
+	}
+	s.imageScanSpec.Results = results
+	s.step.Spec = s.imageScanSpec
+
+	scanTime := time.Now().Unix()
+	for _, result := range results {
+		if result.Summary == nil {
+			continue
+		}
+		if err := commonrepo.NewDeliveryArtifactColl().UpdateVulnerabilitySummary(result.Image, result.Summary, scanTime); err != nil {
+			s.log.Errorf("failed to attach scan result to the delivery artifact of image %s: %v", result.Image, err)
+		}
+	}
+	return nil
+}
@@ -65,6 +65,19 @@ func (s *junitReportCtl) PreRun(ctx context.Context) error {
 		}
 		s.junitReportSpec.S3Storage = modelS3toS3(modelS3)
 	}
+	if s.junitReportSpec.TestName != "" {
+		quarantines, err := commonrepo.NewTestCaseQuarantineColl().List(s.junitReportSpec.TestProject, s.junitReportSpec.TestName)
+		if err != nil {
+			s.log.Errorf("list quarantined test cases of %s error: %v", s.junitReportSpec.TestName, err)
+		}
+		s.junitReportSpec.QuarantinedCases = make([]*step.QuarantinedTestCase, 0)
+		for _, quarantine := range quarantines {
+			s.junitReportSpec.QuarantinedCases = append(s.junitReportSpec.QuarantinedCases, &step.QuarantinedTestCase{
+				ClassName: quarantine.ClassName,
+				Name:      quarantine.CaseName,
+			})
+		}
+	}
 	s.step.Spec = s.junitReportSpec
 	return nil
 }
@@ -156,5 +169,29 @@ func (s *junitReportCtl) AfterRun(ctx context.Context) error {
 		log.Error("save junit test result failed, error: %v", err)
 	}
 
+	// save the result of each test case for the trend and flakiness analysis
+	now := time.Now()
+	histories := make([]*commonmodels.TestCaseHistory, 0, len(testReport.TestCases))
+	for _, testCase := range testReport.TestCases {
+		histories = append(histories, &commonmodels.TestCaseHistory{
+			ProjectName:  s.junitReportSpec.TestProject,
+			TestName:     s.junitReportSpec.TestName,
+			WorkflowName: s.junitReportSpec.SourceWorkflow,
+			JobTaskName:  s.junitReportSpec.JobTaskName,
+			TaskID:       s.junitReportSpec.TaskID,
+			CommitID:     s.junitReportSpec.CommitID,
+			ClassName:    testCase.ClassName,
+			CaseName:     testCase.Name,
+			Status:       commonmodels.TestCaseStatus(&testCase),
+			Duration:     testCase.Time,
+			Quarantined:  s.junitReportSpec.IsQuarantined(testCase.ClassName, testCase.Name),
+			CreateTime:   now.Unix(),
+			CreatedAt:    now,
+		})
+	}
+	if err := commonrepo.NewTestCaseHistoryColl().BulkCreate(histories); err != nil {
+		log.Errorf("save test case history failed, error: %v", err)
+	}
+
 	return nil
 }
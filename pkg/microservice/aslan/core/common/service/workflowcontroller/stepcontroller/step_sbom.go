@@ -0,0 +1,119 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stepcontroller
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v2"
+
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/tool/s3"
+	"github.com/koderover/zadig/v2/pkg/tool/sbom"
+	"github.com/koderover/zadig/v2/pkg/types/step"
+)
+
+type sbomCtl struct {
+	step        *commonmodels.StepTask
+	sbomSpec    *step.StepSBOMSpec
+	log         *zap.SugaredLogger
+	workflowCtx *commonmodels.WorkflowTaskCtx
+}
+
+func NewSBOMCtl(stepTask *commonmodels.StepTask, workflowCtx *commonmodels.WorkflowTaskCtx, log *zap.SugaredLogger) (*sbomCtl, error) {
+	yamlString, err := yaml.Marshal(stepTask.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("marshal sbom spec error: %v", err)
+	}
+	sbomSpec := &step.StepSBOMSpec{}
+	if err := yaml.Unmarshal(yamlString, &sbomSpec); err != nil {
+		return nil, fmt.Errorf("unmarshal sbom spec error: %v", err)
+	}
+	stepTask.Spec = sbomSpec
+	return &sbomCtl{sbomSpec: sbomSpec, log: log, step: stepTask, workflowCtx: workflowCtx}, nil
+}
+
+func (s *sbomCtl) PreRun(ctx context.Context) error {
+	return nil
+}
+
+// AfterRun indexes the packages of the archived sbom and links it to the image artifact,
+// nothing is recorded if the sbom was not generated.
+func (s *sbomCtl) AfterRun(ctx context.Context) error {
+	if s.sbomSpec.S3 == nil || s.sbomSpec.ObjectKey == "" {
+		return nil
+	}
+	client, err := s3.NewClient(s.sbomSpec.S3.Endpoint, s.sbomSpec.S3.Ak, s.sbomSpec.S3.Sk, s.sbomSpec.S3.Region, s.sbomSpec.S3.Insecure, s.sbomSpec.S3.Provider)
+	if err != nil {
+		s.log.Errorf("failed to create s3 client to download sbom, err: %s", err)
+		return nil
+	}
+	tmpFile, err := os.CreateTemp("", "sbom-")
+	if err != nil {
+		s.log.Errorf("failed to create temp file for sbom, err: %s", err)
+		return nil
+	}
+	_ = tmpFile.Close()
+	defer os.Remove(tmpFile.Name())
+
+	if err := client.Download(s.sbomSpec.S3.Bucket, s.sbomSpec.ObjectKey, tmpFile.Name()); err != nil {
+		s.log.Warnf("sbom of image %s not found in object storage, err: %s", s.sbomSpec.Image, err)
+		return nil
+	}
+	data, err := os.ReadFile(tmpFile.Name())
+	if err != nil {
+		s.log.Errorf("failed to read sbom of image %s, err: %s", s.sbomSpec.Image, err)
+		return nil
+	}
+	packages, err := sbom.ParsePackages(s.sbomSpec.Format, data)
+	if err != nil {
+		s.log.Errorf("failed to parse sbom of image %s, err: %s", s.sbomSpec.Image, err)
+		return nil
+	}
+
+	imageSBOM := &commonmodels.ImageSBOM{
+		Image:           s.sbomSpec.Image,
+		ServiceName:     s.sbomSpec.ServiceName,
+		ServiceModule:   s.sbomSpec.ServiceModule,
+		ProjectName:     s.workflowCtx.ProjectName,
+		WorkflowName:    s.workflowCtx.WorkflowName,
+		TaskID:          s.workflowCtx.TaskID,
+		Format:          s.sbomSpec.Format,
+		ObjectStorageID: s.sbomSpec.ObjectStorageID,
+		ObjectKey:       s.sbomSpec.ObjectKey,
+		Packages:        packages,
+		CreatedTime:     time.Now().Unix(),
+	}
+	if err := commonrepo.NewImageSBOMColl().Upsert(imageSBOM); err != nil {
+		s.log.Errorf("failed to save sbom of image %s, err: %s", s.sbomSpec.Image, err)
+		return nil
+	}
+	saved, err := commonrepo.NewImageSBOMColl().GetByImage(s.sbomSpec.Image)
+	if err != nil {
+		s.log.Errorf("failed to find sbom of image %s, err: %s", s.sbomSpec.Image, err)
+		return nil
+	}
+	if err := commonrepo.NewDeliveryArtifactColl().UpdateSBOM(s.sbomSpec.Image, saved.ID.Hex(), s.sbomSpec.Format); err != nil {
+		s.log.Errorf("failed to link sbom to the delivery artifact of image %s, err: %s", s.sbomSpec.Image, err)
+	}
+	return nil
+}
@@ -19,6 +19,7 @@ package workflowcontroller
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"sync"
@@ -185,7 +186,10 @@ func (c *workflowCtl) Run(ctx context.Context, concurrency int) {
 	}
 
 	c.workflowTask.Status = config.StatusRunning
-	c.workflowTask.StartTime = time.Now().Unix()
+	// a task resumed after pausing keeps its start time, so that its timeout counts the time before the pause
+	if c.workflowTask.StartTime == 0 || !stagesStarted(c.workflowTask.Stages) {
+		c.workflowTask.StartTime = time.Now().Unix()
+	}
 	c.ack()
 	c.logger.Infof("start workflow: %s,status: %s", c.workflowTask.WorkflowName, c.workflowTask.Status)
 	defer func() {
@@ -197,6 +201,11 @@ func (c *workflowCtl) Run(ctx context.Context, concurrency int) {
 	}()
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
+	if deadline, ok := workflowDeadline(c.workflowTask); ok {
+		var timeoutCancel context.CancelFunc
+		ctx, timeoutCancel = context.WithDeadline(ctx, deadline)
+		defer timeoutCancel()
+	}
 
 	// sub cancel signal from redis
 	cancelChan, closeFunc := cache.NewRedisCache(config2.RedisCommonCacheTokenDB()).Subscribe(fmt.Sprintf("workflowctl-cancel-%s-%d", c.workflowTask.WorkflowName, c.workflowTask.TaskID))
@@ -245,6 +254,10 @@ func (c *workflowCtl) Run(ctx context.Context, concurrency int) {
 		GlobalContextEach:           c.globalContextEach,
 		ClusterIDAdd:                c.addClusterID,
 		StartTime:                   time.Now(),
+		Params:                      c.workflowTask.Params,
+	}
+	if c.workflowTask.WorkflowArgs != nil && c.workflowTask.WorkflowArgs.HookPayload != nil {
+		workflowCtx.ChangedFiles = c.workflowTask.WorkflowArgs.HookPayload.ChangedFiles
 	}
 	defer jobcontroller.CleanWorkflowJobs(ctx, c.workflowTask, workflowCtx, c.logger, c.ack)
 	if err := scmnotify.NewService().UpdateWebhookCommentForWorkflowV4(c.workflowTask, c.logger); err != nil {
@@ -253,7 +266,17 @@ func (c *workflowCtl) Run(ctx context.Context, concurrency int) {
 	if err := scmnotify.NewService().UpdateGitCheckForWorkflowV4(c.workflowTask.WorkflowArgs, c.workflowTask.TaskID, c.logger); err != nil {
 		log.Warnf("Failed to update github check status for custom workflow %s, taskID: %d the error is: %s", c.workflowTask.WorkflowName, c.workflowTask.TaskID, err)
 	}
-	RunStages(ctx, c.workflowTask.Stages, workflowCtx, concurrency, c.logger, c.ack)
+	stages, finallyStages := splitFinallyStages(c.workflowTask.Stages)
+	RunStages(ctx, stages, workflowCtx, concurrency, c.logger, c.ack)
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		// the stages not started or waiting to be executed manually are timed out as well
+		for _, stage := range stages {
+			if !statusCompleted(stage.Status) {
+				timeoutStage(stage, fmt.Sprintf("workflow timeout after %d minutes", c.workflowTask.WorkflowArgs.Timeout))
+			}
+		}
+	}
+	runFinallyStages(ctx, stages, finallyStages, workflowCtx, concurrency, c.logger, c.ack)
 	updateworkflowStatus(c.workflowTask)
 }
 
@@ -480,6 +503,22 @@ FOR:
 }
 
 func updateworkflowStatus(workflow *commonmodels.WorkflowTask) {
+	workflowStatus := calculateWorkflowStatus(workflow.Stages)
+	if workflow.Status != workflowStatus {
+		SendWorkflowNotifyMessage(workflow, workflow.TaskCreator, workflowStatus, log.SugaredLogger())
+	}
+
+	// special case: if there is only 1 stage with unstable status, we still count it as passed
+	if workflowStatus == config.StatusUnstable {
+		workflowStatus = config.StatusPassed
+	}
+
+	workflow.Status = workflowStatus
+}
+
+// calculateWorkflowStatus returns the status of the workflow by the status of its stages,
+// the finally stages are counted only when they are set to affect the status.
+func calculateWorkflowStatus(stages []*commonmodels.StageTask) config.Status {
 	statusMap := map[config.Status]int{
 		config.StatusPause:     7,
 		config.StatusReject:    6,
@@ -494,14 +533,17 @@ func updateworkflowStatus(workflow *commonmodels.WorkflowTask) {
 	// 初始化workflowStatus为创建状态
 	workflowStatus := config.StatusRunning
 
-	stageStatus := make([]int, len(workflow.Stages))
+	stageStatus := make([]int, 0, len(stages))
 
-	for i, j := range workflow.Stages {
+	for _, j := range stages {
+		if j.Finally && !j.FinallyAffectsStatus {
+			continue
+		}
 		statusCode, ok := statusMap[j.Status]
 		if !ok {
 			statusCode = -1
 		}
-		stageStatus[i] = statusCode
+		stageStatus = append(stageStatus, statusCode)
 	}
 	var workflowStatusCode int
 	for i, code := range stageStatus {
@@ -516,16 +558,7 @@ func updateworkflowStatus(workflow *commonmodels.WorkflowTask) {
 			break
 		}
 	}
-	if workflow.Status != workflowStatus {
-		SendWorkflowNotifyMessage(workflow, workflow.TaskCreator, workflowStatus, log.SugaredLogger())
-	}
-
-	// special case: if there is only 1 stage with unstable status, we still count it as passed
-	if workflowStatus == config.StatusUnstable {
-		workflowStatus = config.StatusPassed
-	}
-
-	workflow.Status = workflowStatus
+	return workflowStatus
 }
 
 func (c *workflowCtl) updateWorkflowTask() {
@@ -624,6 +657,25 @@ func (c *workflowCtl) CleanShareStorage() {
 	cache.NewRedisCache(config2.RedisCommonCacheTokenDB()).Delete(c.prefix)
 }
 
+// stagesStarted returns true if any stage of the task has been run.
+func stagesStarted(stages []*commonmodels.StageTask) bool {
+	for _, stage := range stages {
+		if stage.StartTime > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// workflowDeadline returns the deadline of the task counted from its start time,
+// false is returned if the workflow has no timeout.
+func workflowDeadline(task *commonmodels.WorkflowTask) (time.Time, bool) {
+	if task.WorkflowArgs == nil || task.WorkflowArgs.Timeout <= 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(task.StartTime, 0).Add(time.Duration(task.WorkflowArgs.Timeout) * time.Minute), true
+}
+
 func (c *workflowCtl) addClusterID(clusterID string) {
 	c.workflowTaskMutex.Lock()
 	defer c.workflowTaskMutex.Unlock()
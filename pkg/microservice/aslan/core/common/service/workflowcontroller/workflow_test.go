@@ -0,0 +1,56 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflowcontroller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+)
+
+func TestWorkflowDeadline(t *testing.T) {
+	task := &commonmodels.WorkflowTask{StartTime: 1000}
+	_, ok := workflowDeadline(task)
+	assert.False(t, ok)
+
+	task.WorkflowArgs = &commonmodels.WorkflowV4{}
+	_, ok = workflowDeadline(task)
+	assert.False(t, ok)
+
+	// the deadline of a resumed task is counted from the time it started rather than the time it resumed
+	task.WorkflowArgs.Timeout = 10
+	deadline, ok := workflowDeadline(task)
+	assert.True(t, ok)
+	assert.Equal(t, time.Unix(1000+10*60, 0), deadline)
+}
+
+func TestStagesStarted(t *testing.T) {
+	stages := []*commonmodels.StageTask{
+		{Name: "build"},
+		{Name: "deploy"},
+	}
+	assert.False(t, stagesStarted(stages))
+
+	stages[0].Status = config.StatusPassed
+	stages[0].StartTime = 1000
+	stages[1].Status = config.StatusPause
+	assert.True(t, stagesStarted(stages))
+}
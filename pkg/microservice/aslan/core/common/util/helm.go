@@ -26,6 +26,7 @@ import (
 
 	"github.com/27149chen/afero"
 	"gopkg.in/yaml.v2"
+	"helm.sh/helm/v3/pkg/registry"
 	"helm.sh/helm/v3/pkg/repo"
 
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
@@ -320,12 +321,71 @@ func ReplaceImage(sourceYaml string, imageValuesMap ...map[string]interface{}) (
 }
 
 func GeneHelmRepo(chartRepo *commonmodels.HelmRepo) *repo.Entry {
-	return &repo.Entry{
+	entry := &repo.Entry{
 		Name:     chartRepo.RepoName,
 		URL:      chartRepo.URL,
 		Username: chartRepo.Username,
 		Password: chartRepo.Password,
 	}
+	if chartRepo.RegistryID != "" {
+		reg, err := commonrepo.NewRegistryNamespaceColl().Find(&commonrepo.FindRegOps{ID: chartRepo.RegistryID})
+		if err != nil {
+			log.Errorf("failed to find registry %s for chart repo %s, err: %s", chartRepo.RegistryID, chartRepo.RepoName, err)
+			return entry
+		}
+		reg, err = DecodeRegistry(reg)
+		if err != nil {
+			log.Errorf("failed to decode registry %s for chart repo %s, err: %s", chartRepo.RegistryID, chartRepo.RepoName, err)
+			return entry
+		}
+		entry.Username = reg.AccessKey
+		entry.Password = reg.SecretKey
+	}
+	return entry
+}
+
+// FindChartRepo finds the chart repo by name, an oci url like oci://registry/ns can also be used as the chart repo name,
+// the credential is reused from the OCI chart repo or the image registry with the same host which is available to the project,
+// only the ones available to all projects are used if the project is empty
+func FindChartRepo(chartRepoName, projectName string) (*commonmodels.HelmRepo, error) {
+	if !registry.IsOCI(chartRepoName) {
+		return commonrepo.NewHelmRepoColl().Find(&commonrepo.HelmRepoFindOption{RepoName: chartRepoName})
+	}
+
+	chartRepo := &commonmodels.HelmRepo{
+		RepoName: chartRepoName,
+		URL:      strings.TrimSuffix(chartRepoName, "/"),
+	}
+	host := helmtool.OCIHost(chartRepoName)
+	chartRepos, err := commonrepo.NewHelmRepoColl().ListByProject(projectName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list chart repos, err: %s", err)
+	}
+	for _, helmRepo := range chartRepos {
+		if registry.IsOCI(helmRepo.URL) && helmtool.OCIHost(helmRepo.URL) == host {
+			chartRepo.Username = helmRepo.Username
+			chartRepo.Password = helmRepo.Password
+			chartRepo.RegistryID = helmRepo.RegistryID
+			chartRepo.EnableProxy = helmRepo.EnableProxy
+			return chartRepo, nil
+		}
+	}
+
+	registries, err := commonrepo.NewRegistryNamespaceColl().FindByProject(projectName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list registries, err: %s", err)
+	}
+	for _, reg := range registries {
+		regAddr, err := reg.GetRegistryAddress()
+		if err != nil {
+			continue
+		}
+		if strings.TrimSuffix(regAddr, "/") == host {
+			chartRepo.RegistryID = reg.ID.Hex()
+			break
+		}
+	}
+	return chartRepo, nil
 }
 
 func GetValidMatchData(spec *commonmodels.ImagePathSpec) map[string]string {
@@ -345,6 +405,22 @@ func GetValidMatchData(spec *commonmodels.ImagePathSpec) map[string]string {
 	return ret
 }
 
+// ResolveOCIChartRef splits the chart name like oci://registry/ns/chart:version into the chart repo, chart name and version
+// when no chart repo is specified, the version in the reference takes precedence over the given chart version
+func ResolveOCIChartRef(chartRepo, chartName, chartVersion string) (string, string, string, error) {
+	if chartRepo != "" || !registry.IsOCI(chartName) {
+		return chartRepo, chartName, chartVersion, nil
+	}
+	repoURL, name, version, err := helmtool.ParseOCIChartRef(chartName)
+	if err != nil {
+		return "", "", "", err
+	}
+	if version == "" {
+		version = chartVersion
+	}
+	return repoURL, name, version, nil
+}
+
 func NewHelmClient(chartRepo *commonmodels.HelmRepo) (*helmtool.HelmClient, error) {
 	client, err := helmtool.NewClient()
 	if err != nil {
@@ -0,0 +1,129 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+)
+
+// only the latest snapshots taken automatically by workflows or before restorations are kept for each env and source,
+// snapshots created manually are never cleaned
+const autoEnvSnapshotLimit = 30
+
+type CreateEnvSnapshotArgs struct {
+	Name         string
+	Source       config.EnvSnapshotSource
+	WorkflowName string
+	TaskID       int64
+	CreateBy     string
+}
+
+// GenerateEnvSnapshot collects the services, global variables and configmaps of the env without saving it
+func GenerateEnvSnapshot(env *models.Product, args *CreateEnvSnapshotArgs) (*models.EnvSnapshot, error) {
+	configMaps, err := listLatestEnvConfigMaps(env)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list configmaps of env %s/%s, error: %v", env.ProductName, env.EnvName, err)
+	}
+
+	return &models.EnvSnapshot{
+		Name:            args.Name,
+		ProductName:     env.ProductName,
+		EnvName:         env.EnvName,
+		Production:      env.Production,
+		Source:          args.Source,
+		WorkflowName:    args.WorkflowName,
+		TaskID:          args.TaskID,
+		Services:        env.GetSvcList(),
+		DefaultValues:   env.DefaultValues,
+		YamlData:        env.YamlData,
+		GlobalVariables: env.GlobalVariables,
+		ConfigMaps:      configMaps,
+		CreateBy:        args.CreateBy,
+	}, nil
+}
+
+// CreateEnvSnapshot takes a snapshot of the env and saves it
+func CreateEnvSnapshot(env *models.Product, args *CreateEnvSnapshotArgs, log *zap.SugaredLogger) (*models.EnvSnapshot, error) {
+	snapshot, err := GenerateEnvSnapshot(env, args)
+	if err != nil {
+		return nil, err
+	}
+	if err := commonrepo.NewEnvSnapshotColl().Create(snapshot); err != nil {
+		return nil, fmt.Errorf("failed to create snapshot for env %s/%s, error: %v", env.ProductName, env.EnvName, err)
+	}
+	log.Infof("Create environment snapshot %s for %s/%s, isProduction %v", snapshot.Name, env.ProductName, env.EnvName, env.Production)
+
+	if args.Source == config.EnvSnapshotSourceWorkflow || args.Source == config.EnvSnapshotSourceRestore {
+		expired, err := commonrepo.NewEnvSnapshotColl().List(&commonrepo.EnvSnapshotListOption{
+			ProductName: env.ProductName,
+			EnvName:     env.EnvName,
+			Production:  env.Production,
+			Source:      args.Source,
+			Skip:        autoEnvSnapshotLimit,
+		})
+		if err != nil {
+			log.Errorf("failed to list expired snapshots of env %s/%s, error: %v", env.ProductName, env.EnvName, err)
+			return snapshot, nil
+		}
+		ids := make([]primitive.ObjectID, 0, len(expired))
+		for _, s := range expired {
+			ids = append(ids, s.ID)
+		}
+		if err := commonrepo.NewEnvSnapshotColl().DeleteByIDs(ids); err != nil {
+			log.Errorf("failed to delete expired snapshots of env %s/%s, error: %v", env.ProductName, env.EnvName, err)
+		}
+	}
+
+	return snapshot, nil
+}
+
+// listLatestEnvConfigMaps returns the latest version of the configmaps managed in the env
+func listLatestEnvConfigMaps(env *models.Product) ([]*models.EnvSnapshotConfigMap, error) {
+	resources, err := commonrepo.NewEnvResourceColl().List(&commonrepo.QueryEnvResourceOption{
+		ProductName: env.ProductName,
+		EnvName:     env.EnvName,
+		Type:        string(config.CommonEnvCfgTypeConfigMap),
+		IsSort:      true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]*models.EnvSnapshotConfigMap, 0)
+	visited := make(map[string]bool)
+	for _, res := range resources {
+		if visited[res.Name] {
+			continue
+		}
+		visited[res.Name] = true
+		if res.DeletedAt > 0 {
+			continue
+		}
+		ret = append(ret, &models.EnvSnapshotConfigMap{
+			Name:     res.Name,
+			YamlData: res.YamlData,
+		})
+	}
+	return ret, nil
+}
@@ -32,6 +32,13 @@ func (*Router) Inject(router *gin.RouterGroup) {
 		deliveryArtifact.POST("/:id/activities", CreateDeliveryActivities)
 	}
 
+	sbom := router.Group("sboms")
+	{
+		sbom.GET("/packages", SearchSBOMPackages)
+		sbom.GET("/:id", GetImageSBOM)
+		sbom.GET("/:id/file", DownloadImageSBOM)
+	}
+
 	deliveryRelease := router.Group("releases")
 	{
 		deliveryRelease.GET("/:id", GetDeliveryVersion)
@@ -400,7 +400,7 @@ func GetChartVersionFromRepo(c *gin.Context) {
 	chartName := c.Query("chartName")
 	chartRepoName := c.Query("chartRepoName")
 
-	ctx.Resp, ctx.RespErr = deliveryservice.GetChartVersion(chartName, chartRepoName)
+	ctx.Resp, ctx.RespErr = deliveryservice.GetChartVersion(chartName, chartRepoName, c.Query("projectName"))
 }
 
 func PreviewGetDeliveryChart(c *gin.Context) {
@@ -0,0 +1,154 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"io"
+	"path"
+
+	"go.uber.org/zap"
+
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/s3"
+	e "github.com/koderover/zadig/v2/pkg/tool/errors"
+	s3tool "github.com/koderover/zadig/v2/pkg/tool/s3"
+	"github.com/koderover/zadig/v2/pkg/tool/sbom"
+)
+
+type SBOMPackageSearchResult struct {
+	SBOMID        string        `json:"sbom_id"`
+	Image         string        `json:"image"`
+	ServiceName   string        `json:"service_name"`
+	ServiceModule string        `json:"service_module"`
+	ProjectName   string        `json:"project_name"`
+	WorkflowName  string        `json:"workflow_name"`
+	TaskID        int64         `json:"task_id"`
+	Format        string        `json:"format"`
+	Package       *sbom.Package `json:"package"`
+	CreatedTime   int64         `json:"created_time"`
+	// Environments are the environments currently running the image
+	Environments []*SBOMImageEnvironment `json:"environments"`
+}
+
+type SBOMImageEnvironment struct {
+	ProjectName   string `json:"project_name"`
+	EnvName       string `json:"env_name"`
+	Production    bool   `json:"production"`
+	ServiceName   string `json:"service_name"`
+	ContainerName string `json:"container_name"`
+}
+
+// SearchSBOMPackages finds the images whose sbom contains the package and the environments deploying them
+func SearchSBOMPackages(name, version string, log *zap.SugaredLogger) ([]*SBOMPackageSearchResult, error) {
+	sboms, err := commonrepo.NewImageSBOMColl().ListByPackage(name, version)
+	if err != nil {
+		log.Errorf("list sbom by package %s@%s error: %v", name, version, err)
+		return nil, e.ErrSearchSBOM.AddErr(err)
+	}
+
+	resp := make([]*SBOMPackageSearchResult, 0, len(sboms))
+	resultMap := make(map[string]*SBOMPackageSearchResult, len(sboms))
+	for _, imageSBOM := range sboms {
+		result := &SBOMPackageSearchResult{
+			SBOMID:        imageSBOM.ID.Hex(),
+			Image:         imageSBOM.Image,
+			ServiceName:   imageSBOM.ServiceName,
+			ServiceModule: imageSBOM.ServiceModule,
+			ProjectName:   imageSBOM.ProjectName,
+			WorkflowName:  imageSBOM.WorkflowName,
+			TaskID:        imageSBOM.TaskID,
+			Format:        imageSBOM.Format,
+			CreatedTime:   imageSBOM.CreatedTime,
+			Environments:  make([]*SBOMImageEnvironment, 0),
+		}
+		if len(imageSBOM.Packages) > 0 {
+			result.Package = imageSBOM.Packages[0]
+		}
+		resp = append(resp, result)
+		resultMap[imageSBOM.Image] = result
+	}
+	if len(resp) == 0 {
+		return resp, nil
+	}
+
+	products, err := commonrepo.NewProductColl().List(nil)
+	if err != nil {
+		log.Errorf("list environments error: %v", err)
+		return nil, e.ErrSearchSBOM.AddErr(err)
+	}
+	for _, product := range products {
+		for _, service := range product.GetServiceMap() {
+			for _, container := range service.Containers {
+				result, ok := resultMap[container.Image]
+				if !ok {
+					continue
+				}
+				result.Environments = append(result.Environments, &SBOMImageEnvironment{
+					ProjectName:   product.ProductName,
+					EnvName:       product.EnvName,
+					Production:    product.Production,
+					ServiceName:   service.ServiceName,
+					ContainerName: container.Name,
+				})
+			}
+		}
+	}
+	return resp, nil
+}
+
+func GetImageSBOM(id string, log *zap.SugaredLogger) (*commonmodels.ImageSBOM, error) {
+	resp, err := commonrepo.NewImageSBOMColl().GetByID(id)
+	if err != nil {
+		log.Errorf("get sbom %s error: %v", id, err)
+		return nil, e.ErrFindSBOM.AddErr(err)
+	}
+	return resp, nil
+}
+
+// DownloadImageSBOM returns the sbom file archived in the object storage
+func DownloadImageSBOM(id string, log *zap.SugaredLogger) ([]byte, string, error) {
+	imageSBOM, err := commonrepo.NewImageSBOMColl().GetByID(id)
+	if err != nil {
+		log.Errorf("get sbom %s error: %v", id, err)
+		return nil, "", e.ErrFindSBOM.AddErr(err)
+	}
+
+	storage, err := s3.FindS3ById(imageSBOM.ObjectStorageID)
+	if err != nil {
+		log.Errorf("find object storage %s error: %v", imageSBOM.ObjectStorageID, err)
+		return nil, "", e.ErrDownloadSBOM.AddErr(err)
+	}
+	client, err := s3tool.NewClient(storage.Endpoint, storage.Ak, storage.Sk, storage.Region, storage.Insecure, storage.Provider)
+	if err != nil {
+		log.Errorf("create s3 client error: %v", err)
+		return nil, "", e.ErrDownloadSBOM.AddErr(err)
+	}
+	object, err := client.GetFile(storage.Bucket, imageSBOM.ObjectKey, &s3tool.DownloadOption{RetryNum: 2})
+	if err != nil {
+		log.Errorf("get sbom file %s error: %v", imageSBOM.ObjectKey, err)
+		return nil, "", e.ErrDownloadSBOM.AddErr(err)
+	}
+	defer object.Body.Close()
+
+	fileBytes, err := io.ReadAll(object.Body)
+	if err != nil {
+		log.Errorf("read sbom file %s error: %v", imageSBOM.ObjectKey, err)
+		return nil, "", e.ErrDownloadSBOM.AddErr(err)
+	}
+	return fileBytes, path.Base(imageSBOM.ObjectKey), nil
+}
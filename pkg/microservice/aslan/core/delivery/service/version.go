@@ -41,6 +41,7 @@ import (
 	"go.uber.org/zap"
 	chartloader "helm.sh/helm/v3/pkg/chart/loader"
 	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/registry"
 	"helm.sh/helm/v3/pkg/repo"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/yaml"
@@ -685,8 +686,8 @@ func getProductEnvInfo(productName, envName string, production bool) (*commonmod
 	return productInfo, nil
 }
 
-func getChartRepoData(repoName string) (*commonmodels.HelmRepo, error) {
-	return commonrepo.NewHelmRepoColl().Find(&commonrepo.HelmRepoFindOption{RepoName: repoName})
+func getChartRepoData(repoName, projectName string) (*commonmodels.HelmRepo, error) {
+	return commonutil.FindChartRepo(repoName, projectName)
 }
 
 // ensure chart files exist
@@ -1244,7 +1245,7 @@ func buildDeliveryCharts(chartDataMap map[string]*DeliveryChartData, deliveryVer
 	if err != nil {
 		return err
 	}
-	repoInfo, err := getChartRepoData(args.ChartRepoName)
+	repoInfo, err := getChartRepoData(args.ChartRepoName, deliveryVersion.ProductName)
 	if err != nil {
 		log.Errorf("failed to query chart-repo info, productName: %s, err: %s", deliveryVersion.ProductName, err)
 		return fmt.Errorf("failed to query chart-repo info, productName: %s, repoName: %s", deliveryVersion.ProductName, args.ChartRepoName)
@@ -1361,7 +1362,7 @@ func sendVersionDeliveryHook(deliveryVersion *commonmodels.DeliveryVersion, host
 		})
 		chartRepoName = distribute.ChartRepoName
 	}
-	err = fillChartUrl(ret.Charts, chartRepoName)
+	err = fillChartUrl(ret.Charts, chartRepoName, deliveryVersion.ProductName)
 	if err != nil {
 		return err
 	}
@@ -1982,7 +1983,7 @@ func downloadChart(deliveryVersion *commonmodels.DeliveryVersion, chartInfo *com
 		return chartTGZFilePath, nil
 	}
 
-	chartRepo, err := getChartRepoData(chartInfo.ChartRepoName)
+	chartRepo, err := getChartRepoData(chartInfo.ChartRepoName, deliveryVersion.ProductName)
 	if err != nil {
 		return "", err
 	}
@@ -2047,8 +2048,10 @@ func preDownloadChart(projectName, versionName, chartName string, log *zap.Sugar
 	return filePath, err
 }
 
-func getIndexInfoFromChartRepo(chartRepoName string) (*repo.IndexFile, error) {
-	chartRepo, err := getChartRepoData(chartRepoName)
+// getIndexInfoFromChartRepo returns the index of the chart repo, OCI registries have no index.yaml
+// so the index is built from the tags of the given charts
+func getIndexInfoFromChartRepo(chartRepoName, projectName string, chartNames []string) (*repo.IndexFile, error) {
+	chartRepo, err := getChartRepoData(chartRepoName, projectName)
 	if err != nil {
 		return nil, err
 	}
@@ -2056,17 +2059,22 @@ func getIndexInfoFromChartRepo(chartRepoName string) (*repo.IndexFile, error) {
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to create chart repo client")
 	}
+	if registry.IsOCI(chartRepo.URL) {
+		return hClient.FetchOCIIndex(commonutil.GeneHelmRepo(chartRepo), chartNames)
+	}
 	return hClient.FetchIndexYaml(commonutil.GeneHelmRepo(chartRepo))
 }
 
-func fillChartUrl(charts []*DeliveryVersionPayloadChart, chartRepoName string) error {
-	index, err := getIndexInfoFromChartRepo(chartRepoName)
-	if err != nil {
-		return err
-	}
+func fillChartUrl(charts []*DeliveryVersionPayloadChart, chartRepoName, projectName string) error {
 	chartMap := make(map[string]*DeliveryVersionPayloadChart)
+	chartNames := make([]string, 0, len(charts))
 	for _, chart := range charts {
 		chartMap[chart.ChartName] = chart
+		chartNames = append(chartNames, chart.ChartName)
+	}
+	index, err := getIndexInfoFromChartRepo(chartRepoName, projectName, chartNames)
+	if err != nil {
+		return err
 	}
 
 	for name, entries := range index.Entries {
@@ -2090,14 +2098,13 @@ func fillChartUrl(charts []*DeliveryVersionPayloadChart, chartRepoName string) e
 	return nil
 }
 
-func GetChartVersion(chartName, chartRepoName string) ([]*ChartVersionResp, error) {
-
-	index, err := getIndexInfoFromChartRepo(chartRepoName)
+func GetChartVersion(chartName, chartRepoName, projectName string) ([]*ChartVersionResp, error) {
+	chartNameList := strings.Split(chartName, ",")
+	index, err := getIndexInfoFromChartRepo(chartRepoName, projectName, chartNameList)
 	if err != nil {
 		return nil, err
 	}
 
-	chartNameList := strings.Split(chartName, ",")
 	chartNameSet := sets.NewString(chartNameList...)
 	existedChartSet := sets.NewString()
 
@@ -0,0 +1,152 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handler
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/environment/service"
+	"github.com/koderover/zadig/v2/pkg/setting"
+	internalhandler "github.com/koderover/zadig/v2/pkg/shared/handler"
+	e "github.com/koderover/zadig/v2/pkg/tool/errors"
+)
+
+func CheckEnvTTLCronJob(c *gin.Context) {
+	ctx := internalhandler.NewContext(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	service.CheckEnvTTL(ctx.Logger)
+}
+
+// @Summary Get Environment TTL
+// @Description Get the ttl of the non-production environment
+// @Tags 	environment
+// @Accept 	json
+// @Produce json
+// @Param 	name			path		string							true	"env name"
+// @Param 	projectName		query		string							true	"project name"
+// @Success 200 			{object}  	service.EnvTTLResp
+// @Router /api/aslan/environment/environments/{name}/ttl [get]
+func GetEnvTTL(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	projectKey := c.Query("projectName")
+	if projectKey == "" {
+		ctx.RespErr = e.ErrInvalidParam.AddDesc("projectName can not be null!")
+		return
+	}
+	envName := c.Param("name")
+	if !checkEnvPermission(ctx, projectKey, envName, false, false) {
+		ctx.UnAuthorized = true
+		return
+	}
+
+	ctx.Resp, ctx.RespErr = service.GetEnvTTL(projectKey, envName)
+}
+
+// @Summary Update Environment TTL
+// @Description Reset the ttl of the non-production environment, the ttl is counted from now on and removed if both ttl and idle_timeout are 0
+// @Tags 	environment
+// @Accept 	json
+// @Produce json
+// @Param 	name			path		string							true	"env name"
+// @Param 	projectName		query		string							true	"project name"
+// @Param 	body 			body 		service.EnvTTLArgs 				true 	"body"
+// @Success 200
+// @Router /api/aslan/environment/environments/{name}/ttl [put]
+func UpdateEnvTTL(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	arg := new(service.EnvTTLArgs)
+	if err := c.ShouldBindJSON(arg); err != nil {
+		ctx.RespErr = e.ErrInvalidParam.AddErr(err)
+		return
+	}
+
+	projectKey := c.Query("projectName")
+	if projectKey == "" {
+		ctx.RespErr = e.ErrInvalidParam.AddDesc("projectName can not be null!")
+		return
+	}
+	envName := c.Param("name")
+	if !checkEnvPermission(ctx, projectKey, envName, false, true) {
+		ctx.UnAuthorized = true
+		return
+	}
+
+	internalhandler.InsertDetailedOperationLog(c, ctx.UserName, projectKey, setting.OperationSceneEnv, "更新", "环境-有效期", envName, fmt.Sprintf("ttl: %d, idle_timeout: %d", arg.TTL, arg.IdleTimeout), ctx.Logger, envName)
+
+	ctx.RespErr = service.UpdateEnvTTL(projectKey, envName, ctx.UserName, arg)
+}
+
+// @Summary Extend Environment TTL
+// @Description Put off the expiry of the non-production environment
+// @Tags 	environment
+// @Accept 	json
+// @Produce json
+// @Param 	name			path		string							true	"env name"
+// @Param 	projectName		query		string							true	"project name"
+// @Param 	hours			query		int								true	"hours to extend"
+// @Success 200 			{object}  	service.EnvTTLResp
+// @Router /api/aslan/environment/environments/{name}/ttl/extend [post]
+func ExtendEnvTTL(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	projectKey := c.Query("projectName")
+	if projectKey == "" {
+		ctx.RespErr = e.ErrInvalidParam.AddDesc("projectName can not be null!")
+		return
+	}
+	hours, err := strconv.ParseInt(c.Query("hours"), 10, 64)
+	if err != nil {
+		ctx.RespErr = e.ErrInvalidParam.AddErr(fmt.Errorf("invalid hours: %s", err))
+		return
+	}
+	envName := c.Param("name")
+	if !checkEnvPermission(ctx, projectKey, envName, false, true) {
+		ctx.UnAuthorized = true
+		return
+	}
+
+	internalhandler.InsertDetailedOperationLog(c, ctx.UserName, projectKey, setting.OperationSceneEnv, "延长", "环境-有效期", fmt.Sprintf("环境: %s, 延长: %d 小时", envName, hours), "", ctx.Logger, envName)
+
+	ctx.Resp, ctx.RespErr = service.ExtendEnvTTL(projectKey, envName, hours)
+}
@@ -60,6 +60,8 @@ func (*Router) Inject(router *gin.RouterGroup) {
 	cron := router.Group("cron")
 	{
 		cron.GET("/cleanproduct", CleanProductCronJob)
+		cron.GET("/drift", ScanEnvDriftCronJob)
+		cron.GET("/ttl", CheckEnvTTLCronJob)
 	}
 
 	// ---------------------------------------------------------------------------------------
@@ -146,6 +148,8 @@ func (*Router) Inject(router *gin.RouterGroup) {
 		environments.GET("/:name", GetEnvironment)
 		environments.PUT("/:name/envRecycle", UpdateProductRecycleDay)
 		environments.PUT("/:name/alias", UpdateProductAlias)
+		environments.GET("/:name/signaturePolicy", GetEnvSignaturePolicy)
+		environments.PUT("/:name/signaturePolicy", UpdateEnvSignaturePolicy)
 		environments.POST("/:name/affectedservices", AffectedServices)
 		environments.POST("/:name/estimated-values", EstimatedValues)
 
@@ -218,6 +222,22 @@ func (*Router) Inject(router *gin.RouterGroup) {
 		environments.GET("/:name/version/:serviceName/diff", DiffEnvServiceVersions)
 		environments.POST("/:name/version/:serviceName/rollback", RollbackEnvServiceVersion)
 
+		environments.GET("/:name/snapshots", ListEnvSnapshots)
+		environments.POST("/:name/snapshots", CreateEnvSnapshot)
+		environments.DELETE("/:name/snapshots/:id", DeleteEnvSnapshot)
+		environments.GET("/:name/snapshots/:id/diff", DiffEnvSnapshots)
+		environments.GET("/:name/snapshots/:id/restore/preview", PreviewRestoreEnvSnapshot)
+		environments.POST("/:name/snapshots/:id/restore", RestoreEnvSnapshot)
+
+		environments.GET("/:name/drifts", ListEnvDrifts)
+		environments.POST("/:name/drifts/:id/reconcile", ReconcileEnvDrift)
+		environments.POST("/:name/drifts/:id/adopt", AdoptEnvDrift)
+		environments.GET("/:name/driftDetection", GetEnvDriftDetection)
+		environments.PUT("/:name/driftDetection", UpdateEnvDriftDetection)
+		environments.GET("/:name/ttl", GetEnvTTL)
+		environments.PUT("/:name/ttl", UpdateEnvTTL)
+		environments.POST("/:name/ttl/extend", ExtendEnvTTL)
+
 		environments.GET("sae", ListSAEEnvs)
 		environments.POST("sae", CreateSAEEnv)
 		environments.GET("sae/:name", GetSAEEnv)
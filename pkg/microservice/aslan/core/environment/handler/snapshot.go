@@ -0,0 +1,279 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handler
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	commonutil "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/util"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/environment/service"
+	"github.com/koderover/zadig/v2/pkg/setting"
+	internalhandler "github.com/koderover/zadig/v2/pkg/shared/handler"
+	e "github.com/koderover/zadig/v2/pkg/tool/errors"
+	"github.com/koderover/zadig/v2/pkg/types"
+)
+
+// checkEnvPermission checks the view permission of the env, or the edit config permission if edit is true
+func checkEnvPermission(ctx *internalhandler.Context, projectKey, envName string, production, edit bool) bool {
+	if ctx.Resources.IsSystemAdmin {
+		return true
+	}
+	authInfo, ok := ctx.Resources.ProjectAuthInfo[projectKey]
+	if !ok {
+		return false
+	}
+	if authInfo.IsProjectAdmin {
+		return true
+	}
+
+	var (
+		permitted bool
+		action    string
+	)
+	switch {
+	case production && edit:
+		permitted, action = authInfo.ProductionEnv.EditConfig, types.ProductionEnvActionEditConfig
+	case production:
+		permitted, action = authInfo.ProductionEnv.View, types.ProductionEnvActionView
+	case edit:
+		permitted, action = authInfo.Env.EditConfig, types.EnvActionEditConfig
+	default:
+		permitted, action = authInfo.Env.View, types.EnvActionView
+	}
+	if permitted {
+		return true
+	}
+	permitted, err := internalhandler.GetCollaborationModePermission(ctx.UserID, projectKey, types.ResourceTypeEnvironment, envName, action)
+	return err == nil && permitted
+}
+
+// @Summary List Environment Snapshots
+// @Description List Environment Snapshots, services and configmaps are not returned
+// @Tags 	environment
+// @Accept 	json
+// @Produce json
+// @Param 	name			path		string							true	"env name"
+// @Param 	projectName		query		string							true	"project name"
+// @Param 	production		query		bool							false	"is production env"
+// @Success 200 			{array}  	commonmodels.EnvSnapshot
+// @Router /api/aslan/environment/environments/{name}/snapshots [get]
+func ListEnvSnapshots(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	projectKey := c.Query("projectName")
+	envName := c.Param("name")
+	production := c.Query("production") == "true"
+	if !checkEnvPermission(ctx, projectKey, envName, production, false) {
+		ctx.UnAuthorized = true
+		return
+	}
+
+	ctx.Resp, ctx.RespErr = service.ListEnvSnapshots(projectKey, envName, production, ctx.Logger)
+}
+
+// @Summary Create Environment Snapshot
+// @Description Create Environment Snapshot
+// @Tags 	environment
+// @Accept 	json
+// @Produce json
+// @Param 	name			path		string							true	"env name"
+// @Param 	projectName		query		string							true	"project name"
+// @Param 	production		query		bool							false	"is production env"
+// @Param 	body 			body 		service.CreateEnvSnapshotArgs 	true 	"body"
+// @Success 200 			{object}  	commonmodels.EnvSnapshot
+// @Router /api/aslan/environment/environments/{name}/snapshots [post]
+func CreateEnvSnapshot(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	projectKey := c.Query("projectName")
+	envName := c.Param("name")
+	production := c.Query("production") == "true"
+	if !checkEnvPermission(ctx, projectKey, envName, production, true) {
+		ctx.UnAuthorized = true
+		return
+	}
+
+	args := new(service.CreateEnvSnapshotArgs)
+	if err := c.ShouldBindJSON(args); err != nil {
+		ctx.RespErr = e.ErrInvalidParam.AddErr(err)
+		return
+	}
+
+	internalhandler.InsertDetailedOperationLog(c, ctx.UserName, projectKey, setting.OperationSceneEnv, "新增", "环境-快照", fmt.Sprintf("环境: %s, 快照: %s", envName, args.Name), "", ctx.Logger, envName)
+
+	ctx.Resp, ctx.RespErr = service.CreateEnvSnapshot(projectKey, envName, args.Name, ctx.UserName, production, ctx.Logger)
+}
+
+// @Summary Delete Environment Snapshot
+// @Description Delete Environment Snapshot
+// @Tags 	environment
+// @Accept 	json
+// @Produce json
+// @Param 	name			path		string							true	"env name"
+// @Param 	id				path		string							true	"snapshot id"
+// @Param 	projectName		query		string							true	"project name"
+// @Param 	production		query		bool							false	"is production env"
+// @Success 200
+// @Router /api/aslan/environment/environments/{name}/snapshots/{id} [delete]
+func DeleteEnvSnapshot(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	projectKey := c.Query("projectName")
+	envName := c.Param("name")
+	production := c.Query("production") == "true"
+	if !checkEnvPermission(ctx, projectKey, envName, production, true) {
+		ctx.UnAuthorized = true
+		return
+	}
+
+	internalhandler.InsertDetailedOperationLog(c, ctx.UserName, projectKey, setting.OperationSceneEnv, "删除", "环境-快照", fmt.Sprintf("环境: %s, 快照: %s", envName, c.Param("id")), "", ctx.Logger, envName)
+
+	ctx.RespErr = service.DeleteEnvSnapshot(projectKey, envName, c.Param("id"), production, ctx.Logger)
+}
+
+// @Summary Diff Environment Snapshots
+// @Description Diff the snapshot with the target snapshot
+// @Tags 	environment
+// @Accept 	json
+// @Produce json
+// @Param 	name			path		string							true	"env name"
+// @Param 	id				path		string							true	"snapshot id"
+// @Param 	projectName		query		string							true	"project name"
+// @Param 	target			query		string							true	"target snapshot id"
+// @Param 	production		query		bool							false	"is production env"
+// @Success 200 			{object}  	service.EnvSnapshotDiff
+// @Router /api/aslan/environment/environments/{name}/snapshots/{id}/diff [get]
+func DiffEnvSnapshots(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	projectKey := c.Query("projectName")
+	envName := c.Param("name")
+	production := c.Query("production") == "true"
+	if !checkEnvPermission(ctx, projectKey, envName, production, false) {
+		ctx.UnAuthorized = true
+		return
+	}
+
+	target := c.Query("target")
+	if target == "" {
+		ctx.RespErr = e.ErrInvalidParam.AddDesc("empty target")
+		return
+	}
+
+	ctx.Resp, ctx.RespErr = service.DiffEnvSnapshots(projectKey, envName, c.Param("id"), target, production)
+}
+
+// @Summary Preview Restoring Environment Snapshot
+// @Description Preview the changes from the current environment to the snapshot
+// @Tags 	environment
+// @Accept 	json
+// @Produce json
+// @Param 	name			path		string							true	"env name"
+// @Param 	id				path		string							true	"snapshot id"
+// @Param 	projectName		query		string							true	"project name"
+// @Param 	production		query		bool							false	"is production env"
+// @Success 200 			{object}  	service.EnvSnapshotDiff
+// @Router /api/aslan/environment/environments/{name}/snapshots/{id}/restore/preview [get]
+func PreviewRestoreEnvSnapshot(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	projectKey := c.Query("projectName")
+	envName := c.Param("name")
+	production := c.Query("production") == "true"
+	if !checkEnvPermission(ctx, projectKey, envName, production, false) {
+		ctx.UnAuthorized = true
+		return
+	}
+
+	ctx.Resp, ctx.RespErr = service.PreviewRestoreEnvSnapshot(projectKey, envName, c.Param("id"), production)
+}
+
+// @Summary Restore Environment Snapshot
+// @Description Restore the environment to the snapshot
+// @Tags 	environment
+// @Accept 	json
+// @Produce json
+// @Param 	name			path		string							true	"env name"
+// @Param 	id				path		string							true	"snapshot id"
+// @Param 	projectName		query		string							true	"project name"
+// @Param 	production		query		bool							false	"is production env"
+// @Success 200 			{object}  	service.RestoreEnvSnapshotResult
+// @Router /api/aslan/environment/environments/{name}/snapshots/{id}/restore [post]
+func RestoreEnvSnapshot(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	projectKey := c.Query("projectName")
+	envName := c.Param("name")
+	production := c.Query("production") == "true"
+	if !checkEnvPermission(ctx, projectKey, envName, production, true) {
+		ctx.UnAuthorized = true
+		return
+	}
+
+	if err := commonutil.CheckZadigProfessionalLicense(); err != nil {
+		ctx.RespErr = err
+		return
+	}
+
+	internalhandler.InsertDetailedOperationLog(c, ctx.UserName, projectKey, setting.OperationSceneEnv, "恢复", "环境-快照", fmt.Sprintf("环境: %s, 快照: %s", envName, c.Param("id")), "", ctx.Logger, envName)
+
+	ctx.Resp, ctx.RespErr = service.RestoreEnvSnapshot(ctx, projectKey, envName, c.Param("id"), production, ctx.Logger)
+}
@@ -0,0 +1,454 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	templatemodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models/template"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	helmservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/helm"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/kube"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/notify"
+	commonutil "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/util"
+	"github.com/koderover/zadig/v2/pkg/setting"
+	internalhandler "github.com/koderover/zadig/v2/pkg/shared/handler"
+	"github.com/koderover/zadig/v2/pkg/tool/cache"
+	"github.com/koderover/zadig/v2/pkg/tool/clientmanager"
+	e "github.com/koderover/zadig/v2/pkg/tool/errors"
+	"github.com/koderover/zadig/v2/pkg/tool/kube/getter"
+)
+
+const envDriftScanLockKey = "env_drift_scan"
+
+// ScanEnvDrifts is triggered by the cron service periodically, it compares the resources of the k8s yaml envs
+// with the manifests rendered by Zadig and records the resources modified outside Zadig
+func ScanEnvDrifts(log *zap.SugaredLogger) {
+	// the scan may take a while, only one aslan instance does it at a time
+	mutex := cache.NewRedisLockWithExpiry(envDriftScanLockKey, 10*time.Minute)
+	if err := mutex.TryLock(); err != nil {
+		log.Infof("env drift scan is in progress, skip")
+		return
+	}
+	defer mutex.Unlock()
+
+	log.Info("[ScanEnvDrifts] started ...")
+	defer log.Info("[ScanEnvDrifts] end")
+
+	envs, err := commonrepo.NewProductColl().List(&commonrepo.ProductListOptions{
+		Source:        setting.SourceFromZadig,
+		ExcludeStatus: []string{setting.ProductStatusCreating, setting.ProductStatusDeleting, setting.ProductStatusUnknown},
+	})
+	if err != nil {
+		log.Errorf("failed to list envs to scan drifts, error: %v", err)
+		return
+	}
+
+	for _, env := range envs {
+		if env.IsSleeping() || (env.DriftDetection != nil && env.DriftDetection.Disabled) {
+			continue
+		}
+		if err := scanEnvDrift(env, log); err != nil {
+			log.Errorf("failed to scan drifts of env %s/%s, isProduction %v, error: %v", env.ProductName, env.EnvName, env.Production, err)
+		}
+	}
+}
+
+func envDriftKey(serviceName, kind, name string) string {
+	return fmt.Sprintf("%s/%s/%s", serviceName, kind, name)
+}
+
+func scanEnvDrift(env *commonmodels.Product, log *zap.SugaredLogger) error {
+	kubeClient, err := clientmanager.NewKubeClientManager().GetControllerRuntimeClient(env.ClusterID)
+	if err != nil {
+		return err
+	}
+
+	existing, err := commonrepo.NewEnvDriftColl().List(&commonrepo.EnvDriftListOption{
+		ProductName: env.ProductName,
+		EnvName:     env.EnvName,
+		Production:  env.Production,
+		Status:      []config.EnvDriftStatus{config.EnvDriftStatusOpen},
+	})
+	if err != nil {
+		return err
+	}
+	openDrifts := make(map[string]*commonmodels.EnvDrift)
+	for _, drift := range existing {
+		openDrifts[envDriftKey(drift.ServiceName, drift.Kind, drift.Name)] = drift
+	}
+
+	newDrifts := make([]*commonmodels.EnvDrift, 0)
+	detected := make(map[string]bool)
+	for _, svc := range env.GetSvcList() {
+		if svc.Type != setting.K8SDeployType || !commonutil.ServiceDeployed(svc.ServiceName, env.ServiceDeployStrategy) {
+			continue
+		}
+		drifts, err := detectServiceDrifts(env, svc, kubeClient)
+		if err != nil {
+			log.Errorf("failed to detect drifts of service %s in env %s/%s, error: %v", svc.ServiceName, env.ProductName, env.EnvName, err)
+			continue
+		}
+
+		for _, drift := range drifts {
+			key := envDriftKey(drift.ServiceName, drift.Kind, drift.Name)
+			detected[key] = true
+
+			if openDrift, ok := openDrifts[key]; ok {
+				if err := commonrepo.NewEnvDriftColl().UpdateDiffs(openDrift.ID, drift.Diffs); err != nil {
+					log.Errorf("failed to update drift %s, error: %v", key, err)
+				}
+				continue
+			}
+			if err := commonrepo.NewEnvDriftColl().Create(drift); err != nil {
+				log.Errorf("failed to create drift %s, error: %v", key, err)
+				continue
+			}
+			newDrifts = append(newDrifts, drift)
+		}
+	}
+
+	for key, drift := range openDrifts {
+		if detected[key] {
+			continue
+		}
+		if err := commonrepo.NewEnvDriftColl().UpdateStatus(drift.ID, config.EnvDriftStatusResolved, ""); err != nil {
+			log.Errorf("failed to resolve drift %s, error: %v", key, err)
+		}
+	}
+
+	if len(newDrifts) > 0 && env.DriftDetection != nil && env.DriftDetection.Notify {
+		notifyEnvDrifts(env, newDrifts, log)
+	}
+	return nil
+}
+
+// detectServiceDrifts compares the resources of the service in the cluster with the manifest rendered from the service in the env
+func detectServiceDrifts(env *commonmodels.Product, svc *commonmodels.ProductService, kubeClient client.Client) ([]*commonmodels.EnvDrift, error) {
+	parsedYaml, err := kube.RenderEnvService(env, svc.GetServiceRender(), svc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render service yaml, error: %v", err)
+	}
+	resources, _, err := kube.ManifestToUnstructured(parsedYaml)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert service yaml to unstructured, error: %v", err)
+	}
+
+	ret := make([]*commonmodels.EnvDrift, 0)
+	for _, expected := range resources {
+		// live is nil if the resource is deleted
+		live, _, err := getter.GetUnstructuredResourceInCache(env.Namespace, expected.GetName(), expected.GroupVersionKind(), kubeClient)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s/%s, error: %v", expected.GetKind(), expected.GetName(), err)
+		}
+		diffs := kube.DiffLiveResource(expected, live)
+		if len(diffs) == 0 {
+			continue
+		}
+		ret = append(ret, &commonmodels.EnvDrift{
+			ProductName: env.ProductName,
+			EnvName:     env.EnvName,
+			Production:  env.Production,
+			Namespace:   env.Namespace,
+			ServiceName: svc.ServiceName,
+			Kind:        expected.GetKind(),
+			Name:        expected.GetName(),
+			Diffs:       diffs,
+			Status:      config.EnvDriftStatusOpen,
+		})
+	}
+	return ret, nil
+}
+
+func notifyEnvDrifts(env *commonmodels.Product, drifts []*commonmodels.EnvDrift, log *zap.SugaredLogger) {
+	receivers := env.DriftDetection.Receivers
+	if len(receivers) == 0 {
+		receivers = []string{env.UpdateBy}
+	}
+
+	resources := make([]string, 0, len(drifts))
+	for _, drift := range drifts {
+		resources = append(resources, fmt.Sprintf("%s/%s", drift.Kind, drift.Name))
+	}
+	title := "环境配置漂移"
+	content := fmt.Sprintf("项目：%s, 环境：%s, 以下资源在 Zadig 之外被修改：%s", env.ProductName, env.EnvName, strings.Join(resources, ", "))
+	for _, receiver := range receivers {
+		notify.SendMessage(receiver, title, content, "", log)
+	}
+}
+
+func ListEnvDrifts(projectName, envName string, production bool, status config.EnvDriftStatus, log *zap.SugaredLogger) ([]*commonmodels.EnvDrift, error) {
+	if status == "" {
+		status = config.EnvDriftStatusOpen
+	}
+	drifts, err := commonrepo.NewEnvDriftColl().List(&commonrepo.EnvDriftListOption{
+		ProductName: projectName,
+		EnvName:     envName,
+		Production:  production,
+		Status:      []config.EnvDriftStatus{status},
+	})
+	if err != nil {
+		log.Errorf("failed to list drifts of env %s/%s, error: %v", projectName, envName, err)
+		return nil, e.ErrListEnvDrifts.AddErr(err)
+	}
+	return drifts, nil
+}
+
+func getOpenEnvDrift(projectName, envName, id string, production bool) (*commonmodels.EnvDrift, error) {
+	drift, err := commonrepo.NewEnvDriftColl().GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find drift %s, error: %v", id, err)
+	}
+	if drift.ProductName != projectName || drift.EnvName != envName || drift.Production != production {
+		return nil, fmt.Errorf("drift %s is not found in env %s/%s", id, projectName, envName)
+	}
+	if drift.Status != config.EnvDriftStatusOpen {
+		return nil, fmt.Errorf("drift %s is already %s", id, drift.Status)
+	}
+	return drift, nil
+}
+
+// ReconcileEnvDrift reapplies the service of the drifted resource with the manifest rendered by Zadig,
+// the other drifts of the service are reconciled as well
+func ReconcileEnvDrift(ctx *internalhandler.Context, projectName, envName, id string, production bool, log *zap.SugaredLogger) error {
+	drift, err := getOpenEnvDrift(projectName, envName, id, production)
+	if err != nil {
+		return e.ErrReconcileEnvDrift.AddErr(err)
+	}
+	env, err := commonrepo.NewProductColl().Find(&commonrepo.ProductFindOptions{
+		Name:       projectName,
+		EnvName:    envName,
+		Production: &production,
+	})
+	if err != nil {
+		return e.ErrReconcileEnvDrift.AddErr(fmt.Errorf("failed to find %s/%s env, isProduction %v, error: %v", projectName, envName, production, err))
+	}
+	if env.IsSleeping() {
+		return e.ErrReconcileEnvDrift.AddDesc("environment is sleeping")
+	}
+	svc := env.GetServiceMap()[drift.ServiceName]
+	if svc == nil {
+		return e.ErrReconcileEnvDrift.AddDesc(fmt.Sprintf("service %s is not found in env", drift.ServiceName))
+	}
+
+	kubeClient, err := clientmanager.NewKubeClientManager().GetControllerRuntimeClient(env.ClusterID)
+	if err != nil {
+		return e.ErrReconcileEnvDrift.AddErr(err)
+	}
+	istioClient, err := clientmanager.NewKubeClientManager().GetIstioClientSet(env.ClusterID)
+	if err != nil {
+		return e.ErrReconcileEnvDrift.AddErr(err)
+	}
+	informer, err := clientmanager.NewKubeClientManager().GetInformer(env.ClusterID, env.Namespace)
+	if err != nil {
+		return e.ErrReconcileEnvDrift.AddErr(err)
+	}
+
+	parsedYaml, err := kube.RenderEnvService(env, svc.GetServiceRender(), svc)
+	if err != nil {
+		return e.ErrReconcileEnvDrift.AddErr(fmt.Errorf("failed to render service %s, error: %v", svc.ServiceName, err))
+	}
+	// CurrentResourceYaml is left empty so all the resources are applied even though the manifest is unchanged
+	_, err = kube.CreateOrPatchResource(&kube.ResourceApplyParam{
+		ProductInfo:        env,
+		ServiceName:        svc.ServiceName,
+		UpdateResourceYaml: parsedYaml,
+		Informer:           informer,
+		KubeClient:         kubeClient,
+		IstioClient:        istioClient,
+		InjectSecrets:      true,
+		AddZadigLabel:      !production,
+		SharedEnvHandler:   EnsureUpdateZadigService,
+	}, log)
+	if err != nil {
+		return e.ErrReconcileEnvDrift.AddErr(fmt.Errorf("failed to apply service %s, error: %v", svc.ServiceName, err))
+	}
+
+	svcDrifts, err := commonrepo.NewEnvDriftColl().List(&commonrepo.EnvDriftListOption{
+		ProductName: projectName,
+		EnvName:     envName,
+		Production:  production,
+		ServiceName: drift.ServiceName,
+		Status:      []config.EnvDriftStatus{config.EnvDriftStatusOpen},
+	})
+	if err != nil {
+		return e.ErrReconcileEnvDrift.AddErr(err)
+	}
+	for _, svcDrift := range svcDrifts {
+		if err := commonrepo.NewEnvDriftColl().UpdateStatus(svcDrift.ID, config.EnvDriftStatusReconciled, ctx.UserName); err != nil {
+			return e.ErrReconcileEnvDrift.AddErr(err)
+		}
+	}
+	return nil
+}
+
+// AdoptEnvDrift accepts the drifted resource as it is running now, the live values of the drifted fields are saved into
+// the service of the env so the later deployments keep them. Changed container images are saved as the images of the
+// service, the other fields are saved as the adopted fields overriding the rendered manifests of the service.
+func AdoptEnvDrift(ctx *internalhandler.Context, projectName, envName, id string, production bool, log *zap.SugaredLogger) error {
+	drift, err := getOpenEnvDrift(projectName, envName, id, production)
+	if err != nil {
+		return e.ErrAdoptEnvDrift.AddErr(err)
+	}
+	env, err := commonrepo.NewProductColl().Find(&commonrepo.ProductFindOptions{
+		Name:       projectName,
+		EnvName:    envName,
+		Production: &production,
+	})
+	if err != nil {
+		return e.ErrAdoptEnvDrift.AddErr(fmt.Errorf("failed to find %s/%s env, isProduction %v, error: %v", projectName, envName, production, err))
+	}
+	svc := env.GetServiceMap()[drift.ServiceName]
+	if svc == nil {
+		return e.ErrAdoptEnvDrift.AddDesc(fmt.Sprintf("service %s is not found in env", drift.ServiceName))
+	}
+
+	parsedYaml, err := kube.RenderEnvService(env, svc.GetServiceRender(), svc)
+	if err != nil {
+		return e.ErrAdoptEnvDrift.AddErr(fmt.Errorf("failed to render service %s, error: %v", svc.ServiceName, err))
+	}
+	resources, _, err := kube.ManifestToUnstructured(parsedYaml)
+	if err != nil {
+		return e.ErrAdoptEnvDrift.AddErr(fmt.Errorf("failed to convert service yaml to unstructured, error: %v", err))
+	}
+	var expected *unstructured.Unstructured
+	for _, resource := range resources {
+		if resource.GetKind() == drift.Kind && resource.GetName() == drift.Name {
+			expected = resource
+			break
+		}
+	}
+	if expected == nil {
+		return e.ErrAdoptEnvDrift.AddDesc(fmt.Sprintf("%s/%s is not found in service %s any more", drift.Kind, drift.Name, drift.ServiceName))
+	}
+
+	kubeClient, err := clientmanager.NewKubeClientManager().GetControllerRuntimeClient(env.ClusterID)
+	if err != nil {
+		return e.ErrAdoptEnvDrift.AddErr(err)
+	}
+	live, _, err := getter.GetUnstructuredResourceInCache(env.Namespace, expected.GetName(), expected.GroupVersionKind(), kubeClient)
+	if err != nil {
+		return e.ErrAdoptEnvDrift.AddErr(fmt.Errorf("failed to get %s/%s, error: %v", expected.GetKind(), expected.GetName(), err))
+	}
+	if live == nil {
+		return e.ErrAdoptEnvDrift.AddDesc(fmt.Sprintf("%s/%s is deleted, it can only be reconciled", drift.Kind, drift.Name))
+	}
+
+	svcRender := svc.GetServiceRender()
+	for _, diff := range drift.Diffs {
+		path, ok := kube.ResolveDriftPath(expected.Object, diff.Path)
+		if !ok {
+			return e.ErrAdoptEnvDrift.AddDesc(fmt.Sprintf("field %s is not found in %s/%s", diff.Path, drift.Kind, drift.Name))
+		}
+		liveValue, found := kube.GetDriftField(live.Object, path)
+		if adoptContainerImage(svc, diff, liveValue) {
+			continue
+		}
+
+		field := &templatemodels.AdoptedField{
+			Kind: drift.Kind,
+			Name: drift.Name,
+			Path: path,
+		}
+		if found {
+			value, err := json.Marshal(liveValue)
+			if err != nil {
+				return e.ErrAdoptEnvDrift.AddErr(fmt.Errorf("failed to marshal field %s, error: %v", diff.Path, err))
+			}
+			field.Value = string(value)
+		}
+		svcRender.AdoptedFields = setAdoptedField(svcRender.AdoptedFields, field)
+	}
+
+	if err := helmservice.UpdateServiceInEnv(env, svc, ctx.UserName); err != nil {
+		return e.ErrAdoptEnvDrift.AddErr(fmt.Errorf("failed to update service %s in env, error: %v", svc.ServiceName, err))
+	}
+	log.Infof("adopt drift %s of service %s into env %s/%s", id, svc.ServiceName, projectName, envName)
+
+	if err := commonrepo.NewEnvDriftColl().UpdateStatus(drift.ID, config.EnvDriftStatusAdopted, ctx.UserName); err != nil {
+		return e.ErrAdoptEnvDrift.AddErr(err)
+	}
+	return nil
+}
+
+// adoptContainerImage saves the live image into the container of the service, so the image is still replaced by the
+// later deployments of the service
+func adoptContainerImage(svc *commonmodels.ProductService, diff *commonmodels.EnvDriftFieldDiff, liveValue interface{}) bool {
+	image, ok := liveValue.(string)
+	if !strings.HasSuffix(diff.Path, ".image") || !ok || image == "" {
+		return false
+	}
+	for _, container := range svc.Containers {
+		if container.Image == diff.Expected {
+			container.Image = image
+			return true
+		}
+	}
+	return false
+}
+
+// setAdoptedField adds the field to the adopted fields, or replaces the adopted one with the same path
+func setAdoptedField(fields []*templatemodels.AdoptedField, field *templatemodels.AdoptedField) []*templatemodels.AdoptedField {
+	for i, adopted := range fields {
+		if adopted.Kind == field.Kind && adopted.Name == field.Name && slices.Equal(adopted.Path, field.Path) {
+			fields[i] = field
+			return fields
+		}
+	}
+	return append(fields, field)
+}
+
+func GetEnvDriftDetection(productName, envName string, production bool) (*commonmodels.EnvDriftDetection, error) {
+	productInfo, err := commonrepo.NewProductColl().Find(&commonrepo.ProductFindOptions{
+		Name:       productName,
+		EnvName:    envName,
+		Production: &production,
+	})
+	if err != nil {
+		return nil, e.ErrGetEnv.AddErr(fmt.Errorf("failed to query product info, name %s", envName))
+	}
+	if productInfo.DriftDetection == nil {
+		return &commonmodels.EnvDriftDetection{Receivers: []string{}}, nil
+	}
+	return productInfo.DriftDetection, nil
+}
+
+func UpdateEnvDriftDetection(productName, envName string, production bool, detection *commonmodels.EnvDriftDetection) error {
+	_, err := commonrepo.NewProductColl().Find(&commonrepo.ProductFindOptions{
+		Name:       productName,
+		EnvName:    envName,
+		Production: &production,
+	})
+	if err != nil {
+		return e.ErrUpdateEnv.AddErr(fmt.Errorf("failed to query product info, name %s", envName))
+	}
+
+	if err := commonrepo.NewProductColl().UpdateDriftDetection(envName, productName, detection); err != nil {
+		return e.ErrUpdateEnv.AddErr(err)
+	}
+	return nil
+}
@@ -0,0 +1,269 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/notify"
+	systemmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/system/repository/models"
+	systemmongodb "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/system/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/setting"
+	"github.com/koderover/zadig/v2/pkg/tool/cache"
+	e "github.com/koderover/zadig/v2/pkg/tool/errors"
+	"github.com/koderover/zadig/v2/pkg/util"
+)
+
+const (
+	envTTLCheckLockKey = "env_ttl_check"
+	// envTTLWarnBefore is how long before the env expires its creator is warned
+	envTTLWarnBefore = 2 * time.Hour
+	// envTTLRetryInterval is how long the deletion of an expired env is retried after it fails
+	envTTLRetryInterval = time.Hour
+)
+
+type EnvTTLResp struct {
+	*commonmodels.EnvTTL
+	// Deadline is the unix time the env is deleted at, 0 means the env never expires
+	Deadline int64 `json:"deadline"`
+}
+
+// newEnvTTL returns the ttl of the env created by the user, nil if the env never expires
+func newEnvTTL(args *EnvTTLArgs, production bool, creator string) *commonmodels.EnvTTL {
+	if args == nil || production || (args.TTL <= 0 && args.IdleTimeout <= 0) {
+		return nil
+	}
+
+	now := time.Now().Unix()
+	ttl := &commonmodels.EnvTTL{
+		ActiveTime: now,
+		CreateBy:   creator,
+	}
+	if args.TTL > 0 {
+		ttl.ExpireTime = now + args.TTL*int64(time.Hour/time.Second)
+	}
+	if args.IdleTimeout > 0 {
+		ttl.IdleTimeout = args.IdleTimeout
+	}
+	return ttl
+}
+
+// envTTLDeadline returns the unix time the env is deleted at and whether it's deleted for idleness,
+// the deadline is 0 if the env never expires
+func envTTLDeadline(env *commonmodels.Product) (int64, bool) {
+	if env.TTL == nil {
+		return 0, false
+	}
+
+	deadline, idle := env.TTL.ExpireTime, false
+	if env.TTL.IdleTimeout > 0 {
+		lastActive := env.UpdateTime
+		if env.TTL.ActiveTime > lastActive {
+			lastActive = env.TTL.ActiveTime
+		}
+		idleDeadline := lastActive + env.TTL.IdleTimeout*int64(time.Hour/time.Second)
+		if deadline == 0 || idleDeadline < deadline {
+			deadline, idle = idleDeadline, true
+		}
+	}
+	return deadline, idle
+}
+
+func envTTLOwner(env *commonmodels.Product) string {
+	if env.TTL != nil && env.TTL.CreateBy != "" {
+		return env.TTL.CreateBy
+	}
+	return env.UpdateBy
+}
+
+// CheckEnvTTL is triggered by the cron service periodically, it warns the creators of the envs about to expire
+// and deletes the expired envs with their namespaces, helm releases and share env configs
+func CheckEnvTTL(log *zap.SugaredLogger) {
+	mutex := cache.NewRedisLockWithExpiry(envTTLCheckLockKey, 5*time.Minute)
+	if err := mutex.TryLock(); err != nil {
+		log.Infof("env ttl check is in progress, skip")
+		return
+	}
+	defer mutex.Unlock()
+
+	log.Info("[CheckEnvTTL] started ...")
+	defer log.Info("[CheckEnvTTL] end")
+
+	envs, err := commonrepo.NewProductColl().List(&commonrepo.ProductListOptions{
+		WithTTL:       true,
+		Production:    util.GetBoolPointer(false),
+		ExcludeStatus: []string{setting.ProductStatusCreating, setting.ProductStatusDeleting},
+	})
+	if err != nil {
+		log.Errorf("failed to list envs to check ttl, error: %v", err)
+		return
+	}
+
+	now := time.Now().Unix()
+	for _, env := range envs {
+		deadline, idle := envTTLDeadline(env)
+		switch {
+		case deadline == 0:
+			continue
+		case now >= deadline:
+			if env.TTL.DeleteFailTime+int64(envTTLRetryInterval/time.Second) > now {
+				continue
+			}
+			expireEnv(env, idle, log)
+		case now >= deadline-int64(envTTLWarnBefore/time.Second) && env.TTL.WarnTime < deadline-int64(envTTLWarnBefore/time.Second):
+			// the deadline may be put off by updating or extending the env after the warning, warn again in this case
+			warnEnvExpiry(env, deadline, log)
+		}
+	}
+}
+
+func warnEnvExpiry(env *commonmodels.Product, deadline int64, log *zap.SugaredLogger) {
+	title := "环境即将过期"
+	content := fmt.Sprintf("项目：%s, 环境：%s 将于 %s 被自动删除, 如需继续使用请延长环境有效期", env.ProductName, env.EnvName, time.Unix(deadline, 0).Format("2006-01-02 15:04:05"))
+	notify.SendMessage(envTTLOwner(env), title, content, "", log)
+
+	env.TTL.WarnTime = time.Now().Unix()
+	if err := commonrepo.NewProductColl().UpdateTTL(env.EnvName, env.ProductName, env.TTL); err != nil {
+		log.Errorf("failed to update ttl of env %s/%s, error: %v", env.ProductName, env.EnvName, err)
+	}
+}
+
+func expireEnv(env *commonmodels.Product, idle bool, log *zap.SugaredLogger) {
+	reason := "有效期已到"
+	if idle {
+		reason = fmt.Sprintf("超过 %d 小时未更新", env.TTL.IdleTimeout)
+	}
+	log.Infof("env %s/%s expired: %s, deleting", env.ProductName, env.EnvName, reason)
+
+	// the result of the deletion is sent to the creator
+	status := http.StatusOK
+	if err := DeleteProduct(envTTLOwner(env), env.EnvName, env.ProductName, "", true, log); err != nil {
+		log.Errorf("failed to delete expired env %s/%s, error: %v", env.ProductName, env.EnvName, err)
+
+		env.TTL.DeleteFailTime = time.Now().Unix()
+		env.TTL.DeleteFailCount++
+		if err := commonrepo.NewProductColl().UpdateTTL(env.EnvName, env.ProductName, env.TTL); err != nil {
+			log.Errorf("failed to update ttl of env %s/%s, error: %v", env.ProductName, env.EnvName, err)
+		}
+		// the deletion is retried until it succeeds, the owner is notified and it's audited only for the first failure
+		if env.TTL.DeleteFailCount > 1 {
+			return
+		}
+		title := fmt.Sprintf("过期环境删除失败：[%s] 环境：[%s]", env.ProductName, env.EnvName)
+		notify.SendErrorMessage(envTTLOwner(env), title, "", err, log)
+		status = http.StatusInternalServerError
+	}
+
+	err := systemmongodb.NewOperationLogColl().Insert(&systemmodels.OperationLog{
+		Username:    setting.SystemUser,
+		ProductName: env.ProductName,
+		Method:      "删除",
+		Function:    "环境-过期回收",
+		Scene:       setting.OperationSceneEnv,
+		Targets:     []string{env.EnvName},
+		Name:        fmt.Sprintf("环境: %s, 创建者: %s, 原因: %s", env.EnvName, envTTLOwner(env), reason),
+		Status:      status,
+		CreatedAt:   time.Now().Unix(),
+	})
+	if err != nil {
+		log.Errorf("failed to insert operation log of expired env %s/%s, error: %v", env.ProductName, env.EnvName, err)
+	}
+}
+
+func findTTLEnv(productName, envName string) (*commonmodels.Product, error) {
+	return commonrepo.NewProductColl().Find(&commonrepo.ProductFindOptions{
+		Name:       productName,
+		EnvName:    envName,
+		Production: util.GetBoolPointer(false),
+	})
+}
+
+func GetEnvTTL(productName, envName string) (*EnvTTLResp, error) {
+	env, err := findTTLEnv(productName, envName)
+	if err != nil {
+		return nil, e.ErrGetEnv.AddErr(fmt.Errorf("failed to query product info, name %s", envName))
+	}
+	if env.TTL == nil {
+		return &EnvTTLResp{EnvTTL: &commonmodels.EnvTTL{}}, nil
+	}
+
+	deadline, _ := envTTLDeadline(env)
+	return &EnvTTLResp{EnvTTL: env.TTL, Deadline: deadline}, nil
+}
+
+// UpdateEnvTTL resets the ttl of the env, the ttl is counted from now on
+func UpdateEnvTTL(productName, envName, userName string, args *EnvTTLArgs) error {
+	if args.TTL < 0 || args.IdleTimeout < 0 {
+		return e.ErrInvalidParam.AddDesc("ttl and idle_timeout can not be negative")
+	}
+
+	env, err := findTTLEnv(productName, envName)
+	if err != nil {
+		return e.ErrUpdateEnv.AddErr(fmt.Errorf("failed to query product info, name %s", envName))
+	}
+
+	creator := userName
+	if env.TTL != nil && env.TTL.CreateBy != "" {
+		creator = env.TTL.CreateBy
+	}
+	if err := commonrepo.NewProductColl().UpdateTTL(envName, productName, newEnvTTL(args, false, creator)); err != nil {
+		return e.ErrUpdateEnv.AddErr(err)
+	}
+	return nil
+}
+
+// ExtendEnvTTL puts off the expiry of the env by the given hours, the idle time of the env is counted from now on as well
+func ExtendEnvTTL(productName, envName string, hours int64) (*EnvTTLResp, error) {
+	if hours <= 0 {
+		return nil, e.ErrInvalidParam.AddDesc("hours must be positive")
+	}
+
+	env, err := findTTLEnv(productName, envName)
+	if err != nil {
+		return nil, e.ErrExtendEnvTTL.AddErr(fmt.Errorf("failed to query product info, name %s", envName))
+	}
+	if env.TTL == nil {
+		return nil, e.ErrExtendEnvTTL.AddDesc("环境未设置有效期")
+	}
+	if env.Status == setting.ProductStatusDeleting {
+		return nil, e.ErrExtendEnvTTL.AddDesc("环境正在删除中")
+	}
+
+	now := time.Now().Unix()
+	if env.TTL.ExpireTime > 0 {
+		if env.TTL.ExpireTime < now {
+			env.TTL.ExpireTime = now
+		}
+		env.TTL.ExpireTime += hours * int64(time.Hour/time.Second)
+	}
+	env.TTL.ActiveTime = now
+	env.TTL.WarnTime = 0
+	env.TTL.DeleteFailTime = 0
+	env.TTL.DeleteFailCount = 0
+	if err := commonrepo.NewProductColl().UpdateTTL(envName, productName, env.TTL); err != nil {
+		return nil, e.ErrExtendEnvTTL.AddErr(err)
+	}
+
+	deadline, _ := envTTLDeadline(env)
+	return &EnvTTLResp{EnvTTL: env.TTL, Deadline: deadline}, nil
+}
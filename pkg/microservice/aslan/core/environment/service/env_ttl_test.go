@@ -0,0 +1,75 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+)
+
+var _ = Describe("Testing env ttl", func() {
+
+	Describe("test envTTLDeadline", func() {
+
+		It("should never expire without ttl", func() {
+			deadline, _ := envTTLDeadline(&commonmodels.Product{UpdateTime: 1000})
+			Expect(deadline).To(BeZero())
+		})
+
+		It("should expire at the expire time", func() {
+			deadline, idle := envTTLDeadline(&commonmodels.Product{UpdateTime: 1000, TTL: &commonmodels.EnvTTL{ExpireTime: 5000}})
+			Expect(deadline).To(Equal(int64(5000)))
+			Expect(idle).To(BeFalse())
+		})
+
+		It("should count the idle time from the last update or extension", func() {
+			env := &commonmodels.Product{UpdateTime: 1000, TTL: &commonmodels.EnvTTL{IdleTimeout: 1, ActiveTime: 500}}
+			deadline, idle := envTTLDeadline(env)
+			Expect(deadline).To(Equal(int64(4600)))
+			Expect(idle).To(BeTrue())
+
+			env.TTL.ActiveTime = 2000
+			deadline, _ = envTTLDeadline(env)
+			Expect(deadline).To(Equal(int64(5600)))
+		})
+
+		It("should expire at the earlier of the expire time and the idle deadline", func() {
+			env := &commonmodels.Product{UpdateTime: 1000, TTL: &commonmodels.EnvTTL{ExpireTime: 3000, IdleTimeout: 1}}
+			deadline, idle := envTTLDeadline(env)
+			Expect(deadline).To(Equal(int64(3000)))
+			Expect(idle).To(BeFalse())
+		})
+	})
+
+	Describe("test newEnvTTL", func() {
+
+		It("should not set ttl for production envs or empty args", func() {
+			Expect(newEnvTTL(&EnvTTLArgs{TTL: 24}, true, "admin")).To(BeNil())
+			Expect(newEnvTTL(&EnvTTLArgs{}, false, "admin")).To(BeNil())
+			Expect(newEnvTTL(nil, false, "admin")).To(BeNil())
+		})
+
+		It("should record the creator", func() {
+			ttl := newEnvTTL(&EnvTTLArgs{TTL: 24, IdleTimeout: 4}, false, "admin")
+			Expect(ttl.CreateBy).To(Equal("admin"))
+			Expect(ttl.ExpireTime - ttl.ActiveTime).To(Equal(int64(24 * 3600)))
+			Expect(ttl.IdleTimeout).To(Equal(int64(4)))
+		})
+	})
+})
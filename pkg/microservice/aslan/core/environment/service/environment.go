@@ -154,6 +154,10 @@ func ListProducts(userID, projectName string, envNames []string, production bool
 	if err != nil {
 		return nil, err
 	}
+	driftCountMap, err := commonrepo.NewEnvDriftColl().CountOpenByEnv(projectName, production)
+	if err != nil {
+		log.Errorf("failed to count env drifts, err: %s", err)
+	}
 	for _, env := range envs {
 		if len(env.RegistryID) == 0 {
 			env.RegistryID = defaultRegID
@@ -188,6 +192,7 @@ func ListProducts(userID, projectName string, envNames []string, production bool
 			IstioGrayscaleIsBase:  env.IstioGrayscale.IsBase,
 			IstioGrayscaleBaseEnv: env.IstioGrayscale.BaseEnv,
 			IsFavorite:            favSet.Has(env.EnvName),
+			DriftCount:            driftCountMap[env.EnvName],
 		})
 	}
 
@@ -1554,7 +1559,7 @@ func GenEstimatedValues(projectName, envName, serviceOrReleaseName string, scene
 
 	// generate the new yaml content
 	if isHelmChartDeploy {
-		chartRepo, err := commonrepo.NewHelmRepoColl().Find(&commonrepo.HelmRepoFindOption{RepoName: arg.ChartRepo})
+		chartRepo, err := commonutil.FindChartRepo(arg.ChartRepo, projectName)
 		if err != nil {
 			return nil, fmt.Errorf("failed to query chart-repo info, repoName: %s", arg.ChartRepo)
 		}
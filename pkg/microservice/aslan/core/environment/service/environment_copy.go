@@ -42,6 +42,7 @@ type YamlProductItem struct {
 	DefaultValues   string                           `json:"default_values"`
 	GlobalVariables []*commontypes.GlobalVariableKV  `json:"global_variables"`
 	Services        []*commonservice.K8sSvcRenderArg `json:"services"`
+	TTL             *EnvTTLArgs                      `json:"ttl,omitempty"`
 }
 
 type CopyYamlProductArg struct {
@@ -55,6 +56,7 @@ type HelmProductItem struct {
 	DefaultValues string                            `json:"default_values"`
 	ChartValues   []*commonservice.HelmSvcRenderArg `json:"chart_values"`
 	ValuesData    *commonservice.ValuesDataArgs     `json:"values_data"`
+	TTL           *EnvTTLArgs                       `json:"ttl,omitempty"`
 }
 
 type CopyHelmProductArg struct {
@@ -105,6 +107,7 @@ func BulkCopyHelmProduct(projectName, user, requestID string, arg CopyHelmProduc
 				BaseName:      item.BaseName,
 				ChartValues:   chartValues,
 				ValuesData:    item.ValuesData,
+				TTL:           item.TTL,
 			})
 		} else {
 			return fmt.Errorf("product:%s not exist", item.OldName)
@@ -149,6 +152,7 @@ func BulkCopyYamlProduct(projectName, user, requestID string, arg CopyYamlProduc
 			newProduct.BaseName = item.BaseName
 			newProduct.GlobalVariables = item.GlobalVariables
 			newProduct.DefaultValues = item.DefaultValues
+			newProduct.TTL = newEnvTTL(item.TTL, false, user)
 
 			svcVariableKVMap := make(map[string][]*commontypes.RenderVariableKV)
 			for _, sv := range item.Services {
@@ -281,6 +285,7 @@ func copySingleHelmProduct(templateProduct *templatemodels.Product, productInfo
 	productInfo.BaseName = arg.BaseName
 	productInfo.Namespace = commonservice.GetProductEnvNamespace(arg.EnvName, arg.ProductName, arg.Namespace)
 	productInfo.EnvConfigs = arg.EnvConfigs
+	productInfo.TTL = newEnvTTL(arg.TTL, false, userName)
 
 	// merge chart infos, use chart info in product to override charts in template_project
 	sourceChartMap := make(map[string]*templatemodels.ServiceRender)
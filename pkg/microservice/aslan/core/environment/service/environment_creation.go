@@ -176,6 +176,7 @@ func createSingleHelmProduct(templateProduct *templatemodels.Product, requestID,
 		IstioGrayscale:  arg.IstioGrayscale,
 		Production:      arg.Production,
 		Alias:           arg.Alias,
+		TTL:             newEnvTTL(arg.TTL, arg.Production, userName),
 	}
 
 	// fill services and chart infos of product
@@ -317,6 +318,7 @@ func createSingleYamlProduct(templateProduct *templatemodels.Product, requestID,
 		IstioGrayscale:  arg.IstioGrayscale,
 		Production:      arg.Production,
 		Alias:           arg.Alias,
+		TTL:             newEnvTTL(arg.TTL, arg.Production, userName),
 	}
 	if len(arg.BaseEnvName) > 0 {
 		productObj.BaseEnvName = arg.BaseEnvName
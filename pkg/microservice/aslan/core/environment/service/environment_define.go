@@ -73,6 +73,9 @@ type EnvResp struct {
 	IstioGrayscaleEnable  bool   `json:"istio_grayscale_enable"`
 	IstioGrayscaleIsBase  bool   `json:"istio_grayscale_is_base"`
 	IstioGrayscaleBaseEnv string `json:"istio_grayscale_base_env"`
+
+	// DriftCount is the number of open drifts found by the drift scan
+	DriftCount int `json:"drift_count"`
 }
 
 type SharedNSEnvs struct {
@@ -189,6 +192,16 @@ type CreateSingleProductArg struct {
 	EnvConfigs []*commonmodels.CreateUpdateCommonEnvCfgArgs `json:"env_configs"`
 	// New Since v2.1.0
 	IstioGrayscale commonmodels.IstioGrayscale `json:"istio_grayscale"`
+
+	// TTL deletes the env automatically once it expires, only works for non-production envs
+	TTL *EnvTTLArgs `json:"ttl,omitempty"`
+}
+
+type EnvTTLArgs struct {
+	// TTL is the hours the env is kept since it's created, 0 means unlimited
+	TTL int64 `json:"ttl"`
+	// IdleTimeout is the hours the env is kept without being updated, 0 means unlimited
+	IdleTimeout int64 `json:"idle_timeout"`
 }
 
 type UpdateMultiHelmProductArg struct {
@@ -321,6 +321,10 @@ func updateK8sProduct(exitedProd *commonmodels.Product, user, requestID string,
 	for _, svcRender := range updatedSvcs {
 		updatedSvcMap[svcRender.ServiceName] = svcRender
 		curSvcRender := exitedProd.GetSvcRender(svcRender.ServiceName)
+		// the adopted fields are only changed by adopting drifts, keep them if they are not in the request
+		if svcRender.AdoptedFields == nil {
+			svcRender.AdoptedFields = curSvcRender.AdoptedFields
+		}
 
 		if updateRevisionSvcSet.Has(svcRender.ServiceName) {
 			svcTemplate, err := repository.QueryTemplateService(&commonrepo.ServiceFindOption{
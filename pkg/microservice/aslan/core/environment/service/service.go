@@ -239,7 +239,12 @@ func GetServiceWorkloads(svcTmpl *commonmodels.Service, env *commonmodels.Produc
 	envName, productName, namespace := env.EnvName, env.ProductName, env.Namespace
 
 	svcRender := env.GetSvcRender(svcTmpl.ServiceName)
-	parsedYaml, err := kube.RenderServiceYaml(svcTmpl.Yaml, productName, svcTmpl.ServiceName, svcRender)
+	originYaml, err := kube.ServiceTemplateYaml(svcTmpl, envName)
+	if err != nil {
+		log.Errorf("failed to build service yaml, err: %s", err)
+		return nil, err
+	}
+	parsedYaml, err := kube.RenderServiceYaml(originYaml, productName, svcTmpl.ServiceName, svcRender)
 	if err != nil {
 		log.Errorf("failed to render service yaml, err: %s", err)
 		return nil, err
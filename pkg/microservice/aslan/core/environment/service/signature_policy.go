@@ -0,0 +1,68 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"context"
+	"fmt"
+
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	e "github.com/koderover/zadig/v2/pkg/tool/errors"
+)
+
+func GetEnvSignaturePolicy(productName, envName string, production bool) (*commonmodels.ImageSignaturePolicy, error) {
+	productInfo, err := commonrepo.NewProductColl().Find(&commonrepo.ProductFindOptions{
+		Name:       productName,
+		EnvName:    envName,
+		Production: &production,
+	})
+	if err != nil {
+		return nil, e.ErrGetEnv.AddErr(fmt.Errorf("failed to query product info, name %s", envName))
+	}
+	if productInfo.SignaturePolicy == nil {
+		return &commonmodels.ImageSignaturePolicy{TrustedKeyIDs: []string{}}, nil
+	}
+	return productInfo.SignaturePolicy, nil
+}
+
+func UpdateEnvSignaturePolicy(productName, envName string, production bool, policy *commonmodels.ImageSignaturePolicy) error {
+	_, err := commonrepo.NewProductColl().Find(&commonrepo.ProductFindOptions{
+		Name:       productName,
+		EnvName:    envName,
+		Production: &production,
+	})
+	if err != nil {
+		return e.ErrUpdateEnv.AddErr(fmt.Errorf("failed to query product info, name %s", envName))
+	}
+
+	if policy.Enabled && len(policy.TrustedKeyIDs) == 0 {
+		return e.ErrUpdateEnv.AddDesc("at least one trusted key is required")
+	}
+	keys, err := commonrepo.NewImageSigningKeyColl().ListByIDs(context.Background(), policy.TrustedKeyIDs)
+	if err != nil {
+		return e.ErrUpdateEnv.AddErr(err)
+	}
+	if len(keys) != len(policy.TrustedKeyIDs) {
+		return e.ErrUpdateEnv.AddDesc("some trusted keys do not exist")
+	}
+
+	if err := commonrepo.NewProductColl().UpdateSignaturePolicy(envName, productName, policy); err != nil {
+		return e.ErrUpdateEnv.AddErr(err)
+	}
+	return nil
+}
@@ -0,0 +1,548 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	templaterepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb/template"
+	commontypes "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/types"
+	commonutil "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/util"
+	internalhandler "github.com/koderover/zadig/v2/pkg/shared/handler"
+	e "github.com/koderover/zadig/v2/pkg/tool/errors"
+)
+
+type CreateEnvSnapshotArgs struct {
+	Name string `json:"name"`
+}
+
+type EnvSnapshotDiffOperation string
+
+const (
+	EnvSnapshotDiffAdded    EnvSnapshotDiffOperation = "added"
+	EnvSnapshotDiffDeleted  EnvSnapshotDiffOperation = "deleted"
+	EnvSnapshotDiffModified EnvSnapshotDiffOperation = "modified"
+)
+
+// EnvSnapshotDiff describes the changes from the state A to the state B of an environment,
+// items which could not be restored automatically, like added or deleted services, are marked as not restorable
+type EnvSnapshotDiff struct {
+	Services        []*EnvSnapshotServiceDiff   `json:"services"`
+	GlobalVariables []*EnvSnapshotVariableDiff  `json:"global_variables"`
+	DefaultValues   *EnvSnapshotYamlDiff        `json:"default_values,omitempty"`
+	ConfigMaps      []*EnvSnapshotConfigMapDiff `json:"config_maps"`
+}
+
+type EnvSnapshotServiceDiff struct {
+	ServiceName   string                   `json:"service_name"`
+	IsHelmChart   bool                     `json:"is_helm_chart"`
+	Operation     EnvSnapshotDiffOperation `json:"operation"`
+	Restorable    bool                     `json:"restorable"`
+	RevisionA     int64                    `json:"revision_a"`
+	RevisionB     int64                    `json:"revision_b"`
+	ChartVersionA string                   `json:"chart_version_a,omitempty"`
+	ChartVersionB string                   `json:"chart_version_b,omitempty"`
+	Images        []*EnvSnapshotImageDiff  `json:"images"`
+	VariableYamlA string                   `json:"variable_yaml_a"`
+	VariableYamlB string                   `json:"variable_yaml_b"`
+	// OverrideValues are the kv values of helm services
+	OverrideValuesA string `json:"override_values_a,omitempty"`
+	OverrideValuesB string `json:"override_values_b,omitempty"`
+}
+
+type EnvSnapshotImageDiff struct {
+	Container string `json:"container"`
+	ImageA    string `json:"image_a"`
+	ImageB    string `json:"image_b"`
+}
+
+type EnvSnapshotVariableDiff struct {
+	Key       string                   `json:"key"`
+	Operation EnvSnapshotDiffOperation `json:"operation"`
+	ValueA    interface{}              `json:"value_a"`
+	ValueB    interface{}              `json:"value_b"`
+}
+
+type EnvSnapshotYamlDiff struct {
+	YamlA string `json:"yaml_a"`
+	YamlB string `json:"yaml_b"`
+}
+
+type EnvSnapshotConfigMapDiff struct {
+	Name       string                   `json:"name"`
+	Operation  EnvSnapshotDiffOperation `json:"operation"`
+	Restorable bool                     `json:"restorable"`
+	YamlA      string                   `json:"yaml_a"`
+	YamlB      string                   `json:"yaml_b"`
+}
+
+// RestoreEnvSnapshotResult reports the items applied by restoring the env to a snapshot,
+// the env can be restored to the backup snapshot taken before the restoration if it fails partway
+type RestoreEnvSnapshotResult struct {
+	BackupSnapshotID   string   `json:"backup_snapshot_id"`
+	BackupSnapshotName string   `json:"backup_snapshot_name"`
+	Services           []string `json:"services"`
+	GlobalVariables    bool     `json:"global_variables"`
+	DefaultValues      bool     `json:"default_values"`
+	ConfigMaps         []string `json:"config_maps"`
+}
+
+func (r *RestoreEnvSnapshotResult) applied() string {
+	items := make([]string, 0)
+	for _, svc := range r.Services {
+		items = append(items, "service "+svc)
+	}
+	if r.GlobalVariables {
+		items = append(items, "global variables")
+	}
+	if r.DefaultValues {
+		items = append(items, "default values")
+	}
+	for _, cm := range r.ConfigMaps {
+		items = append(items, "configmap "+cm)
+	}
+	if len(items) == 0 {
+		return "none"
+	}
+	return strings.Join(items, ", ")
+}
+
+// failed returns the error of the restoration with the applied items and the backup snapshot to restore the env to
+func (r *RestoreEnvSnapshotResult) failed(err error) error {
+	return e.ErrRestoreEnvSnapshot.AddErr(fmt.Errorf("%v, applied items: %s, the env can be restored to the snapshot %s taken before the restoration", err, r.applied(), r.BackupSnapshotName))
+}
+
+func CreateEnvSnapshot(projectName, envName, name, userName string, production bool, log *zap.SugaredLogger) (*commonmodels.EnvSnapshot, error) {
+	env, err := commonrepo.NewProductColl().Find(&commonrepo.ProductFindOptions{
+		Name:       projectName,
+		EnvName:    envName,
+		Production: &production,
+	})
+	if err != nil {
+		return nil, e.ErrCreateEnvSnapshot.AddErr(fmt.Errorf("failed to find %s/%s env, isProduction %v, error: %v", projectName, envName, production, err))
+	}
+
+	if name == "" {
+		name = fmt.Sprintf("%s-%s", envName, time.Now().Format("20060102150405"))
+	}
+	snapshot, err := commonutil.CreateEnvSnapshot(env, &commonutil.CreateEnvSnapshotArgs{
+		Name:     name,
+		Source:   config.EnvSnapshotSourceManual,
+		CreateBy: userName,
+	}, log)
+	if err != nil {
+		return nil, e.ErrCreateEnvSnapshot.AddErr(err)
+	}
+	return snapshot, nil
+}
+
+func ListEnvSnapshots(projectName, envName string, production bool, log *zap.SugaredLogger) ([]*commonmodels.EnvSnapshot, error) {
+	snapshots, err := commonrepo.NewEnvSnapshotColl().List(&commonrepo.EnvSnapshotListOption{
+		ProductName: projectName,
+		EnvName:     envName,
+		Production:  production,
+	})
+	if err != nil {
+		log.Errorf("failed to list snapshots of env %s/%s, error: %v", projectName, envName, err)
+		return nil, e.ErrListEnvSnapshots.AddErr(err)
+	}
+	return snapshots, nil
+}
+
+func GetEnvSnapshot(projectName, envName, id string, production bool) (*commonmodels.EnvSnapshot, error) {
+	snapshot, err := commonrepo.NewEnvSnapshotColl().GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find snapshot %s, error: %v", id, err)
+	}
+	if snapshot.ProductName != projectName || snapshot.EnvName != envName || snapshot.Production != production {
+		return nil, fmt.Errorf("snapshot %s is not taken from env %s/%s", id, projectName, envName)
+	}
+	return snapshot, nil
+}
+
+func DeleteEnvSnapshot(projectName, envName, id string, production bool, log *zap.SugaredLogger) error {
+	if _, err := GetEnvSnapshot(projectName, envName, id, production); err != nil {
+		return e.ErrDeleteEnvSnapshot.AddErr(err)
+	}
+	if err := commonrepo.NewEnvSnapshotColl().DeleteByID(id); err != nil {
+		log.Errorf("failed to delete snapshot %s, error: %v", id, err)
+		return e.ErrDeleteEnvSnapshot.AddErr(err)
+	}
+	return nil
+}
+
+func DiffEnvSnapshots(projectName, envName, idA, idB string, production bool) (*EnvSnapshotDiff, error) {
+	snapshotA, err := GetEnvSnapshot(projectName, envName, idA, production)
+	if err != nil {
+		return nil, e.ErrDiffEnvSnapshots.AddErr(err)
+	}
+	snapshotB, err := GetEnvSnapshot(projectName, envName, idB, production)
+	if err != nil {
+		return nil, e.ErrDiffEnvSnapshots.AddErr(err)
+	}
+	return diffEnvSnapshots(snapshotA, snapshotB), nil
+}
+
+// PreviewRestoreEnvSnapshot returns the changes from the current state of the env to the snapshot
+func PreviewRestoreEnvSnapshot(projectName, envName, id string, production bool) (*EnvSnapshotDiff, error) {
+	_, diff, err := prepareRestoreEnvSnapshot(projectName, envName, id, production)
+	if err != nil {
+		return nil, e.ErrRestoreEnvSnapshot.AddErr(err)
+	}
+	return diff, nil
+}
+
+func prepareRestoreEnvSnapshot(projectName, envName, id string, production bool) (*commonmodels.EnvSnapshot, *EnvSnapshotDiff, error) {
+	snapshot, err := GetEnvSnapshot(projectName, envName, id, production)
+	if err != nil {
+		return nil, nil, err
+	}
+	env, err := commonrepo.NewProductColl().Find(&commonrepo.ProductFindOptions{
+		Name:       projectName,
+		EnvName:    envName,
+		Production: &production,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find %s/%s env, isProduction %v, error: %v", projectName, envName, production, err)
+	}
+	current, err := commonutil.GenerateEnvSnapshot(env, &commonutil.CreateEnvSnapshotArgs{})
+	if err != nil {
+		return nil, nil, err
+	}
+	return snapshot, diffEnvSnapshots(current, snapshot), nil
+}
+
+// RestoreEnvSnapshot restores the services, global variables and configmaps of the env to the snapshot,
+// services are restored one by one in the same way as rolling back a single service.
+// A snapshot of the current state is taken before the restoration so that a restoration failed partway can be undone.
+func RestoreEnvSnapshot(ctx *internalhandler.Context, projectName, envName, id string, production bool, log *zap.SugaredLogger) (*RestoreEnvSnapshotResult, error) {
+	snapshot, diff, err := prepareRestoreEnvSnapshot(projectName, envName, id, production)
+	if err != nil {
+		return nil, e.ErrRestoreEnvSnapshot.AddErr(err)
+	}
+
+	env, err := commonrepo.NewProductColl().Find(&commonrepo.ProductFindOptions{
+		Name:       projectName,
+		EnvName:    envName,
+		Production: &production,
+	})
+	if err != nil {
+		return nil, e.ErrRestoreEnvSnapshot.AddErr(fmt.Errorf("failed to find %s/%s env, isProduction %v, error: %v", projectName, envName, production, err))
+	}
+	if env.IsSleeping() {
+		return nil, e.ErrRestoreEnvSnapshot.AddDesc("environment is sleeping")
+	}
+
+	backup, err := commonutil.CreateEnvSnapshot(env, &commonutil.CreateEnvSnapshotArgs{
+		Name:     fmt.Sprintf("%s-before-restore-%s", envName, time.Now().Format("20060102150405")),
+		Source:   config.EnvSnapshotSourceRestore,
+		CreateBy: ctx.UserName,
+	}, log)
+	if err != nil {
+		return nil, e.ErrRestoreEnvSnapshot.AddErr(fmt.Errorf("failed to take a snapshot of the env before restoring, error: %v", err))
+	}
+	result := &RestoreEnvSnapshotResult{
+		BackupSnapshotID:   backup.ID.Hex(),
+		BackupSnapshotName: backup.Name,
+		Services:           make([]string, 0),
+		ConfigMaps:         make([]string, 0),
+	}
+
+	snapshotSvcMap := make(map[string]*commonmodels.ProductService)
+	for _, svc := range snapshot.Services {
+		snapshotSvcMap[snapshotServiceKey(svc)] = svc
+	}
+	for _, svcDiff := range diff.Services {
+		if !svcDiff.Restorable {
+			log.Warnf("service %s is %s since snapshot %s was taken, skip restoring it", svcDiff.ServiceName, svcDiff.Operation, snapshot.Name)
+			continue
+		}
+		svc := snapshotSvcMap[snapshotServiceDiffKey(svcDiff)]
+		envSvcVersion := &commonmodels.EnvServiceVersion{
+			ProductName:     snapshot.ProductName,
+			EnvName:         snapshot.EnvName,
+			Namespace:       env.Namespace,
+			Production:      snapshot.Production,
+			Service:         svc,
+			DefaultValues:   snapshot.DefaultValues,
+			YamlData:        snapshot.YamlData,
+			GlobalVariables: snapshot.GlobalVariables,
+		}
+		if err := rollbackEnvService(ctx, envSvcVersion, log); err != nil {
+			return result, result.failed(fmt.Errorf("failed to restore service %s, error: %v", svcDiff.ServiceName, err))
+		}
+		result.Services = append(result.Services, svcDiff.ServiceName)
+	}
+
+	if len(diff.GlobalVariables) > 0 {
+		if err := restoreEnvGlobalVariables(ctx, snapshot, log); err != nil {
+			return result, result.failed(err)
+		}
+		result.GlobalVariables = true
+	}
+	if diff.DefaultValues != nil {
+		err = UpdateProductVariable(projectName, envName, ctx.UserName, ctx.RequestID, nil, nil, snapshot.DefaultValues, snapshot.YamlData, log)
+		if err != nil {
+			return result, result.failed(fmt.Errorf("failed to restore default values, error: %v", err))
+		}
+		result.DefaultValues = true
+	}
+
+	for _, cmDiff := range diff.ConfigMaps {
+		if !cmDiff.Restorable {
+			log.Warnf("configmap %s is %s since snapshot %s was taken, skip restoring it", cmDiff.Name, cmDiff.Operation, snapshot.Name)
+			continue
+		}
+		err = UpdateCommonEnvCfg(&commonmodels.CreateUpdateCommonEnvCfgArgs{
+			EnvName:          envName,
+			ProductName:      projectName,
+			Name:             cmDiff.Name,
+			YamlData:         cmDiff.YamlB,
+			CommonEnvCfgType: config.CommonEnvCfgTypeConfigMap,
+			Production:       production,
+		}, ctx.UserName, true, log)
+		if err != nil {
+			return result, result.failed(fmt.Errorf("failed to restore configmap %s, error: %v", cmDiff.Name, err))
+		}
+		result.ConfigMaps = append(result.ConfigMaps, cmDiff.Name)
+	}
+
+	return result, nil
+}
+
+// restoreEnvGlobalVariables restores the values of global variables, the services restored from the snapshot no longer
+// use the global variables just like rolling back a single service, so the related services are kept as they are now
+func restoreEnvGlobalVariables(ctx *internalhandler.Context, snapshot *commonmodels.EnvSnapshot, log *zap.SugaredLogger) error {
+	env, err := commonrepo.NewProductColl().Find(&commonrepo.ProductFindOptions{
+		Name:       snapshot.ProductName,
+		EnvName:    snapshot.EnvName,
+		Production: &snapshot.Production,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to find %s/%s env, error: %v", snapshot.ProductName, snapshot.EnvName, err)
+	}
+	project, err := templaterepo.NewProductColl().Find(snapshot.ProductName)
+	if err != nil {
+		return fmt.Errorf("failed to find project %s, error: %v", snapshot.ProductName, err)
+	}
+
+	relatedServices := make(map[string][]string)
+	for _, kv := range env.GlobalVariables {
+		relatedServices[kv.Key] = kv.RelatedServices
+	}
+	args := make([]*commontypes.GlobalVariableKV, 0, len(snapshot.GlobalVariables))
+	for _, kv := range snapshot.GlobalVariables {
+		args = append(args, &commontypes.GlobalVariableKV{
+			ServiceVariableKV: kv.ServiceVariableKV,
+			RelatedServices:   relatedServices[kv.Key],
+		})
+	}
+	// global variables which are still used by services can't be deleted
+	for _, kv := range env.GlobalVariables {
+		if !hasGlobalVariable(snapshot.GlobalVariables, kv.Key) && len(kv.RelatedServices) > 0 {
+			args = append(args, kv)
+		}
+	}
+
+	err = UpdateProductGlobalVariablesWithRender(project, env, nil, ctx.UserName, ctx.RequestID, args, log)
+	if err != nil {
+		return fmt.Errorf("failed to restore global variables, error: %v", err)
+	}
+	return nil
+}
+
+func hasGlobalVariable(kvs []*commontypes.GlobalVariableKV, key string) bool {
+	for _, kv := range kvs {
+		if kv.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+func snapshotServiceKey(svc *commonmodels.ProductService) string {
+	if !svc.FromZadig() {
+		return "chart:" + svc.ReleaseName
+	}
+	return svc.ServiceName
+}
+
+func snapshotServiceDiffKey(diff *EnvSnapshotServiceDiff) string {
+	if diff.IsHelmChart {
+		return "chart:" + diff.ServiceName
+	}
+	return diff.ServiceName
+}
+
+func diffEnvSnapshots(a, b *commonmodels.EnvSnapshot) *EnvSnapshotDiff {
+	ret := &EnvSnapshotDiff{
+		Services:        make([]*EnvSnapshotServiceDiff, 0),
+		GlobalVariables: make([]*EnvSnapshotVariableDiff, 0),
+		ConfigMaps:      make([]*EnvSnapshotConfigMapDiff, 0),
+	}
+
+	svcMapA := make(map[string]*commonmodels.ProductService)
+	for _, svc := range a.Services {
+		svcMapA[snapshotServiceKey(svc)] = svc
+	}
+	svcMapB := make(map[string]*commonmodels.ProductService)
+	for _, svc := range b.Services {
+		svcMapB[snapshotServiceKey(svc)] = svc
+	}
+	for key, svcA := range svcMapA {
+		svcB, ok := svcMapB[key]
+		if !ok {
+			ret.Services = append(ret.Services, newServiceDiff(svcA, nil, EnvSnapshotDiffDeleted))
+			continue
+		}
+		if svcDiff := newServiceDiff(svcA, svcB, EnvSnapshotDiffModified); svcDiff.changed() {
+			ret.Services = append(ret.Services, svcDiff)
+		}
+	}
+	for key, svcB := range svcMapB {
+		if _, ok := svcMapA[key]; !ok {
+			ret.Services = append(ret.Services, newServiceDiff(nil, svcB, EnvSnapshotDiffAdded))
+		}
+	}
+	sort.Slice(ret.Services, func(i, j int) bool {
+		return ret.Services[i].ServiceName < ret.Services[j].ServiceName
+	})
+
+	kvMapA := make(map[string]*commontypes.GlobalVariableKV)
+	for _, kv := range a.GlobalVariables {
+		kvMapA[kv.Key] = kv
+	}
+	kvMapB := make(map[string]*commontypes.GlobalVariableKV)
+	for _, kv := range b.GlobalVariables {
+		kvMapB[kv.Key] = kv
+	}
+	for key, kvA := range kvMapA {
+		kvB, ok := kvMapB[key]
+		if !ok {
+			ret.GlobalVariables = append(ret.GlobalVariables, &EnvSnapshotVariableDiff{Key: key, Operation: EnvSnapshotDiffDeleted, ValueA: kvA.Value})
+		} else if fmt.Sprintf("%v", kvA.Value) != fmt.Sprintf("%v", kvB.Value) {
+			ret.GlobalVariables = append(ret.GlobalVariables, &EnvSnapshotVariableDiff{Key: key, Operation: EnvSnapshotDiffModified, ValueA: kvA.Value, ValueB: kvB.Value})
+		}
+	}
+	for key, kvB := range kvMapB {
+		if _, ok := kvMapA[key]; !ok {
+			ret.GlobalVariables = append(ret.GlobalVariables, &EnvSnapshotVariableDiff{Key: key, Operation: EnvSnapshotDiffAdded, ValueB: kvB.Value})
+		}
+	}
+	sort.Slice(ret.GlobalVariables, func(i, j int) bool {
+		return ret.GlobalVariables[i].Key < ret.GlobalVariables[j].Key
+	})
+
+	if strings.TrimSpace(a.DefaultValues) != strings.TrimSpace(b.DefaultValues) {
+		ret.DefaultValues = &EnvSnapshotYamlDiff{YamlA: a.DefaultValues, YamlB: b.DefaultValues}
+	}
+
+	cmMapA := make(map[string]string)
+	for _, cm := range a.ConfigMaps {
+		cmMapA[cm.Name] = cm.YamlData
+	}
+	cmMapB := make(map[string]string)
+	for _, cm := range b.ConfigMaps {
+		cmMapB[cm.Name] = cm.YamlData
+	}
+	for name, yamlA := range cmMapA {
+		yamlB, ok := cmMapB[name]
+		if !ok {
+			ret.ConfigMaps = append(ret.ConfigMaps, &EnvSnapshotConfigMapDiff{Name: name, Operation: EnvSnapshotDiffDeleted, YamlA: yamlA})
+		} else if strings.TrimSpace(yamlA) != strings.TrimSpace(yamlB) {
+			ret.ConfigMaps = append(ret.ConfigMaps, &EnvSnapshotConfigMapDiff{Name: name, Operation: EnvSnapshotDiffModified, Restorable: true, YamlA: yamlA, YamlB: yamlB})
+		}
+	}
+	for name, yamlB := range cmMapB {
+		if _, ok := cmMapA[name]; !ok {
+			ret.ConfigMaps = append(ret.ConfigMaps, &EnvSnapshotConfigMapDiff{Name: name, Operation: EnvSnapshotDiffAdded, YamlB: yamlB})
+		}
+	}
+	sort.Slice(ret.ConfigMaps, func(i, j int) bool {
+		return ret.ConfigMaps[i].Name < ret.ConfigMaps[j].Name
+	})
+
+	return ret
+}
+
+// newServiceDiff compares the service in state A and state B, either of them could be nil when the service is added or deleted
+func newServiceDiff(svcA, svcB *commonmodels.ProductService, operation EnvSnapshotDiffOperation) *EnvSnapshotServiceDiff {
+	ret := &EnvSnapshotServiceDiff{
+		Operation:  operation,
+		Restorable: operation == EnvSnapshotDiffModified,
+		Images:     make([]*EnvSnapshotImageDiff, 0),
+	}
+	imagesA, imagesB := make(map[string]string), make(map[string]string)
+	for i, svc := range []*commonmodels.ProductService{svcA, svcB} {
+		if svc == nil {
+			continue
+		}
+		ret.ServiceName = svc.ServiceName
+		ret.IsHelmChart = !svc.FromZadig()
+		if ret.IsHelmChart {
+			ret.ServiceName = svc.ReleaseName
+		}
+		images := imagesA
+		if i == 0 {
+			ret.RevisionA = svc.Revision
+			ret.ChartVersionA = svc.GetServiceRender().ChartVersion
+			ret.VariableYamlA = svc.GetServiceRender().GetOverrideYaml()
+			ret.OverrideValuesA = svc.GetServiceRender().OverrideValues
+		} else {
+			ret.RevisionB = svc.Revision
+			ret.ChartVersionB = svc.GetServiceRender().ChartVersion
+			ret.VariableYamlB = svc.GetServiceRender().GetOverrideYaml()
+			ret.OverrideValuesB = svc.GetServiceRender().OverrideValues
+			images = imagesB
+		}
+		for _, container := range svc.Containers {
+			images[container.Name] = container.Image
+		}
+	}
+
+	for name, imageA := range imagesA {
+		if imagesB[name] != imageA {
+			ret.Images = append(ret.Images, &EnvSnapshotImageDiff{Container: name, ImageA: imageA, ImageB: imagesB[name]})
+		}
+	}
+	for name, imageB := range imagesB {
+		if _, ok := imagesA[name]; !ok {
+			ret.Images = append(ret.Images, &EnvSnapshotImageDiff{Container: name, ImageB: imageB})
+		}
+	}
+	sort.Slice(ret.Images, func(i, j int) bool {
+		return ret.Images[i].Container < ret.Images[j].Container
+	})
+	return ret
+}
+
+func (d *EnvSnapshotServiceDiff) changed() bool {
+	return d.Operation != EnvSnapshotDiffModified ||
+		d.RevisionA != d.RevisionB ||
+		d.ChartVersionA != d.ChartVersionB ||
+		len(d.Images) > 0 ||
+		strings.TrimSpace(d.VariableYamlA) != strings.TrimSpace(d.VariableYamlB) ||
+		d.OverrideValuesA != d.OverrideValuesB
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commontypes "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/types"
+	"github.com/koderover/zadig/v2/pkg/setting"
+)
+
+var _ = Describe("Testing env snapshot", func() {
+
+	Describe("test diffEnvSnapshots", func() {
+
+		snapshotA := &commonmodels.EnvSnapshot{
+			Services: []*commonmodels.ProductService{
+				{ServiceName: "svc-a", Type: setting.K8SDeployType, Revision: 1, Containers: []*commonmodels.Container{{Name: "a", Image: "a:v1"}}},
+				{ServiceName: "svc-b", Type: setting.K8SDeployType, Revision: 2, Containers: []*commonmodels.Container{{Name: "b", Image: "b:v1"}}},
+				{ServiceName: "svc-c", Type: setting.K8SDeployType, Revision: 1},
+			},
+			GlobalVariables: []*commontypes.GlobalVariableKV{
+				{ServiceVariableKV: commontypes.ServiceVariableKV{Key: "replicas", Value: 1}},
+				{ServiceVariableKV: commontypes.ServiceVariableKV{Key: "domain", Value: "a.com"}},
+			},
+			ConfigMaps: []*commonmodels.EnvSnapshotConfigMap{{Name: "cm", YamlData: "data: a"}},
+		}
+		snapshotB := &commonmodels.EnvSnapshot{
+			Services: []*commonmodels.ProductService{
+				{ServiceName: "svc-a", Type: setting.K8SDeployType, Revision: 1, Containers: []*commonmodels.Container{{Name: "a", Image: "a:v2"}}},
+				{ServiceName: "svc-b", Type: setting.K8SDeployType, Revision: 2, Containers: []*commonmodels.Container{{Name: "b", Image: "b:v1"}}},
+				{ReleaseName: "chart-d", Type: setting.HelmChartDeployType},
+			},
+			GlobalVariables: []*commontypes.GlobalVariableKV{
+				{ServiceVariableKV: commontypes.ServiceVariableKV{Key: "replicas", Value: 2}},
+				{ServiceVariableKV: commontypes.ServiceVariableKV{Key: "domain", Value: "a.com"}},
+			},
+			ConfigMaps: []*commonmodels.EnvSnapshotConfigMap{{Name: "cm", YamlData: "data: b"}},
+		}
+
+		It("should only return the changed items", func() {
+			diff := diffEnvSnapshots(snapshotA, snapshotB)
+
+			Expect(diff.Services).To(HaveLen(3))
+			Expect(diff.Services[0].ServiceName).To(Equal("chart-d"))
+			Expect(diff.Services[0].IsHelmChart).To(BeTrue())
+			Expect(diff.Services[0].Operation).To(Equal(EnvSnapshotDiffAdded))
+			Expect(diff.Services[0].Restorable).To(BeFalse())
+			Expect(diff.Services[1].ServiceName).To(Equal("svc-a"))
+			Expect(diff.Services[1].Operation).To(Equal(EnvSnapshotDiffModified))
+			Expect(diff.Services[1].Restorable).To(BeTrue())
+			Expect(diff.Services[1].Images).To(HaveLen(1))
+			Expect(diff.Services[1].Images[0].ImageA).To(Equal("a:v1"))
+			Expect(diff.Services[1].Images[0].ImageB).To(Equal("a:v2"))
+			Expect(diff.Services[2].ServiceName).To(Equal("svc-c"))
+			Expect(diff.Services[2].Operation).To(Equal(EnvSnapshotDiffDeleted))
+
+			Expect(diff.GlobalVariables).To(HaveLen(1))
+			Expect(diff.GlobalVariables[0].Key).To(Equal("replicas"))
+
+			Expect(diff.DefaultValues).To(BeNil())
+			Expect(diff.ConfigMaps).To(HaveLen(1))
+			Expect(diff.ConfigMaps[0].Restorable).To(BeTrue())
+		})
+
+		It("should return nothing for the same snapshot", func() {
+			diff := diffEnvSnapshots(snapshotA, snapshotA)
+
+			Expect(diff.Services).To(BeEmpty())
+			Expect(diff.GlobalVariables).To(BeEmpty())
+			Expect(diff.ConfigMaps).To(BeEmpty())
+		})
+	})
+
+	Describe("test RestoreEnvSnapshotResult", func() {
+
+		It("should report the applied items", func() {
+			result := &RestoreEnvSnapshotResult{BackupSnapshotName: "dev-before-restore"}
+			Expect(result.applied()).To(Equal("none"))
+
+			result.Services = []string{"svc-a", "svc-b"}
+			result.GlobalVariables = true
+			result.ConfigMaps = []string{"cm-a"}
+			Expect(result.applied()).To(Equal("service svc-a, service svc-b, global variables, configmap cm-a"))
+		})
+	})
+})
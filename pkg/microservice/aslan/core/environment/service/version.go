@@ -108,7 +108,7 @@ func GetEnvServiceVersionYaml(ctx *internalhandler.Context, projectName, envName
 		chartRepoName := envSvcRevision.Service.GetServiceRender().ChartRepo
 		chartName := envSvcRevision.Service.GetServiceRender().ChartName
 		chartVersion := envSvcRevision.Service.GetServiceRender().ChartVersion
-		chartRepo, err := commonrepo.NewHelmRepoColl().Find(&commonrepo.HelmRepoFindOption{RepoName: chartRepoName})
+		chartRepo, err := commonutil.FindChartRepo(chartRepoName, projectName)
 		if err != nil {
 			return resp, e.ErrDiffEnvServiceVersions.AddErr(fmt.Errorf("failed to query chart-repo info, repoName: %s", chartRepoName))
 		}
@@ -173,6 +173,17 @@ func RollbackEnvServiceVersion(ctx *internalhandler.Context, projectName, envNam
 		return e.ErrRollbackEnvServiceVersion.AddErr(fmt.Errorf("failed to find %s/%s/%s service for revision %d, isProduction %v, error: %v", projectName, envName, serviceName, revision, isProduction, err))
 	}
 
+	return rollbackEnvService(ctx, envSvcVersion, log)
+}
+
+// rollbackEnvService deploys the service in the version to the env and records the rollback
+func rollbackEnvService(ctx *internalhandler.Context, envSvcVersion *commonmodels.EnvServiceVersion, log *zap.SugaredLogger) error {
+	projectName, envName, isProduction := envSvcVersion.ProductName, envSvcVersion.EnvName, envSvcVersion.Production
+	serviceName := envSvcVersion.Service.ServiceName
+	if !envSvcVersion.Service.FromZadig() {
+		serviceName = envSvcVersion.Service.ReleaseName
+	}
+
 	env, err := mongodb.NewProductColl().Find(&mongodb.ProductFindOptions{
 		Name:       projectName,
 		EnvName:    envName,
@@ -100,6 +100,12 @@ func (*Router) Inject(router *gin.RouterGroup) {
 		template.POST("/preview", PreviewServiceYamlFromYamlTemplate)
 	}
 
+	kustomize := router.Group("kustomize")
+	{
+		kustomize.POST("/load", LoadServiceFromKustomize)
+		kustomize.POST("/reload", ReloadServiceFromKustomize)
+	}
+
 	version := router.Group("version")
 	{
 		version.GET("/:serviceName", ListServiceVersions)
@@ -556,7 +556,13 @@ func CreateOrUpdateHelmServiceFromChartRepo(projectName string, args *HelmServic
 		return nil, e.ErrCreateTemplate.AddDesc("invalid argument")
 	}
 
-	chartRepo, err := commonrepo.NewHelmRepoColl().Find(&commonrepo.HelmRepoFindOption{RepoName: chartRepoArgs.ChartRepoName})
+	var err error
+	chartRepoArgs.ChartRepoName, chartRepoArgs.ChartName, chartRepoArgs.ChartVersion, err = commonutil.ResolveOCIChartRef(chartRepoArgs.ChartRepoName, chartRepoArgs.ChartName, chartRepoArgs.ChartVersion)
+	if err != nil {
+		return nil, e.ErrCreateTemplate.AddErr(err)
+	}
+
+	chartRepo, err := commonutil.FindChartRepo(chartRepoArgs.ChartRepoName, projectName)
 	if err != nil {
 		log.Errorf("failed to query chart-repo info, productName: %s, err: %s", projectName, err)
 		return nil, e.ErrCreateTemplate.AddDesc(fmt.Sprintf("failed to query chart-repo info, productName: %s, repoName: %s", projectName, chartRepoArgs.ChartRepoName))
@@ -0,0 +1,174 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/27149chen/afero"
+	"go.uber.org/zap"
+
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	fsservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/fs"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/kube"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/repository"
+	"github.com/koderover/zadig/v2/pkg/setting"
+	"github.com/koderover/zadig/v2/pkg/tool/kustomize"
+)
+
+// LoadServiceFromKustomize creates a k8s service from the kustomization directory of a github or gitlab repository,
+// the files are saved in the service revision so that each revision can be built again for the environments
+func LoadServiceFromKustomize(username string, req *LoadServiceFromKustomizeReq, force bool, production bool, logger *zap.SugaredLogger) error {
+	if strings.ToLower(req.ServiceName) != req.ServiceName {
+		return fmt.Errorf("service name should be lowercase")
+	}
+
+	config, err := loadKustomizeConfig(req)
+	if err != nil {
+		logger.Errorf("Failed to load kustomization from %s/%s/%s, the error is: %s", req.RepoOwner, req.RepoName, req.LoadPath, err)
+		return err
+	}
+
+	// the base yaml is used to extract the containers of the service
+	baseYaml, err := kube.BuildKustomizeYaml(config, "")
+	if err != nil {
+		return err
+	}
+
+	service := &commonmodels.Service{
+		ServiceName:   req.ServiceName,
+		Type:          setting.K8SDeployType,
+		ProductName:   req.ProjectName,
+		Source:        setting.SourceFromKustomize,
+		Yaml:          baseYaml,
+		Visibility:    setting.PrivateVisibility,
+		CodehostID:    req.CodehostID,
+		RepoOwner:     req.RepoOwner,
+		RepoNamespace: req.Namespace,
+		RepoName:      req.RepoName,
+		BranchName:    req.BranchName,
+		LoadPath:      req.LoadPath,
+		LoadFromDir:   true,
+		Kustomize:     config,
+	}
+	_, err = CreateServiceTemplate(username, service, force, production, logger)
+	if err != nil {
+		logger.Errorf("Failed to create service template from kustomization %s, the error is: %s", req.LoadPath, err)
+	}
+	return err
+}
+
+// ReloadServiceFromKustomize pulls the kustomization again and creates a new service revision
+func ReloadServiceFromKustomize(username string, req *LoadServiceFromKustomizeReq, production bool, logger *zap.SugaredLogger) error {
+	service, err := repository.QueryTemplateService(&commonrepo.ServiceFindOption{
+		ServiceName: req.ServiceName,
+		ProductName: req.ProjectName,
+	}, production)
+	if err != nil {
+		logger.Errorf("Cannot find service of name [%s] from project [%s], the error is: %s", req.ServiceName, req.ProjectName, err)
+		return err
+	}
+	if service.Source != setting.SourceFromKustomize {
+		return errors.New("service is not created from kustomize")
+	}
+	return LoadServiceFromKustomize(username, req, true, production, logger)
+}
+
+func loadKustomizeConfig(req *LoadServiceFromKustomizeReq) (*commonmodels.KustomizeConfig, error) {
+	loadPath := strings.Trim(req.LoadPath, "/")
+	tree, err := fsservice.DownloadFilesFromSource(&fsservice.DownloadFromSourceArgs{
+		CodehostID: req.CodehostID,
+		Owner:      req.RepoOwner,
+		Namespace:  req.Namespace,
+		Repo:       req.RepoName,
+		Path:       loadPath,
+		Branch:     req.BranchName,
+	}, func(afero.Fs) (string, error) {
+		return "", nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to download files: %s", err)
+	}
+
+	// files are downloaded into the directory named after the last element of the load path
+	root := "."
+	if loadPath != "" {
+		root = path.Base(loadPath)
+	}
+	files := make([]*commonmodels.KustomizeFile, 0)
+	fileMap := make(map[string][]byte)
+	err = fs.WalkDir(tree, root, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		content, err := fs.ReadFile(tree, filePath)
+		if err != nil {
+			return err
+		}
+		relPath := filePath
+		if root != "." {
+			relPath = strings.TrimPrefix(filePath, root+"/")
+		}
+		files = append(files, &commonmodels.KustomizeFile{
+			Path:    relPath,
+			Content: string(content),
+		})
+		fileMap[relPath] = content
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read files: %s", err)
+	}
+
+	config := &commonmodels.KustomizeConfig{
+		BasePath: cleanKustomizePath(req.BasePath),
+		Overlays: req.Overlays,
+		Files:    files,
+	}
+	if !kustomize.IsKustomizationDir(fileMap, config.BasePath) {
+		return nil, fmt.Errorf("kustomization file not found in base path: %s", req.BasePath)
+	}
+	envs := make(map[string]bool)
+	for _, overlay := range config.Overlays {
+		if overlay.EnvName == "" {
+			return nil, fmt.Errorf("env name of overlay %s is empty", overlay.Path)
+		}
+		if envs[overlay.EnvName] {
+			return nil, fmt.Errorf("duplicated overlay for env %s", overlay.EnvName)
+		}
+		envs[overlay.EnvName] = true
+
+		overlay.Path = cleanKustomizePath(overlay.Path)
+		if !kustomize.IsKustomizationDir(fileMap, overlay.Path) {
+			return nil, fmt.Errorf("kustomization file not found in overlay path: %s", overlay.Path)
+		}
+		if _, err := kube.BuildKustomizeYaml(config, overlay.EnvName); err != nil {
+			return nil, err
+		}
+	}
+	return config, nil
+}
+
+// cleanKustomizePath returns the path relative to the load path, empty means the load path itself
+func cleanKustomizePath(p string) string {
+	return strings.Trim(path.Clean("/"+p), "/")
+}
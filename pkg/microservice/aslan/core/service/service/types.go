@@ -161,6 +161,20 @@ type LoadServiceFromYamlTemplateReq struct {
 	ServiceVariableKVs []*commontypes.ServiceVariableKV `json:"service_variable_kvs"`
 }
 
+type LoadServiceFromKustomizeReq struct {
+	ServiceName string `json:"service_name"`
+	ProjectName string `json:"project_name"`
+	CodehostID  int    `json:"codehost_id"`
+	RepoOwner   string `json:"repo_owner"`
+	Namespace   string `json:"namespace"`
+	RepoName    string `json:"repo_name"`
+	BranchName  string `json:"branch_name"`
+	// LoadPath is the directory in the repository which contains the kustomization base and overlays
+	LoadPath string                           `json:"load_path"`
+	BasePath string                           `json:"base_path"`
+	Overlays []*commonmodels.KustomizeOverlay `json:"overlays"`
+}
+
 type OpenAPILoadServiceFromYamlTemplateReq struct {
 	Production   bool         `json:"production"`
 	ServiceName  string       `json:"service_name"`
@@ -185,5 +185,5 @@ func ListCharts(c *gin.Context) {
 	ctx := internalhandler.NewContext(c)
 	defer func() { internalhandler.JSONResponse(c, ctx) }()
 
-	ctx.Resp, ctx.RespErr = service.ListCharts(c.Param("name"), ctx.Logger)
+	ctx.Resp, ctx.RespErr = service.ListCharts(c.Param("name"), c.Query("projectName"), ctx.Logger)
 }
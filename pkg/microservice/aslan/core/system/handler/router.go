@@ -90,6 +90,13 @@ func (*Router) Inject(router *gin.RouterGroup) {
 		cleanCache.POST("/sharedStorage", CleanSharedStorage)
 	}
 
+	// content addressed caches saved by the cache steps of workflow jobs
+	workflowCache := router.Group("workflowCache")
+	{
+		workflowCache.GET("", ListWorkflowCaches)
+		workflowCache.DELETE("", PurgeWorkflowCaches)
+	}
+
 	// security and privacy settings
 	security := router.Group("security")
 	{
@@ -141,6 +148,8 @@ func (*Router) Inject(router *gin.RouterGroup) {
 	{
 		concurrency.GET("/workflow", GetWorkflowConcurrency)
 		concurrency.POST("/workflow", UpdateWorkflowConcurrency)
+		concurrency.GET("/scheduling", GetWorkflowSchedulingSettings)
+		concurrency.POST("/scheduling", UpdateWorkflowSchedulingSettings)
 	}
 
 	// default login default login home page settings
@@ -310,6 +319,15 @@ func (*Router) Inject(router *gin.RouterGroup) {
 		observability.POST("/validate", ValidateObservability)
 	}
 
+	signingKey := router.Group("signingKeys")
+	{
+		signingKey.GET("", ListImageSigningKeys)
+		signingKey = signingKey.Group("", isSystemAdmin)
+		signingKey.POST("", CreateImageSigningKey)
+		signingKey.PUT("/:id", UpdateImageSigningKey)
+		signingKey.DELETE("/:id", DeleteImageSigningKey)
+	}
+
 	lark := router.Group("lark")
 	{
 		lark.GET("/:id/department/:department_id", GetLarkDepartment)
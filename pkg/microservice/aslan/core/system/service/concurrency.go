@@ -18,9 +18,11 @@ package service
 
 import (
 	"errors"
+	"fmt"
 
 	"go.uber.org/zap"
 
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
 	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
 	workflowservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/workflow/service/workflow"
 )
@@ -51,3 +53,41 @@ func UpdateWorkflowConcurrency(workflowConcurrency, buildConcurrency int64, log
 
 	return nil
 }
+
+func GetWorkflowSchedulingSettings() (*commonmodels.WorkflowSchedulingSettings, error) {
+	configuration, err := commonrepo.NewSystemSettingColl().Get()
+	if err != nil {
+		return nil, err
+	}
+	if configuration.WorkflowScheduling == nil {
+		return &commonmodels.WorkflowSchedulingSettings{
+			Projects: make([]*commonmodels.ProjectSchedulingSetting, 0),
+		}, nil
+	}
+	return configuration.WorkflowScheduling, nil
+}
+
+func UpdateWorkflowSchedulingSettings(scheduling *commonmodels.WorkflowSchedulingSettings, log *zap.SugaredLogger) error {
+	if scheduling.DefaultProjectConcurrency < 0 {
+		return errors.New("default project concurrency cannot be less than 0")
+	}
+	projects := make(map[string]bool)
+	for _, project := range scheduling.Projects {
+		if project.ProjectName == "" {
+			return errors.New("project name cannot be empty")
+		}
+		if projects[project.ProjectName] {
+			return fmt.Errorf("duplicated project: %s", project.ProjectName)
+		}
+		projects[project.ProjectName] = true
+		if project.Concurrency < 0 || project.Weight < 0 {
+			return fmt.Errorf("concurrency and weight of project %s cannot be less than 0", project.ProjectName)
+		}
+	}
+
+	if err := commonrepo.NewSystemSettingColl().UpdateWorkflowSchedulingSetting(scheduling); err != nil {
+		log.Errorf("Failed to update workflow scheduling settings, the error is: %s", err)
+		return err
+	}
+	return nil
+}
@@ -67,8 +67,8 @@ func DeleteHelmRepo(id string, log *zap.SugaredLogger) error {
 	return nil
 }
 
-func ListCharts(name string, log *zap.SugaredLogger) (*IndexFileResp, error) {
-	chartRepo, err := commonrepo.NewHelmRepoColl().Find(&commonrepo.HelmRepoFindOption{RepoName: name})
+func ListCharts(name, projectName string, log *zap.SugaredLogger) (*IndexFileResp, error) {
+	chartRepo, err := commonutil.FindChartRepo(name, projectName)
 	if err != nil {
 		return nil, err
 	}
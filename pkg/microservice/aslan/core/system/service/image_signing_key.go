@@ -0,0 +1,99 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/tool/cosign"
+	e "github.com/koderover/zadig/v2/pkg/tool/errors"
+)
+
+// ListImageSigningKeys lists the signing keys, the private keys are never returned
+func ListImageSigningKeys() ([]*models.ImageSigningKey, error) {
+	resp, err := mongodb.NewImageSigningKeyColl().List(context.Background())
+	if err != nil {
+		return nil, e.ErrListImageSigningKey.AddErr(err)
+	}
+	for _, key := range resp {
+		key.PrivateKey = ""
+		key.Password = ""
+	}
+	return resp, nil
+}
+
+func CreateImageSigningKey(args *models.ImageSigningKey, username string) error {
+	if args.PrivateKey == "" {
+		return e.ErrCreateImageSigningKey.AddErr(errors.New("private key is required"))
+	}
+	if err := validateImageSigningKey(args); err != nil {
+		return e.ErrCreateImageSigningKey.AddErr(err)
+	}
+	args.UpdateBy = username
+	if err := mongodb.NewImageSigningKeyColl().Create(context.Background(), args); err != nil {
+		return e.ErrCreateImageSigningKey.AddErr(err)
+	}
+	return nil
+}
+
+// UpdateImageSigningKey updates the signing key, the private key and its password are kept if not given
+func UpdateImageSigningKey(id string, args *models.ImageSigningKey, username string) error {
+	if err := validateImageSigningKey(args); err != nil {
+		return e.ErrUpdateImageSigningKey.AddErr(err)
+	}
+	if args.PrivateKey == "" {
+		origin, err := mongodb.NewImageSigningKeyColl().GetByID(context.Background(), id)
+		if err != nil {
+			return e.ErrUpdateImageSigningKey.AddErr(err)
+		}
+		args.PrivateKey = origin.PrivateKey
+		args.Password = origin.Password
+	}
+	args.UpdateBy = username
+	if err := mongodb.NewImageSigningKeyColl().Update(context.Background(), id, args); err != nil {
+		return e.ErrUpdateImageSigningKey.AddErr(err)
+	}
+	return nil
+}
+
+func DeleteImageSigningKey(id string) error {
+	envs, err := mongodb.NewProductColl().ListBySigningKey(id)
+	if err != nil {
+		return e.ErrDeleteImageSigningKey.AddErr(err)
+	}
+	if len(envs) > 0 {
+		return e.ErrDeleteImageSigningKey.AddErr(fmt.Errorf("the key is trusted by environment %s/%s", envs[0].ProductName, envs[0].EnvName))
+	}
+	if err := mongodb.NewImageSigningKeyColl().DeleteByID(context.Background(), id); err != nil {
+		return e.ErrDeleteImageSigningKey.AddErr(err)
+	}
+	return nil
+}
+
+func validateImageSigningKey(args *models.ImageSigningKey) error {
+	if args.Name == "" {
+		return errors.New("name is required")
+	}
+	if _, err := cosign.ParsePublicKey(args.PublicKey); err != nil {
+		return err
+	}
+	return nil
+}
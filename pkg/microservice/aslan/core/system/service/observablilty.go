@@ -19,6 +19,7 @@ package service
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
@@ -26,6 +27,7 @@ import (
 	e "github.com/koderover/zadig/v2/pkg/tool/errors"
 	"github.com/koderover/zadig/v2/pkg/tool/grafana"
 	"github.com/koderover/zadig/v2/pkg/tool/guanceyun"
+	"github.com/koderover/zadig/v2/pkg/tool/prometheus"
 )
 
 func ListObservability(_type string, isAdmin bool) ([]*models.Observability, error) {
@@ -37,6 +39,7 @@ func ListObservability(_type string, isAdmin bool) ([]*models.Observability, err
 		for _, v := range resp {
 			v.ApiKey = ""
 			v.GrafanaToken = ""
+			v.PrometheusToken = ""
 		}
 	}
 	return resp, nil
@@ -69,6 +72,8 @@ func ValidateObservability(args *models.Observability) error {
 		return validateGuanceyun(args)
 	case config.ObservabilityTypeGrafana:
 		return validateGrafana(args)
+	case config.ObservabilityTypePrometheus:
+		return validatePrometheus(args)
 	default:
 		return errors.New("invalid observability type")
 	}
@@ -83,3 +88,8 @@ func validateGrafana(args *models.Observability) error {
 	_, err := grafana.NewClient(args.Host, args.GrafanaToken).ListAlertInstance()
 	return err
 }
+
+func validatePrometheus(args *models.Observability) error {
+	_, err := prometheus.NewClient(args.Host, args.PrometheusToken).Query("vector(1)", time.Now())
+	return err
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"fmt"
+	"sort"
+
+	"go.uber.org/zap"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/s3"
+	"github.com/koderover/zadig/v2/pkg/tool/buildcache"
+	e "github.com/koderover/zadig/v2/pkg/tool/errors"
+	s3tool "github.com/koderover/zadig/v2/pkg/tool/s3"
+)
+
+// the max number of objects can be deleted in one request
+const maxDeleteObjects = 1000
+
+func ListWorkflowCaches(projectName string, logger *zap.SugaredLogger) ([]*buildcache.Entry, error) {
+	client, storage, err := getWorkflowCacheStorage()
+	if err != nil {
+		logger.Errorf("failed to get workflow cache storage, err: %s", err)
+		return nil, e.ErrInternalError.AddErr(err)
+	}
+
+	entries, err := buildcache.List(client, storage.Bucket, buildcache.ProjectPrefix(storage.Subfolder, projectName))
+	if err != nil {
+		logger.Errorf("failed to list workflow caches of project %s, err: %s", projectName, err)
+		return nil, e.ErrInternalError.AddErr(err)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].LastModified > entries[j].LastModified
+	})
+	return entries, nil
+}
+
+// PurgeWorkflowCaches deletes the cache entry of the key in the project, or all the entries of the project if the key is empty
+func PurgeWorkflowCaches(projectName, key string, logger *zap.SugaredLogger) error {
+	if key != "" {
+		if err := buildcache.ValidateKey(key); err != nil {
+			return e.ErrInvalidParam.AddErr(err)
+		}
+	}
+	client, storage, err := getWorkflowCacheStorage()
+	if err != nil {
+		logger.Errorf("failed to get workflow cache storage, err: %s", err)
+		return e.ErrInternalError.AddErr(err)
+	}
+
+	entries, err := buildcache.List(client, storage.Bucket, buildcache.ProjectPrefix(storage.Subfolder, projectName))
+	if err != nil {
+		logger.Errorf("failed to list workflow caches of project %s, err: %s", projectName, err)
+		return e.ErrInternalError.AddErr(err)
+	}
+	objectKeys := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if key == "" || entry.Key == key {
+			objectKeys = append(objectKeys, entry.ObjectKey)
+		}
+	}
+	if key != "" && len(objectKeys) == 0 {
+		return e.ErrInvalidParam.AddDesc(fmt.Sprintf("cache %s not found", key))
+	}
+
+	for start := 0; start < len(objectKeys); start += maxDeleteObjects {
+		end := start + maxDeleteObjects
+		if end > len(objectKeys) {
+			end = len(objectKeys)
+		}
+		if err := client.DeleteObjects(storage.Bucket, objectKeys[start:end]); err != nil {
+			logger.Errorf("failed to delete workflow caches of project %s, err: %s", projectName, err)
+			return e.ErrInternalError.AddErr(err)
+		}
+	}
+	return nil
+}
+
+func getWorkflowCacheStorage() (*s3tool.Client, *s3.S3, error) {
+	storage, err := s3.FindDefaultS3()
+	if err != nil {
+		return nil, nil, err
+	}
+	client, err := s3tool.NewClient(storage.Endpoint, storage.Ak, storage.Sk, storage.Region, storage.Insecure, storage.Provider)
+	if err != nil {
+		return nil, nil, err
+	}
+	return client, storage, nil
+}
@@ -41,10 +41,26 @@ type gitEventMatcherForWorkflowV4 interface {
 	GetHookRepo(hookRepo *commonmodels.MainHookRepo) *types.Repository
 }
 
+// changedFilesGetter is implemented by the matchers which collect the changed files of the event in Match,
+// the changed files are passed to the workflow task to be used by the job condition expressions.
+type changedFilesGetter interface {
+	GetChangedFiles() []string
+}
+
+func setHookPayloadChangedFiles(hookPayload *commonmodels.HookPayload, matcher gitEventMatcherForWorkflowV4) {
+	if hookPayload == nil {
+		return
+	}
+	if getter, ok := matcher.(changedFilesGetter); ok {
+		hookPayload.ChangedFiles = getter.GetChangedFiles()
+	}
+}
+
 type githubPushEventMatcheForWorkflowV4 struct {
-	log      *zap.SugaredLogger
-	workflow *commonmodels.WorkflowV4
-	event    *github.PushEvent
+	log          *zap.SugaredLogger
+	workflow     *commonmodels.WorkflowV4
+	event        *github.PushEvent
+	changedFiles []string
 }
 
 func (gpem *githubPushEventMatcheForWorkflowV4) Match(hookRepo *commonmodels.MainHookRepo) (bool, error) {
@@ -76,9 +92,14 @@ func (gpem *githubPushEventMatcheForWorkflowV4) Match(hookRepo *commonmodels.Mai
 		changedFiles = append(changedFiles, commit.Removed...)
 		changedFiles = append(changedFiles, commit.Modified...)
 	}
+	gpem.changedFiles = changedFiles
 	return MatchChanges(hookRepo, changedFiles), nil
 }
 
+func (gpem *githubPushEventMatcheForWorkflowV4) GetChangedFiles() []string {
+	return gpem.changedFiles
+}
+
 func (gpem *githubPushEventMatcheForWorkflowV4) GetHookRepo(hookRepo *commonmodels.MainHookRepo) *types.Repository {
 	return &types.Repository{
 		CodehostID:    hookRepo.CodehostID,
@@ -93,10 +114,11 @@ func (gpem *githubPushEventMatcheForWorkflowV4) GetHookRepo(hookRepo *commonmode
 }
 
 type githubMergeEventMatcherForWorkflowV4 struct {
-	diffFunc githubPullRequestDiffFunc
-	log      *zap.SugaredLogger
-	workflow *commonmodels.WorkflowV4
-	event    *github.PullRequestEvent
+	diffFunc     githubPullRequestDiffFunc
+	log          *zap.SugaredLogger
+	workflow     *commonmodels.WorkflowV4
+	event        *github.PullRequestEvent
+	changedFiles []string
 }
 
 func (gmem *githubMergeEventMatcherForWorkflowV4) Match(hookRepo *commonmodels.MainHookRepo) (bool, error) {
@@ -129,6 +151,7 @@ func (gmem *githubMergeEventMatcherForWorkflowV4) Match(hookRepo *commonmodels.M
 			return false, err
 		}
 		gmem.log.Debugf("succeed to get %d changes in merge event", len(changedFiles))
+		gmem.changedFiles = changedFiles
 
 		return MatchChanges(hookRepo, changedFiles), nil
 	}
@@ -136,6 +159,10 @@ func (gmem *githubMergeEventMatcherForWorkflowV4) Match(hookRepo *commonmodels.M
 	return false, nil
 }
 
+func (gmem *githubMergeEventMatcherForWorkflowV4) GetChangedFiles() []string {
+	return gmem.changedFiles
+}
+
 func (gmem *githubMergeEventMatcherForWorkflowV4) GetHookRepo(hookRepo *commonmodels.MainHookRepo) *types.Repository {
 	return &types.Repository{
 		CodehostID:    hookRepo.CodehostID,
@@ -312,6 +339,7 @@ func TriggerWorkflowV4ByGithubEvent(event interface{}, baseURI, deliveryID, requ
 					EventType: eventType,
 				}
 			}
+			setHookPayloadChangedFiles(hookPayload, matcher)
 			if autoCancelOpt.Type != "" {
 				err := AutoCancelWorkflowV4Task(autoCancelOpt, log)
 				if err != nil {
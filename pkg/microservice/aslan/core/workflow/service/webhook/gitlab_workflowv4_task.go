@@ -47,6 +47,7 @@ type gitlabMergeEventMatcherForWorkflowV4 struct {
 	trigger            *TriggerYaml
 	isYaml             bool
 	yamlServiceChanged []BuildServices
+	changedFiles       []string
 }
 
 func (gmem *gitlabMergeEventMatcherForWorkflowV4) Match(hookRepo *commonmodels.MainHookRepo) (bool, error) {
@@ -90,6 +91,7 @@ func (gmem *gitlabMergeEventMatcherForWorkflowV4) Match(hookRepo *commonmodels.M
 			return false, err
 		}
 		gmem.log.Debugf("succeed to get %d changes in merge event", len(changedFiles))
+		gmem.changedFiles = changedFiles
 		if gmem.isYaml {
 			serviceChangeds := ServicesMatchChangesFiles(gmem.trigger.Rules.MatchFolders, changedFiles)
 			gmem.yamlServiceChanged = serviceChangeds
@@ -100,6 +102,10 @@ func (gmem *gitlabMergeEventMatcherForWorkflowV4) Match(hookRepo *commonmodels.M
 	return false, nil
 }
 
+func (gmem *gitlabMergeEventMatcherForWorkflowV4) GetChangedFiles() []string {
+	return gmem.changedFiles
+}
+
 func (gmem *gitlabMergeEventMatcherForWorkflowV4) GetHookRepo(hookRepo *commonmodels.MainHookRepo) *types.Repository {
 	return &types.Repository{
 		CodehostID:    hookRepo.CodehostID,
@@ -147,6 +153,7 @@ type gitlabPushEventMatcherForWorkflowV4 struct {
 	trigger            *TriggerYaml
 	isYaml             bool
 	yamlServiceChanged []BuildServices
+	changedFiles       []string
 }
 
 func (gpem *gitlabPushEventMatcherForWorkflowV4) Match(hookRepo *commonmodels.MainHookRepo) (bool, error) {
@@ -215,6 +222,7 @@ func (gpem *gitlabPushEventMatcherForWorkflowV4) Match(hookRepo *commonmodels.Ma
 			changedFiles = append(changedFiles, diff.OldPath)
 		}
 	}
+	gpem.changedFiles = changedFiles
 	if gpem.isYaml {
 		serviceChangeds := ServicesMatchChangesFiles(gpem.trigger.Rules.MatchFolders, changedFiles)
 		gpem.yamlServiceChanged = serviceChangeds
@@ -223,6 +231,10 @@ func (gpem *gitlabPushEventMatcherForWorkflowV4) Match(hookRepo *commonmodels.Ma
 	return MatchChanges(hookRepo, changedFiles), nil
 }
 
+func (gpem *gitlabPushEventMatcherForWorkflowV4) GetChangedFiles() []string {
+	return gpem.changedFiles
+}
+
 func (gpem *gitlabPushEventMatcherForWorkflowV4) GetHookRepo(hookRepo *commonmodels.MainHookRepo) *types.Repository {
 	return &types.Repository{
 		CodehostID:    hookRepo.CodehostID,
@@ -400,6 +412,7 @@ func TriggerWorkflowV4ByGitlabEvent(event interface{}, baseURI, requestID string
 					EventType: eventType,
 				}
 			}
+			setHookPayloadChangedFiles(hookPayload, matcher)
 			if autoCancelOpt.Type != "" {
 				err := AutoCancelWorkflowV4Task(autoCancelOpt, log)
 				if err != nil {
@@ -127,6 +127,10 @@ func InitJobCtl(job *commonmodels.Job, workflow *commonmodels.WorkflowV4) (JobCt
 		resp = &GuanceyunCheckJob{job: job, workflow: workflow}
 	case config.JobGrafana:
 		resp = &GrafanaJob{job: job, workflow: workflow}
+	case config.JobMetricCheck:
+		resp = &MetricCheckJob{job: job, workflow: workflow}
+	case config.JobImageScan:
+		resp = &ImageScanJob{job: job, workflow: workflow}
 	case config.JobJenkins:
 		resp = &JenkinsJob{job: job, workflow: workflow}
 	case config.JobSQL:
@@ -141,6 +145,8 @@ func InitJobCtl(job *commonmodels.Job, workflow *commonmodels.WorkflowV4) (JobCt
 		resp = &NotificationJob{job: job, workflow: workflow}
 	case config.JobSAEDeploy:
 		resp = &SAEDeployJob{job: job, workflow: workflow}
+	case config.JobCallWorkflow:
+		resp = &CallWorkflowJob{job: job, workflow: workflow}
 	default:
 		return resp, fmt.Errorf("job type not found %s", job.JobType)
 	}
@@ -427,12 +433,18 @@ func GetWorkflowOutputs(workflow *commonmodels.WorkflowV4, currentJobName string
 			case config.JobZadigDistributeImage:
 				jobCtl := &ImageDistributeJob{job: job, workflow: workflow}
 				resp = append(resp, filter(jobCtl.GetOutPuts(log))...)
+			case config.JobImageScan:
+				jobCtl := &ImageScanJob{job: job, workflow: workflow}
+				resp = append(resp, filter(jobCtl.GetOutPuts(log))...)
 			case config.JobPlugin:
 				jobCtl := &PluginJob{job: job, workflow: workflow}
 				resp = append(resp, filter(jobCtl.GetOutPuts(log))...)
 			case config.JobZadigDeploy:
 				jobCtl := &DeployJob{job: job, workflow: workflow}
 				resp = append(resp, filter(jobCtl.GetOutPuts(log))...)
+			case config.JobCallWorkflow:
+				jobCtl := &CallWorkflowJob{job: job, workflow: workflow}
+				resp = append(resp, filter(jobCtl.GetOutPuts(log))...)
 			}
 		}
 	}
@@ -17,6 +17,7 @@ limitations under the License.
 package job
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"path"
@@ -37,6 +38,7 @@ import (
 	"github.com/koderover/zadig/v2/pkg/setting"
 	internalhandler "github.com/koderover/zadig/v2/pkg/shared/handler"
 	"github.com/koderover/zadig/v2/pkg/tool/log"
+	"github.com/koderover/zadig/v2/pkg/tool/sbom"
 	"github.com/koderover/zadig/v2/pkg/types"
 	"github.com/koderover/zadig/v2/pkg/types/job"
 	"github.com/koderover/zadig/v2/pkg/types/step"
@@ -52,6 +54,9 @@ const (
 	COMMITIDKEY = "COMMITID"
 )
 
+// ImageSigner is the tool used to sign the built images
+const ImageSigner = "cosign"
+
 type BuildJob struct {
 	job      *commonmodels.Job
 	workflow *commonmodels.WorkflowV4
@@ -377,6 +382,8 @@ func (j *BuildJob) UpdateWithLatestSetting() error {
 	}
 
 	j.spec.DockerRegistryID = latestSpec.DockerRegistryID
+	j.spec.SBOM = latestSpec.SBOM
+	j.spec.Signing = latestSpec.Signing
 	j.spec.ServiceAndBuilds = mergedServiceAndBuilds
 	j.job.Spec = j.spec
 	return nil
@@ -423,8 +430,18 @@ func (j *BuildJob) ToJobs(taskID int64) ([]*commonmodels.JobTask, error) {
 	}
 
 	buildSvc := commonservice.NewBuildService()
-	for jobSubTaskID, build := range j.spec.ServiceAndBuilds {
+	targets, err := expandJobMatrixTargets(len(j.spec.ServiceAndBuilds), j.spec.Matrix)
+	if err != nil {
+		return resp, fmt.Errorf("build job %s: %v", j.job.Name, err)
+	}
+	for jobSubTaskID, target := range targets {
+		build := j.spec.ServiceAndBuilds[target.index]
+		// the job tasks of the matrix combinations push their own images and outputs
+		matrixSuffix := jobMatrixSuffix(target.values)
 		imageTag := commonservice.ReleaseCandidate(build.Repos, taskID, j.workflow.Project, build.ServiceModule, "", build.ImageName, "image")
+		if len(matrixSuffix) > 0 {
+			imageTag = fmt.Sprintf("%s-%s", imageTag, strings.Join(matrixSuffix, "-"))
+		}
 
 		image := fmt.Sprintf("%s/%s", registry.RegAddr, imageTag)
 		if len(registry.Namespace) > 0 {
@@ -434,7 +451,11 @@ func (j *BuildJob) ToJobs(taskID int64) ([]*commonmodels.JobTask, error) {
 		image = strings.TrimPrefix(image, "http://")
 		image = strings.TrimPrefix(image, "https://")
 
-		pkgFile := fmt.Sprintf("%s.tar.gz", commonservice.ReleaseCandidate(build.Repos, taskID, j.workflow.Project, build.ServiceModule, "", build.ImageName, "tar"))
+		pkgName := commonservice.ReleaseCandidate(build.Repos, taskID, j.workflow.Project, build.ServiceModule, "", build.ImageName, "tar")
+		if len(matrixSuffix) > 0 {
+			pkgName = fmt.Sprintf("%s-%s", pkgName, strings.Join(matrixSuffix, "-"))
+		}
+		pkgFile := fmt.Sprintf("%s.tar.gz", pkgName)
 
 		buildInfo, err := buildSvc.GetBuild(build.BuildName, build.ServiceName, build.ServiceModule)
 		if err != nil {
@@ -456,7 +477,7 @@ func (j *BuildJob) ToJobs(taskID int64) ([]*commonmodels.JobTask, error) {
 				"service_module": build.ServiceModule,
 				JobNameKey:       j.job.Name,
 			},
-			Key:            genJobKey(j.job.Name, build.ServiceName, build.ServiceModule),
+			Key:            genJobKey(j.job.Name, append([]string{build.ServiceName, build.ServiceModule}, matrixSuffix...)...),
 			Name:           GenJobName(j.workflow, j.job.Name, jobSubTaskID),
 			DisplayName:    genJobDisplayName(j.job.Name, build.ServiceName, build.ServiceModule),
 			OriginName:     j.job.Name,
@@ -538,6 +559,23 @@ func (j *BuildJob) ToJobs(taskID int64) ([]*commonmodels.JobTask, error) {
 				Version: tool.Version,
 			})
 		}
+		generateSBOM := j.spec.SBOM != nil && j.spec.SBOM.Enabled && buildInfo.PostBuild != nil && buildInfo.PostBuild.DockerBuild != nil
+		if generateSBOM && j.spec.SBOM.GeneratorVersion != "" {
+			tools = append(tools, &step.Tool{
+				Name:    ImageScanner,
+				Version: j.spec.SBOM.GeneratorVersion,
+			})
+		}
+		signImage := j.spec.Signing != nil && j.spec.Signing.Enabled && buildInfo.PostBuild != nil && buildInfo.PostBuild.DockerBuild != nil
+		if signImage && j.spec.Signing.SignerVersion != "" {
+			tools = append(tools, &step.Tool{
+				Name:    ImageSigner,
+				Version: j.spec.Signing.SignerVersion,
+			})
+		}
+		if (generateSBOM || signImage) && jobTask.Infrastructure == setting.JobVMInfrastructure {
+			return resp, fmt.Errorf("build %s runs on vm infrastructure, which does not support image signing or sbom generation", build.BuildName)
+		}
 		toolInstallStep := &commonmodels.StepTask{
 			Name:     fmt.Sprintf("%s-%s", build.ServiceName, "tool-install"),
 			JobName:  jobTask.Name,
@@ -676,6 +714,54 @@ func (j *BuildJob) ToJobs(taskID int64) ([]*commonmodels.JobTask, error) {
 			jobTaskSpec.Steps = append(jobTaskSpec.Steps, dockerBuildStep)
 		}
 
+		// init image sign step
+		if signImage {
+			imageSignStep := &commonmodels.StepTask{
+				Name:     build.ServiceName + "-image-sign",
+				JobName:  jobTask.Name,
+				StepType: config.StepImageSign,
+				Spec: step.StepImageSignSpec{
+					Image:        image,
+					SigningKeyID: j.spec.Signing.SigningKeyID,
+					DockerRegistry: &step.DockerRegistry{
+						DockerRegistryID: j.spec.DockerRegistryID,
+						Host:             registry.RegAddr,
+						UserName:         registry.AccessKey,
+						Password:         registry.SecretKey,
+						Namespace:        registry.Namespace,
+					},
+				},
+			}
+			jobTaskSpec.Steps = append(jobTaskSpec.Steps, imageSignStep)
+		}
+
+		// init sbom step
+		if generateSBOM {
+			sbomS3 := modelS3toS3(defaultS3)
+			sbomStep := &commonmodels.StepTask{
+				Name:     build.ServiceName + "-sbom",
+				JobName:  jobTask.Name,
+				StepType: config.StepSBOM,
+				Spec: step.StepSBOMSpec{
+					ServiceName:   build.ServiceName,
+					ServiceModule: build.ServiceModule,
+					Image:         image,
+					Format:        j.spec.SBOM.Format,
+					DockerRegistry: &step.DockerRegistry{
+						DockerRegistryID: j.spec.DockerRegistryID,
+						Host:             registry.RegAddr,
+						UserName:         registry.AccessKey,
+						Password:         registry.SecretKey,
+						Namespace:        registry.Namespace,
+					},
+					ObjectStorageID: defaultS3.ID.Hex(),
+					S3:              sbomS3,
+					ObjectKey:       strings.TrimLeft(path.Join(sbomS3.Subfolder, j.workflow.Name, fmt.Sprint(taskID), jobTask.Name, "sbom", sbom.FileName(j.spec.SBOM.Format)), "/"),
+				},
+			}
+			jobTaskSpec.Steps = append(jobTaskSpec.Steps, sbomStep)
+		}
+
 		// init object cache step
 		if jobTaskSpec.Properties.CacheEnable && jobTaskSpec.Properties.Cache.MediumType == types.ObjectMedium {
 			cacheDir := "/workspace"
@@ -768,6 +854,7 @@ func (j *BuildJob) ToJobs(taskID int64) ([]*commonmodels.JobTask, error) {
 			}
 			jobTaskSpec.Steps = append(jobTaskSpec.Steps, shellStep)
 		}
+		setJobTaskMatrix(jobTask, target.values, j.spec.Matrix)
 		resp = append(resp, jobTask)
 	}
 	j.job.Spec = j.spec
@@ -996,6 +1083,32 @@ func (j *BuildJob) LintJob() error {
 	if err := commonmodels.IToiYaml(j.job.Spec, j.spec); err != nil {
 		return err
 	}
+	if err := lintJobMatrix(j.spec.Matrix); err != nil {
+		return fmt.Errorf("build job %s: %v", j.job.Name, err)
+	}
+	if j.spec.SBOM != nil && j.spec.SBOM.Enabled && !sbom.IsValidFormat(j.spec.SBOM.Format) {
+		return fmt.Errorf("build job %s: unsupported sbom format: %s", j.job.Name, j.spec.SBOM.Format)
+	}
+	if j.spec.Signing != nil && j.spec.Signing.Enabled {
+		if _, err := commonrepo.NewImageSigningKeyColl().GetByID(context.Background(), j.spec.Signing.SigningKeyID); err != nil {
+			return fmt.Errorf("build job %s: image signing key %s not found: %v", j.job.Name, j.spec.Signing.SigningKeyID, err)
+		}
+	}
+	if (j.spec.SBOM != nil && j.spec.SBOM.Enabled) || (j.spec.Signing != nil && j.spec.Signing.Enabled) {
+		// the vm agent runs neither the image sign step nor the sbom step
+		builds := make([]*commonmodels.ServiceAndBuild, 0, len(j.spec.DefaultServiceAndBuilds)+len(j.spec.ServiceAndBuilds))
+		builds = append(builds, j.spec.DefaultServiceAndBuilds...)
+		builds = append(builds, j.spec.ServiceAndBuilds...)
+		for _, build := range builds {
+			buildInfo, err := commonrepo.NewBuildColl().Find(&commonrepo.BuildFindOption{Name: build.BuildName})
+			if err != nil {
+				return fmt.Errorf("build job %s: find build %s error: %v", j.job.Name, build.BuildName, err)
+			}
+			if buildInfo.Infrastructure == setting.JobVMInfrastructure {
+				return fmt.Errorf("build job %s: build %s runs on vm infrastructure, which does not support image signing or sbom generation", j.job.Name, build.BuildName)
+			}
+		}
+	}
 
 	return nil
 }
@@ -1009,14 +1122,20 @@ func (j *BuildJob) GetOutPuts(log *zap.SugaredLogger) []string {
 
 	outputKeys := sets.NewString()
 	for _, build := range j.spec.ServiceAndBuilds {
-		jobKey := strings.Join([]string{j.job.Name, build.ServiceName, build.ServiceModule}, ".")
+		jobKeys, err := matrixJobKeys(genJobKey(j.job.Name, build.ServiceName, build.ServiceModule), j.spec.Matrix)
+		if err != nil {
+			log.Errorf("failed to expand the matrix of job %s, err: %s", j.job.Name, err)
+			return resp
+		}
 		buildInfo, err := commonrepo.NewBuildColl().Find(&commonrepo.BuildFindOption{Name: build.BuildName})
 		if err != nil {
 			log.Errorf("found build %s failed, err: %s", build.BuildName, err)
 			continue
 		}
 		if buildInfo.TemplateID == "" {
-			resp = append(resp, getOutputKey(jobKey, ensureBuildInOutputs(buildInfo.Outputs))...)
+			for _, jobKey := range jobKeys {
+				resp = append(resp, getOutputKey(jobKey, ensureBuildInOutputs(buildInfo.Outputs))...)
+			}
 			for _, output := range ensureBuildInOutputs(buildInfo.Outputs) {
 				outputKeys = outputKeys.Insert(output.Name)
 			}
@@ -1028,7 +1147,9 @@ func (j *BuildJob) GetOutPuts(log *zap.SugaredLogger) []string {
 			log.Errorf("found build template %s failed, err: %s", buildInfo.TemplateID, err)
 			continue
 		}
-		resp = append(resp, getOutputKey(jobKey, ensureBuildInOutputs(buildTemplate.Outputs))...)
+		for _, jobKey := range jobKeys {
+			resp = append(resp, getOutputKey(jobKey, ensureBuildInOutputs(buildTemplate.Outputs))...)
+		}
 		for _, output := range ensureBuildInOutputs(buildTemplate.Outputs) {
 			outputKeys = outputKeys.Insert(output.Name)
 		}
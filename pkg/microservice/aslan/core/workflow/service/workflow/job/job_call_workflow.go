@@ -0,0 +1,296 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+)
+
+// the max depth of nested workflow calls, it also stops the call loop check from going too deep
+const maxCallWorkflowDepth = 5
+
+type CallWorkflowJob struct {
+	job      *commonmodels.Job
+	workflow *commonmodels.WorkflowV4
+	spec     *commonmodels.CallWorkflowJobSpec
+}
+
+func (j *CallWorkflowJob) Instantiate() error {
+	j.spec = &commonmodels.CallWorkflowJobSpec{}
+	if err := commonmodels.IToiYaml(j.job.Spec, j.spec); err != nil {
+		return err
+	}
+	j.job.Spec = j.spec
+	return nil
+}
+
+// SetPreset fills the inputs with the params of the called workflow so that the user can see all of them
+func (j *CallWorkflowJob) SetPreset() error {
+	j.spec = &commonmodels.CallWorkflowJobSpec{}
+	if err := commonmodels.IToi(j.job.Spec, j.spec); err != nil {
+		return err
+	}
+
+	callee, err := mongodb.NewWorkflowV4Coll().Find(j.spec.WorkflowName)
+	if err != nil {
+		return fmt.Errorf("failed to find called workflow %s, error: %v", j.spec.WorkflowName, err)
+	}
+	inputs, err := mergeCallWorkflowInputs(callee.Params, j.spec.Inputs)
+	if err != nil {
+		return err
+	}
+	j.spec.Inputs = inputs
+	j.spec.Outputs = callee.Outputs
+
+	j.job.Spec = j.spec
+	return nil
+}
+
+func (j *CallWorkflowJob) SetOptions(approvalTicket *commonmodels.ApprovalTicket) error {
+	return nil
+}
+
+func (j *CallWorkflowJob) ClearOptions() error {
+	return nil
+}
+
+func (j *CallWorkflowJob) ClearSelectionField() error {
+	return nil
+}
+
+func (j *CallWorkflowJob) MergeArgs(args *commonmodels.Job) error {
+	if j.job.Name == args.Name && j.job.JobType == args.JobType {
+		j.spec = &commonmodels.CallWorkflowJobSpec{}
+		if err := commonmodels.IToi(j.job.Spec, j.spec); err != nil {
+			return err
+		}
+
+		argsSpec := &commonmodels.CallWorkflowJobSpec{}
+		if err := commonmodels.IToi(args.Spec, argsSpec); err != nil {
+			return err
+		}
+		// only the input values can be changed when running the workflow, they are checked when creating the job task
+		j.spec.Inputs = argsSpec.Inputs
+		j.job.Spec = j.spec
+	}
+	return nil
+}
+
+func (j *CallWorkflowJob) UpdateWithLatestSetting() error {
+	return nil
+}
+
+func (j *CallWorkflowJob) ToJobs(taskID int64) ([]*commonmodels.JobTask, error) {
+	resp := []*commonmodels.JobTask{}
+	j.spec = &commonmodels.CallWorkflowJobSpec{}
+	if err := commonmodels.IToi(j.job.Spec, j.spec); err != nil {
+		return resp, err
+	}
+	j.job.Spec = j.spec
+
+	callee, err := mongodb.NewWorkflowV4Coll().Find(j.spec.WorkflowName)
+	if err != nil {
+		return resp, fmt.Errorf("failed to find called workflow %s, error: %v", j.spec.WorkflowName, err)
+	}
+	if callee.Project != j.spec.ProjectName {
+		return resp, fmt.Errorf("workflow %s does not belong to project %s", callee.Name, j.spec.ProjectName)
+	}
+	if callee.Disabled {
+		return resp, fmt.Errorf("called workflow %s is disabled", callee.Name)
+	}
+	inputs, err := mergeCallWorkflowInputs(callee.Params, j.spec.Inputs)
+	if err != nil {
+		return resp, fmt.Errorf("invalid inputs for workflow %s: %v", callee.Name, err)
+	}
+
+	outputs := make([]*commonmodels.Output, 0, len(callee.Outputs))
+	for _, output := range callee.Outputs {
+		outputs = append(outputs, &commonmodels.Output{Name: output.Name, Description: output.Description})
+	}
+
+	jobTask := &commonmodels.JobTask{
+		Name:        GenJobName(j.workflow, j.job.Name, 0),
+		Key:         genJobKey(j.job.Name),
+		DisplayName: genJobDisplayName(j.job.Name),
+		OriginName:  j.job.Name,
+		JobInfo: map[string]string{
+			JobNameKey: j.job.Name,
+		},
+		JobType: string(config.JobCallWorkflow),
+		Spec: &commonmodels.JobTaskCallWorkflowSpec{
+			ProjectName:         callee.Project,
+			WorkflowName:        callee.Name,
+			WorkflowDisplayName: callee.DisplayName,
+			Inputs:              inputs,
+		},
+		Outputs:     outputs,
+		ErrorPolicy: j.job.ErrorPolicy,
+	}
+	return []*commonmodels.JobTask{jobTask}, nil
+}
+
+func (j *CallWorkflowJob) LintJob() error {
+	j.spec = &commonmodels.CallWorkflowJobSpec{}
+	if err := commonmodels.IToiYaml(j.job.Spec, j.spec); err != nil {
+		return err
+	}
+
+	if j.spec.WorkflowName == "" {
+		return fmt.Errorf("called workflow is not set")
+	}
+	if j.spec.WorkflowName == j.workflow.Name {
+		return fmt.Errorf("workflow %s can not call itself", j.workflow.Name)
+	}
+	callee, err := mongodb.NewWorkflowV4Coll().Find(j.spec.WorkflowName)
+	if err != nil {
+		return fmt.Errorf("failed to find called workflow %s, error: %v", j.spec.WorkflowName, err)
+	}
+	if callee.Project != j.spec.ProjectName {
+		return fmt.Errorf("workflow %s does not belong to project %s", callee.Name, j.spec.ProjectName)
+	}
+	if _, err := mergeCallWorkflowInputs(callee.Params, j.spec.Inputs); err != nil {
+		return fmt.Errorf("invalid inputs for workflow %s: %v", callee.Name, err)
+	}
+	return checkCallWorkflowLoop(callee, []string{j.workflow.Name, callee.Name})
+}
+
+func (j *CallWorkflowJob) GetOutPuts(log *zap.SugaredLogger) []string {
+	j.spec = &commonmodels.CallWorkflowJobSpec{}
+	if err := commonmodels.IToiYaml(j.job.Spec, j.spec); err != nil {
+		return []string{}
+	}
+	callee, err := mongodb.NewWorkflowV4Coll().Find(j.spec.WorkflowName)
+	if err != nil {
+		log.Errorf("failed to find called workflow %s, error: %v", j.spec.WorkflowName, err)
+		return []string{}
+	}
+	outputs := make([]*commonmodels.Output, 0, len(callee.Outputs))
+	for _, output := range callee.Outputs {
+		outputs = append(outputs, &commonmodels.Output{Name: output.Name})
+	}
+	return getOutputKey(j.job.Name, outputs)
+}
+
+func checkCallWorkflowLoop(workflow *commonmodels.WorkflowV4, callChain []string) error {
+	if len(callChain) > maxCallWorkflowDepth {
+		return fmt.Errorf("workflow call depth can not exceed %d: %s", maxCallWorkflowDepth, strings.Join(callChain, " -> "))
+	}
+	checkedWorkflow := sets.NewString()
+	for _, stage := range workflow.Stages {
+		for _, job := range stage.Jobs {
+			if job.JobType != config.JobCallWorkflow {
+				continue
+			}
+			spec := &commonmodels.CallWorkflowJobSpec{}
+			if err := commonmodels.IToi(job.Spec, spec); err != nil {
+				return err
+			}
+			if checkedWorkflow.Has(spec.WorkflowName) {
+				continue
+			}
+			checkedWorkflow.Insert(spec.WorkflowName)
+
+			if sets.NewString(callChain...).Has(spec.WorkflowName) {
+				return fmt.Errorf("workflow can not be called in a loop: %s -> %s", strings.Join(callChain, " -> "), spec.WorkflowName)
+			}
+			callee, err := mongodb.NewWorkflowV4Coll().Find(spec.WorkflowName)
+			if err != nil {
+				return fmt.Errorf("failed to find called workflow %s, error: %v", spec.WorkflowName, err)
+			}
+			if err := checkCallWorkflowLoop(callee, append(callChain[:len(callChain):len(callChain)], callee.Name)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// mergeCallWorkflowInputs returns a copy of params with the values set by inputs.
+// an input must match a param by name and type, and the value of a choice input must be one of the options.
+// values referring to variables ({{.xxx}}) are rendered when the job runs, so they are not checked here.
+func mergeCallWorkflowInputs(params, inputs []*commonmodels.Param) ([]*commonmodels.Param, error) {
+	inputMap := make(map[string]*commonmodels.Param, len(inputs))
+	for _, input := range inputs {
+		inputMap[input.Name] = input
+	}
+
+	resp := make([]*commonmodels.Param, 0, len(params))
+	for _, param := range params {
+		newParam := *param
+		input, ok := inputMap[param.Name]
+		if ok {
+			delete(inputMap, param.Name)
+			if input.ParamsType != "" && input.ParamsType != param.ParamsType {
+				return nil, fmt.Errorf("input %s should be of type %s, got %s", input.Name, param.ParamsType, input.ParamsType)
+			}
+			newParam.Value = input.Value
+			newParam.ChoiceValue = input.ChoiceValue
+			if input.Repo != nil {
+				newParam.Repo = input.Repo
+			}
+		}
+
+		switch commonmodels.ParameterSettingType(newParam.ParamsType) {
+		case commonmodels.ChoiceType:
+			if newParam.Value != "" && !isVariableValue(newParam.Value) && !sets.NewString(newParam.ChoiceOption...).Has(newParam.Value) {
+				return nil, fmt.Errorf("input %s: %s is not a valid option", newParam.Name, newParam.Value)
+			}
+		case commonmodels.MultiSelectType:
+			for _, value := range newParam.ChoiceValue {
+				if !isVariableValue(value) && !sets.NewString(newParam.ChoiceOption...).Has(value) {
+					return nil, fmt.Errorf("input %s: %s is not a valid option", newParam.Name, value)
+				}
+			}
+		}
+		resp = append(resp, &newParam)
+	}
+
+	for name := range inputMap {
+		return nil, fmt.Errorf("input %s is not declared in the params of the called workflow", name)
+	}
+	return resp, nil
+}
+
+func isVariableValue(value string) bool {
+	return strings.Contains(value, "{{.")
+}
+
+// LintWorkflowOutputs checks the outputs declared by the workflow for the workflows calling it.
+func LintWorkflowOutputs(workflow *commonmodels.WorkflowV4) error {
+	names := sets.NewString()
+	for _, output := range workflow.Outputs {
+		if !OutputNameRegex.MatchString(output.Name) {
+			return fmt.Errorf("workflow output name %s does not match %s", output.Name, OutputNameRegexString)
+		}
+		if names.Has(output.Name) {
+			return fmt.Errorf("duplicated workflow output name: %s", output.Name)
+		}
+		names.Insert(output.Name)
+		if output.Value == "" {
+			return fmt.Errorf("workflow output %s has no value", output.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+)
+
+func TestMergeCallWorkflowInputs(t *testing.T) {
+	params := []*commonmodels.Param{
+		{Name: "version", ParamsType: string(commonmodels.StringType), Value: "v1"},
+		{Name: "env", ParamsType: string(commonmodels.ChoiceType), Value: "dev", ChoiceOption: []string{"dev", "prod"}},
+	}
+
+	inputs, err := mergeCallWorkflowInputs(params, []*commonmodels.Param{
+		{Name: "env", ParamsType: string(commonmodels.ChoiceType), Value: "prod"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "v1", inputs[0].Value)
+	assert.Equal(t, "prod", inputs[1].Value)
+	assert.Equal(t, "dev", params[1].Value)
+
+	_, err = mergeCallWorkflowInputs(params, []*commonmodels.Param{{Name: "env", Value: "{{.workflow.params.env}}"}})
+	assert.NoError(t, err)
+
+	_, err = mergeCallWorkflowInputs(params, []*commonmodels.Param{{Name: "env", Value: "staging"}})
+	assert.Error(t, err)
+
+	_, err = mergeCallWorkflowInputs(params, []*commonmodels.Param{{Name: "version", ParamsType: string(commonmodels.ChoiceType)}})
+	assert.Error(t, err)
+
+	_, err = mergeCallWorkflowInputs(params, []*commonmodels.Param{{Name: "unknown", Value: "x"}})
+	assert.Error(t, err)
+}
@@ -1168,7 +1168,7 @@ func (j *DeployJob) LintJob() error {
 	if !ok || buildJobRank >= jobRankMap[j.job.Name] {
 		return fmt.Errorf("can not quote job %s in job %s", j.spec.JobName, j.job.Name)
 	}
-	return nil
+	return lintQuotedMatrixJob(j.workflow, j.spec.JobName)
 }
 
 func (j *DeployJob) GetOutPuts(log *zap.SugaredLogger) []string {
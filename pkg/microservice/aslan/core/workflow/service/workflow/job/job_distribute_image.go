@@ -360,7 +360,7 @@ func (j *ImageDistributeJob) LintJob() error {
 	if !ok || buildJobRank >= jobRankMap[j.job.Name] {
 		return fmt.Errorf("can not quote job %s in job %s", j.spec.JobName, j.job.Name)
 	}
-	return nil
+	return lintQuotedMatrixJob(j.workflow, j.spec.JobName)
 }
 
 func getQuoteBuildJobSpec(jobName string, workflow *commonmodels.WorkflowV4) (*commonmodels.ZadigBuildJobSpec, error) {
@@ -33,6 +33,7 @@ import (
 	commonutil "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/util"
 	codehostrepo "github.com/koderover/zadig/v2/pkg/microservice/systemconfig/core/codehost/repository/mongodb"
 	internalhandler "github.com/koderover/zadig/v2/pkg/shared/handler"
+	"github.com/koderover/zadig/v2/pkg/tool/buildcache"
 	e "github.com/koderover/zadig/v2/pkg/tool/errors"
 	"github.com/koderover/zadig/v2/pkg/tool/log"
 	"github.com/koderover/zadig/v2/pkg/types"
@@ -99,6 +100,18 @@ func (j *FreeStyleJob) Instantiate() error {
 				return fmt.Errorf("parse archive step spec error: %v", err)
 			}
 			step.Spec = stepSpec
+		case config.StepCacheRestore:
+			stepSpec := &steptypes.StepCacheRestoreSpec{}
+			if err := commonmodels.IToiYaml(step.Spec, stepSpec); err != nil {
+				return fmt.Errorf("parse cache restore step spec error: %v", err)
+			}
+			step.Spec = stepSpec
+		case config.StepCacheSave:
+			stepSpec := &steptypes.StepCacheSaveSpec{}
+			if err := commonmodels.IToiYaml(step.Spec, stepSpec); err != nil {
+				return fmt.Errorf("parse cache save step spec error: %v", err)
+			}
+			step.Spec = stepSpec
 		default:
 			return fmt.Errorf("freestyle job step type %s not supported", step.StepType)
 		}
@@ -364,28 +377,49 @@ func (j *FreeStyleJob) ToJobs(taskID int64) ([]*commonmodels.JobTask, error) {
 			j.spec.Services = targets
 		}
 
-		for jobSubTaskID, service := range j.spec.Services {
-			task, err := j.toJob(taskID, jobSubTaskID, registries, service, logger)
+		targets, err := expandJobMatrixTargets(len(j.spec.Services), j.spec.Matrix)
+		if err != nil {
+			return nil, fmt.Errorf("job %s: %v", j.job.Name, err)
+		}
+		for jobSubTaskID, target := range targets {
+			task, err := j.toJob(taskID, jobSubTaskID, registries, j.spec.Services[target.index], logger)
 			if err != nil {
 				return nil, err
 			}
+			setJobTaskMatrix(task, target.values, j.spec.Matrix)
+			// the job tasks of the matrix combinations set their own outputs
+			task.Key = genJobKey(task.Key, jobMatrixSuffix(target.values)...)
 			tasks = append(tasks, task)
 		}
 		return tasks, nil
 	} else {
-		// save user defined variables.
-		jobTask, err := j.toJob(taskID, 0, registries, nil, logger)
+		targets, err := expandJobMatrixTargets(1, j.spec.Matrix)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("job %s: %v", j.job.Name, err)
 		}
-		return []*commonmodels.JobTask{jobTask}, nil
+		tasks := []*commonmodels.JobTask{}
+		for jobSubTaskID, target := range targets {
+			// save user defined variables.
+			jobTask, err := j.toJob(taskID, jobSubTaskID, registries, nil, logger)
+			if err != nil {
+				return nil, err
+			}
+			setJobTaskMatrix(jobTask, target.values, j.spec.Matrix)
+			jobTask.Key = genJobKey(jobTask.Key, jobMatrixSuffix(target.values)...)
+			tasks = append(tasks, jobTask)
+		}
+		return tasks, nil
 	}
 }
 
 func (j *FreeStyleJob) toJob(taskID int64, jobSubTaskID int, registries []*commonmodels.RegistryNamespace, service *commonmodels.FreeStyleServiceInfo, logger *zap.SugaredLogger) (*commonmodels.JobTask, error) {
+	steps, err := j.stepsToStepTasks(j.spec.Steps, service, registries)
+	if err != nil {
+		return nil, fmt.Errorf("job %s: %v", j.job.Name, err)
+	}
 	jobTaskSpec := &commonmodels.JobTaskFreestyleSpec{
 		Properties: *j.spec.Properties,
-		Steps:      j.stepsToStepTasks(j.spec.Steps, service, registries),
+		Steps:      steps,
 	}
 
 	jobDisplayName := genJobDisplayName(j.job.Name)
@@ -450,7 +484,7 @@ func (j *FreeStyleJob) toJob(taskID int64, jobSubTaskID int, registries []*commo
 	return jobTask, nil
 }
 
-func (j *FreeStyleJob) stepsToStepTasks(step []*commonmodels.Step, service *commonmodels.FreeStyleServiceInfo, registries []*commonmodels.RegistryNamespace) []*commonmodels.StepTask {
+func (j *FreeStyleJob) stepsToStepTasks(step []*commonmodels.Step, service *commonmodels.FreeStyleServiceInfo, registries []*commonmodels.RegistryNamespace) ([]*commonmodels.StepTask, error) {
 	logger := log.SugaredLogger()
 	resp := []*commonmodels.StepTask{}
 	for _, step := range step {
@@ -580,6 +614,32 @@ func (j *FreeStyleJob) stepsToStepTasks(step []*commonmodels.Step, service *comm
 			}
 			stepTask.Spec = stepTaskSpec
 		}
+		if stepTask.StepType == config.StepCacheRestore {
+			stepTaskSpec := &steptypes.StepCacheRestoreSpec{}
+			if err := commonmodels.IToi(stepTask.Spec, stepTaskSpec); err != nil {
+				continue
+			}
+			defaultS3, err := commonrepo.NewS3StorageColl().FindDefault()
+			if err != nil {
+				return nil, fmt.Errorf("no default object storage for the cache step %s, error: %v", stepTask.Name, err)
+			}
+			stepTaskSpec.ProjectName = j.workflow.Project
+			stepTaskSpec.S3 = modelS3toS3(defaultS3)
+			stepTask.Spec = stepTaskSpec
+		}
+		if stepTask.StepType == config.StepCacheSave {
+			stepTaskSpec := &steptypes.StepCacheSaveSpec{}
+			if err := commonmodels.IToi(stepTask.Spec, stepTaskSpec); err != nil {
+				continue
+			}
+			defaultS3, err := commonrepo.NewS3StorageColl().FindDefault()
+			if err != nil {
+				return nil, fmt.Errorf("no default object storage for the cache step %s, error: %v", stepTask.Name, err)
+			}
+			stepTaskSpec.ProjectName = j.workflow.Project
+			stepTaskSpec.S3 = modelS3toS3(defaultS3)
+			stepTask.Spec = stepTaskSpec
+		}
 		if stepTask.StepType == config.StepShell {
 			stepTaskSpec := &steptypes.StepShellSpec{}
 			if err := commonmodels.IToi(stepTask.Spec, stepTaskSpec); err != nil {
@@ -605,7 +665,7 @@ func (j *FreeStyleJob) stepsToStepTasks(step []*commonmodels.Step, service *comm
 			resp = append(resp, debugAfterStep)
 		}
 	}
-	return resp
+	return resp, nil
 }
 
 func getfreestyleJobVariables(steps []*commonmodels.StepTask, taskID int64, project, workflowName, workflowDisplayName, infrastructure string, serviceAndImage *commonmodels.FreeStyleServiceInfo, registries []*commonmodels.RegistryNamespace) []*commonmodels.KeyVal {
@@ -656,6 +716,12 @@ func (j *FreeStyleJob) LintJob() error {
 	if err := commonmodels.IToiYaml(j.job.Spec, j.spec); err != nil {
 		return err
 	}
+	if err := lintJobMatrix(j.spec.Matrix); err != nil {
+		return fmt.Errorf("job %s: %v", j.job.Name, err)
+	}
+	if err := lintCacheSteps(j.spec.Steps); err != nil {
+		return fmt.Errorf("job %s: %v", j.job.Name, err)
+	}
 
 	// calculate all the referenced keys for frontend
 	for _, kv := range j.spec.Properties.Envs {
@@ -682,10 +748,21 @@ func (j *FreeStyleJob) GetOutPuts(log *zap.SugaredLogger) []string {
 		return resp
 	}
 
-	if j.spec.FreestyleJobType == config.ServiceFreeStyleJobType {
-		resp = append(resp, getOutputKey(j.job.Name+".<SERVICE>.<MODULE>", j.spec.Outputs)...)
-	} else if j.spec.FreestyleJobType == config.NormalFreeStyleJobType {
-		jobKey := j.job.Name
+	var jobKey string
+	switch j.spec.FreestyleJobType {
+	case config.ServiceFreeStyleJobType:
+		jobKey = j.job.Name + ".<SERVICE>.<MODULE>"
+	case config.NormalFreeStyleJobType:
+		jobKey = j.job.Name
+	default:
+		return resp
+	}
+	jobKeys, err := matrixJobKeys(jobKey, j.spec.Matrix)
+	if err != nil {
+		log.Errorf("failed to expand the matrix of job %s, err: %s", j.job.Name, err)
+		return resp
+	}
+	for _, jobKey := range jobKeys {
 		resp = append(resp, getOutputKey(jobKey, j.spec.Outputs)...)
 	}
 	return resp
@@ -942,3 +1019,38 @@ func (j *FreeStyleJob) getOriginReferedJobTargets(jobName string) ([]*commonmode
 	}
 	return nil, fmt.Errorf("FreeStyleJob: refered job %s not found", jobName)
 }
+
+func lintCacheSteps(steps []*commonmodels.Step) error {
+	for _, step := range steps {
+		var key string
+		var paths []string
+		switch step.StepType {
+		case config.StepCacheRestore:
+			stepSpec := &steptypes.StepCacheRestoreSpec{}
+			if err := commonmodels.IToiYaml(step.Spec, stepSpec); err != nil {
+				return fmt.Errorf("parse cache restore step spec error: %v", err)
+			}
+			for _, restoreKey := range stepSpec.RestoreKeys {
+				if err := buildcache.ValidateKeyTemplate(restoreKey); err != nil {
+					return fmt.Errorf("step %s: %v", step.Name, err)
+				}
+			}
+			key, paths = stepSpec.Key, stepSpec.Paths
+		case config.StepCacheSave:
+			stepSpec := &steptypes.StepCacheSaveSpec{}
+			if err := commonmodels.IToiYaml(step.Spec, stepSpec); err != nil {
+				return fmt.Errorf("parse cache save step spec error: %v", err)
+			}
+			key, paths = stepSpec.Key, stepSpec.Paths
+		default:
+			continue
+		}
+		if err := buildcache.ValidateKeyTemplate(key); err != nil {
+			return fmt.Errorf("step %s: %v", step.Name, err)
+		}
+		if len(paths) == 0 {
+			return fmt.Errorf("step %s: cache paths are not set", step.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,305 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	commonservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service"
+	"github.com/koderover/zadig/v2/pkg/setting"
+	"github.com/koderover/zadig/v2/pkg/tool/log"
+	"github.com/koderover/zadig/v2/pkg/tool/trivy"
+	"github.com/koderover/zadig/v2/pkg/types/job"
+	"github.com/koderover/zadig/v2/pkg/types/step"
+)
+
+const (
+	ImageScanTimeout int64 = 30
+	ImageScanner           = "trivy"
+)
+
+type ImageScanJob struct {
+	job      *commonmodels.Job
+	workflow *commonmodels.WorkflowV4
+	spec     *commonmodels.ImageScanJobSpec
+}
+
+func (j *ImageScanJob) Instantiate() error {
+	j.spec = &commonmodels.ImageScanJobSpec{}
+	if err := commonmodels.IToiYaml(j.job.Spec, j.spec); err != nil {
+		return err
+	}
+	j.job.Spec = j.spec
+	return nil
+}
+
+func (j *ImageScanJob) SetPreset() error {
+	j.spec = &commonmodels.ImageScanJobSpec{}
+	if err := commonmodels.IToi(j.job.Spec, j.spec); err != nil {
+		return err
+	}
+
+	if j.spec.Source == config.SourceFromJob {
+		targets, _, err := j.getReferredBuildTargets()
+		if err != nil {
+			return fmt.Errorf("failed to get referred job info for image scan job: %s, error: %s", j.job.Name, err)
+		}
+		j.spec.Targets = targets
+	}
+	j.job.Spec = j.spec
+	return nil
+}
+
+func (j *ImageScanJob) SetOptions(approvalTicket *commonmodels.ApprovalTicket) error {
+	return nil
+}
+
+func (j *ImageScanJob) ClearOptions() error {
+	return nil
+}
+
+func (j *ImageScanJob) ClearSelectionField() error {
+	j.spec = &commonmodels.ImageScanJobSpec{}
+	if err := commonmodels.IToi(j.job.Spec, j.spec); err != nil {
+		return err
+	}
+
+	j.spec.Targets = make([]*commonmodels.ImageScanTarget, 0)
+	j.job.Spec = j.spec
+	return nil
+}
+
+func (j *ImageScanJob) MergeArgs(args *commonmodels.Job) error {
+	if j.job.Name == args.Name && j.job.JobType == args.JobType {
+		j.spec = &commonmodels.ImageScanJobSpec{}
+		if err := commonmodels.IToi(j.job.Spec, j.spec); err != nil {
+			return err
+		}
+		argsSpec := &commonmodels.ImageScanJobSpec{}
+		if err := commonmodels.IToi(args.Spec, argsSpec); err != nil {
+			return err
+		}
+		j.spec.Targets = argsSpec.Targets
+		j.job.Spec = j.spec
+	}
+	return nil
+}
+
+func (j *ImageScanJob) UpdateWithLatestSetting() error {
+	j.spec = &commonmodels.ImageScanJobSpec{}
+	if err := commonmodels.IToi(j.job.Spec, j.spec); err != nil {
+		return err
+	}
+
+	latestWorkflow, err := commonrepo.NewWorkflowV4Coll().Find(j.workflow.Name)
+	if err != nil {
+		log.Errorf("Failed to find original workflow to set options, error: %s", err)
+		return err
+	}
+
+	latestSpec := new(commonmodels.ImageScanJobSpec)
+	found := false
+	for _, stage := range latestWorkflow.Stages {
+		if !found {
+			for _, job := range stage.Jobs {
+				if job.Name == j.job.Name && job.JobType == j.job.JobType {
+					if err := commonmodels.IToi(job.Spec, latestSpec); err != nil {
+						return err
+					}
+					found = true
+					break
+				}
+			}
+		} else {
+			break
+		}
+	}
+
+	if !found {
+		return fmt.Errorf("failed to find the original workflow: %s", j.workflow.Name)
+	}
+
+	if j.spec.Source != latestSpec.Source {
+		j.spec.Targets = make([]*commonmodels.ImageScanTarget, 0)
+	}
+	j.spec.Source = latestSpec.Source
+	j.spec.JobName = latestSpec.JobName
+	j.spec.RegistryID = latestSpec.RegistryID
+	j.spec.ScannerVersion = latestSpec.ScannerVersion
+	j.spec.Severity = latestSpec.Severity
+	j.spec.Threshold = latestSpec.Threshold
+	j.spec.Timeout = latestSpec.Timeout
+	j.spec.ClusterID = latestSpec.ClusterID
+	j.spec.StrategyID = latestSpec.StrategyID
+	j.job.Spec = j.spec
+	return nil
+}
+
+func (j *ImageScanJob) ToJobs(taskID int64) ([]*commonmodels.JobTask, error) {
+	logger := log.SugaredLogger()
+	resp := []*commonmodels.JobTask{}
+
+	j.spec = &commonmodels.ImageScanJobSpec{}
+	if err := commonmodels.IToi(j.job.Spec, j.spec); err != nil {
+		return resp, err
+	}
+
+	if j.spec.Source == config.SourceFromJob {
+		targets, registryID, err := j.getReferredBuildTargets()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get referred job info for image scan job: %s, error: %s", j.job.Name, err)
+		}
+		j.spec.Targets = targets
+		j.spec.RegistryID = registryID
+	}
+	if len(j.spec.Targets) == 0 {
+		return nil, fmt.Errorf("no image to scan in job: %s", j.job.Name)
+	}
+
+	stepSpec := &step.StepImageScanSpec{
+		Severity:  j.spec.Severity,
+		Threshold: j.spec.Threshold,
+	}
+	if j.spec.RegistryID != "" {
+		reg, err := commonservice.FindRegistryById(j.spec.RegistryID, true, logger)
+		if err != nil {
+			return resp, fmt.Errorf("image registry: %s not found: %v", j.spec.RegistryID, err)
+		}
+		stepSpec.Registry = getRegistry(reg)
+	}
+	for _, target := range j.spec.Targets {
+		stepSpec.Targets = append(stepSpec.Targets, &step.ImageScanTarget{
+			ServiceName:   target.ServiceName,
+			ServiceModule: target.ServiceModule,
+			Image:         target.Image,
+		})
+	}
+
+	jobTask := &commonmodels.JobTask{
+		Name:        GenJobName(j.workflow, j.job.Name, 0),
+		Key:         genJobKey(j.job.Name),
+		DisplayName: genJobDisplayName(j.job.Name),
+		OriginName:  j.job.Name,
+		JobInfo: map[string]string{
+			JobNameKey: j.job.Name,
+		},
+		JobType:     string(config.JobImageScan),
+		Outputs:     []*commonmodels.Output{{Name: setting.WorkflowImageScanJobOutputKey}},
+		ErrorPolicy: j.job.ErrorPolicy,
+	}
+
+	steps := make([]*commonmodels.StepTask, 0)
+	if j.spec.ScannerVersion != "" {
+		steps = append(steps, &commonmodels.StepTask{
+			Name:     fmt.Sprintf("%s-%s", j.job.Name, "tool-install"),
+			JobName:  jobTask.Name,
+			StepType: config.StepTools,
+			Spec: step.StepToolInstallSpec{Installs: []*step.Tool{{
+				Name:    ImageScanner,
+				Version: j.spec.ScannerVersion,
+			}}},
+		})
+	}
+	steps = append(steps, &commonmodels.StepTask{
+		Name:     fmt.Sprintf("%s-%s", j.job.Name, "image-scan"),
+		JobName:  jobTask.Name,
+		JobKey:   jobTask.Key,
+		StepType: config.StepImageScan,
+		Spec:     stepSpec,
+	})
+
+	timeout := j.spec.Timeout
+	if timeout == 0 {
+		timeout = ImageScanTimeout
+	}
+	jobTask.Timeout = timeout
+	jobTask.Spec = &commonmodels.JobTaskFreestyleSpec{
+		Properties: commonmodels.JobProperties{
+			Timeout:           timeout,
+			ResourceRequest:   setting.MinRequest,
+			ClusterID:         j.spec.ClusterID,
+			StrategyID:        j.spec.StrategyID,
+			BuildOS:           "focal",
+			ImageFrom:         commonmodels.ImageFromKoderover,
+			CustomAnnotations: j.spec.CustomAnnotations,
+			CustomLabels:      j.spec.CustomLabels,
+		},
+		Steps: steps,
+	}
+	resp = append(resp, jobTask)
+	j.job.Spec = j.spec
+	return resp, nil
+}
+
+func (j *ImageScanJob) LintJob() error {
+	j.spec = &commonmodels.ImageScanJobSpec{}
+	if err := commonmodels.IToiYaml(j.job.Spec, j.spec); err != nil {
+		return err
+	}
+	if !trivy.IsValidSeverity(j.spec.Severity) {
+		return fmt.Errorf("image scan job: [%s] invalid severity: %s", j.job.Name, j.spec.Severity)
+	}
+	if j.spec.Threshold < 0 {
+		return fmt.Errorf("image scan job: [%s] threshold cannot be negative", j.job.Name)
+	}
+	if j.spec.Source != config.SourceFromJob {
+		return nil
+	}
+	jobRankMap := getJobRankMap(j.workflow.Stages)
+	buildJobRank, ok := jobRankMap[j.spec.JobName]
+	if !ok || buildJobRank >= jobRankMap[j.job.Name] {
+		return fmt.Errorf("can not quote job %s in job %s", j.spec.JobName, j.job.Name)
+	}
+	return lintQuotedMatrixJob(j.workflow, j.spec.JobName)
+}
+
+// getReferredBuildTargets returns the images built by the referred build job and the registry they are pushed to
+func (j *ImageScanJob) getReferredBuildTargets() ([]*commonmodels.ImageScanTarget, string, error) {
+	for _, stage := range j.workflow.Stages {
+		for _, jb := range stage.Jobs {
+			if jb.Name != j.spec.JobName {
+				continue
+			}
+			if jb.JobType != config.JobZadigBuild {
+				return nil, "", fmt.Errorf("referred job %s is not a build job", j.spec.JobName)
+			}
+			buildSpec := &commonmodels.ZadigBuildJobSpec{}
+			if err := commonmodels.IToi(jb.Spec, buildSpec); err != nil {
+				return nil, "", fmt.Errorf("failed to decode build job spec, error: %s", err)
+			}
+			targets := make([]*commonmodels.ImageScanTarget, 0)
+			for _, build := range buildSpec.ServiceAndBuilds {
+				targets = append(targets, &commonmodels.ImageScanTarget{
+					ServiceName:   build.ServiceName,
+					ServiceModule: build.ServiceModule,
+					Image:         job.GetJobOutputKey(fmt.Sprintf("%s.%s.%s", j.spec.JobName, build.ServiceName, build.ServiceModule), IMAGEKEY),
+				})
+			}
+			return targets, buildSpec.DockerRegistryID, nil
+		}
+	}
+	return nil, "", fmt.Errorf("referred job %s not found", j.spec.JobName)
+}
+
+func (j *ImageScanJob) GetOutPuts(log *zap.SugaredLogger) []string {
+	return getOutputKey(genJobKey(j.job.Name), []*commonmodels.Output{{Name: setting.WorkflowImageScanJobOutputKey}})
+}
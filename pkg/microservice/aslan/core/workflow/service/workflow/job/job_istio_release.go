@@ -14,6 +14,7 @@ limitations under the License.
 package job
 
 import (
+	"context"
 	"fmt"
 	"math"
 
@@ -25,6 +26,7 @@ import (
 	e "github.com/koderover/zadig/v2/pkg/tool/errors"
 	"github.com/koderover/zadig/v2/pkg/tool/kube/getter"
 	"github.com/koderover/zadig/v2/pkg/tool/log"
+	"github.com/koderover/zadig/v2/pkg/tool/prometheus"
 )
 
 type IstioReleaseJob struct {
@@ -169,6 +171,8 @@ func (j *IstioReleaseJob) UpdateWithLatestSetting() error {
 	j.spec.Timeout = latestSpec.Timeout
 	j.spec.ReplicaPercentage = latestSpec.ReplicaPercentage
 	j.spec.Weight = latestSpec.Weight
+	j.spec.ProgressiveSteps = latestSpec.ProgressiveSteps
+	j.spec.HealthChecks = latestSpec.HealthChecks
 
 	userConfiguredService := make(map[string]*commonmodels.IstioJobTarget)
 	for _, svc := range j.spec.Targets {
@@ -213,6 +217,9 @@ func (j *IstioReleaseJob) ToJobs(taskID int64) ([]*commonmodels.JobTask, error)
 	if err := commonmodels.IToi(j.job.Spec, j.spec); err != nil {
 		return resp, err
 	}
+	if len(j.spec.ProgressiveSteps) > 0 {
+		j.spec.Weight = j.spec.ProgressiveSteps[len(j.spec.ProgressiveSteps)-1].Weight
+	}
 	// if from job is empty, it was the first deploy Job.
 	firstJob := false
 	if j.spec.FromJob != "" {
@@ -284,6 +291,8 @@ func (j *IstioReleaseJob) ToJobs(taskID int64) ([]*commonmodels.JobTask, error)
 				ReplicaPercentage: j.spec.ReplicaPercentage,
 				Replicas:          int64(newReplicaCount),
 				Targets:           target,
+				Steps:             genIstioReleaseSteps(j.spec.ProgressiveSteps),
+				HealthChecks:      j.spec.HealthChecks,
 			},
 			ErrorPolicy: j.job.ErrorPolicy,
 		}
@@ -304,6 +313,12 @@ func (j *IstioReleaseJob) LintJob() error {
 	if j.spec.Weight > 100 {
 		return fmt.Errorf("istio release job: [%s] weight cannot be more than 100", j.job.Name)
 	}
+	if err := lintIstioProgressiveRelease(j.spec); err != nil {
+		return fmt.Errorf("istio release job: [%s] %v", j.job.Name, err)
+	}
+	if len(j.spec.ProgressiveSteps) > 0 && j.spec.FromJob == "" && j.spec.ProgressiveSteps[len(j.spec.ProgressiveSteps)-1].Weight >= 100 {
+		return fmt.Errorf("the first istio release job: [%s] cannot be released in full", j.job.Name)
+	}
 
 	//from job was empty means it is the first deploy job.
 	if j.spec.FromJob == "" {
@@ -376,3 +391,71 @@ func lintFirstIstioReleaseJob(jobName string, stages []*commonmodels.WorkflowSta
 	}
 	return nil
 }
+
+func genIstioReleaseSteps(steps []*commonmodels.IstioProgressiveStep) []*commonmodels.IstioReleaseStep {
+	resp := make([]*commonmodels.IstioReleaseStep, 0, len(steps))
+	for _, step := range steps {
+		resp = append(resp, &commonmodels.IstioReleaseStep{
+			Weight:   step.Weight,
+			Interval: step.Interval,
+			Status:   config.StatusPrepare,
+		})
+	}
+	return resp
+}
+
+func lintIstioProgressiveRelease(spec *commonmodels.IstioJobSpec) error {
+	if len(spec.ProgressiveSteps) == 0 {
+		if len(spec.HealthChecks) > 0 {
+			return fmt.Errorf("health checks can only be used with progressive steps")
+		}
+		return nil
+	}
+
+	lastWeight := int64(0)
+	for _, step := range spec.ProgressiveSteps {
+		if step.Weight <= lastWeight || step.Weight > 100 {
+			return fmt.Errorf("progressive step weights must be increasing and between 1 and 100")
+		}
+		if step.Interval < 0 {
+			return fmt.Errorf("progressive step interval cannot be negative")
+		}
+		lastWeight = step.Weight
+	}
+
+	for _, check := range spec.HealthChecks {
+		switch check.Type {
+		case config.IstioHealthCheckPodReady:
+		case config.IstioHealthCheckRestartCount:
+			if check.MaxRestarts < 0 {
+				return fmt.Errorf("max restarts cannot be negative")
+			}
+		case config.IstioHealthCheckMetric:
+			if check.Metric == nil || check.Metric.Query == "" {
+				return fmt.Errorf("metric health check requires a query")
+			}
+			info, err := commonrepo.NewObservabilityColl().GetByID(context.Background(), check.ObservabilityID)
+			if err != nil {
+				return fmt.Errorf("failed to find prometheus integration %s: %v", check.ObservabilityID, err)
+			}
+			if info.Type != config.ObservabilityTypePrometheus {
+				return fmt.Errorf("observability integration %s is not prometheus", info.Name)
+			}
+			switch check.Metric.Mode {
+			case config.MetricCheckModeAbsolute, config.MetricCheckModeBaseline:
+			case config.MetricCheckModeCanary:
+				if check.Metric.BaselineQuery == "" {
+					return fmt.Errorf("metric health check requires a baseline query in canary mode")
+				}
+			default:
+				return fmt.Errorf("invalid metric check mode: %s", check.Metric.Mode)
+			}
+			if _, err := prometheus.Compare(0, check.Metric.Operator, check.Metric.Threshold); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("invalid health check type: %s", check.Type)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+)
+
+func TestLintIstioProgressiveRelease(t *testing.T) {
+	steps := func(weights ...int64) []*commonmodels.IstioProgressiveStep {
+		resp := make([]*commonmodels.IstioProgressiveStep, 0)
+		for _, weight := range weights {
+			resp = append(resp, &commonmodels.IstioProgressiveStep{Weight: weight, Interval: 60})
+		}
+		return resp
+	}
+	checks := []*commonmodels.IstioHealthCheck{
+		{Type: config.IstioHealthCheckPodReady},
+		{Type: config.IstioHealthCheckRestartCount, MaxRestarts: 1},
+	}
+
+	assert.NoError(t, lintIstioProgressiveRelease(&commonmodels.IstioJobSpec{Weight: 20}))
+	assert.NoError(t, lintIstioProgressiveRelease(&commonmodels.IstioJobSpec{ProgressiveSteps: steps(10, 50, 100), HealthChecks: checks}))
+	assert.Error(t, lintIstioProgressiveRelease(&commonmodels.IstioJobSpec{ProgressiveSteps: steps(50, 10)}))
+	assert.Error(t, lintIstioProgressiveRelease(&commonmodels.IstioJobSpec{ProgressiveSteps: steps(10, 110)}))
+	assert.Error(t, lintIstioProgressiveRelease(&commonmodels.IstioJobSpec{HealthChecks: checks}))
+	assert.Error(t, lintIstioProgressiveRelease(&commonmodels.IstioJobSpec{
+		ProgressiveSteps: steps(10),
+		HealthChecks:     []*commonmodels.IstioHealthCheck{{Type: "unknown"}},
+	}))
+}
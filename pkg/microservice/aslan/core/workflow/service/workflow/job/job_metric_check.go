@@ -0,0 +1,210 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/tool/log"
+	"github.com/koderover/zadig/v2/pkg/tool/prometheus"
+)
+
+const defaultMetricCheckInterval = 60
+
+type MetricCheckJob struct {
+	job      *commonmodels.Job
+	workflow *commonmodels.WorkflowV4
+	spec     *commonmodels.MetricCheckJobSpec
+}
+
+func (j *MetricCheckJob) Instantiate() error {
+	j.spec = &commonmodels.MetricCheckJobSpec{}
+	if err := commonmodels.IToiYaml(j.job.Spec, j.spec); err != nil {
+		return err
+	}
+	j.job.Spec = j.spec
+	return nil
+}
+
+func (j *MetricCheckJob) SetPreset() error {
+	j.spec = &commonmodels.MetricCheckJobSpec{}
+	if err := commonmodels.IToi(j.job.Spec, j.spec); err != nil {
+		return err
+	}
+	j.job.Spec = j.spec
+	return nil
+}
+
+func (j *MetricCheckJob) SetOptions(approvalTicket *commonmodels.ApprovalTicket) error {
+	return nil
+}
+
+func (j *MetricCheckJob) ClearOptions() error {
+	return nil
+}
+
+func (j *MetricCheckJob) ClearSelectionField() error {
+	return nil
+}
+
+func (j *MetricCheckJob) UpdateWithLatestSetting() error {
+	j.spec = &commonmodels.MetricCheckJobSpec{}
+	if err := commonmodels.IToi(j.job.Spec, j.spec); err != nil {
+		return err
+	}
+
+	latestWorkflow, err := commonrepo.NewWorkflowV4Coll().Find(j.workflow.Name)
+	if err != nil {
+		log.Errorf("Failed to find original workflow to set options, error: %s", err)
+		return err
+	}
+
+	latestSpec := new(commonmodels.MetricCheckJobSpec)
+	found := false
+	for _, stage := range latestWorkflow.Stages {
+		if !found {
+			for _, job := range stage.Jobs {
+				if job.Name == j.job.Name && job.JobType == j.job.JobType {
+					if err := commonmodels.IToi(job.Spec, latestSpec); err != nil {
+						return err
+					}
+					found = true
+					break
+				}
+			}
+		} else {
+			break
+		}
+	}
+
+	if !found {
+		return fmt.Errorf("failed to find the original workflow: %s", j.workflow.Name)
+	}
+
+	// metric check settings are not configurable at runtime, always use the latest ones
+	j.spec = latestSpec
+	j.job.Spec = j.spec
+	return nil
+}
+
+func (j *MetricCheckJob) MergeArgs(args *commonmodels.Job) error {
+	j.spec = &commonmodels.MetricCheckJobSpec{}
+	if err := commonmodels.IToi(args.Spec, j.spec); err != nil {
+		return err
+	}
+	j.job.Spec = j.spec
+	return nil
+}
+
+func (j *MetricCheckJob) ToJobs(taskID int64) ([]*commonmodels.JobTask, error) {
+	resp := []*commonmodels.JobTask{}
+	j.spec = &commonmodels.MetricCheckJobSpec{}
+	if err := commonmodels.IToi(j.job.Spec, j.spec); err != nil {
+		return resp, err
+	}
+	j.job.Spec = j.spec
+	if len(j.spec.Metrics) == 0 {
+		return nil, errors.New("no metric")
+	}
+	for _, metric := range j.spec.Metrics {
+		metric.Status = "checking"
+	}
+	interval := j.spec.CheckInterval
+	if interval <= 0 {
+		interval = defaultMetricCheckInterval
+	}
+
+	jobTask := &commonmodels.JobTask{
+		Name:        GenJobName(j.workflow, j.job.Name, 0),
+		Key:         genJobKey(j.job.Name),
+		DisplayName: genJobDisplayName(j.job.Name),
+		OriginName:  j.job.Name,
+		JobInfo: map[string]string{
+			JobNameKey: j.job.Name,
+		},
+		JobType: string(config.JobMetricCheck),
+		Spec: &commonmodels.JobTaskMetricCheckSpec{
+			ID:            j.spec.ID,
+			Name:          j.spec.Name,
+			CheckTime:     j.spec.CheckTime,
+			CheckInterval: interval,
+			FailureLimit:  j.spec.FailureLimit,
+			Metrics:       j.spec.Metrics,
+		},
+		ErrorPolicy: j.job.ErrorPolicy,
+		Timeout:     0,
+	}
+	return []*commonmodels.JobTask{jobTask}, nil
+}
+
+func (j *MetricCheckJob) LintJob() error {
+	j.spec = &commonmodels.MetricCheckJobSpec{}
+	if err := commonmodels.IToiYaml(j.job.Spec, j.spec); err != nil {
+		return err
+	}
+
+	info, err := commonrepo.NewObservabilityColl().GetByID(context.Background(), j.spec.ID)
+	if err != nil {
+		return fmt.Errorf("failed to find prometheus integration %s: %v", j.spec.ID, err)
+	}
+	if info.Type != config.ObservabilityTypePrometheus {
+		return fmt.Errorf("observability integration %s is not prometheus", info.Name)
+	}
+	if j.spec.CheckTime <= 0 {
+		return errors.New("check time must be greater than 0")
+	}
+	if j.spec.CheckInterval < 0 {
+		return errors.New("check interval can not be negative")
+	}
+	interval := j.spec.CheckInterval
+	if interval <= 0 {
+		interval = defaultMetricCheckInterval
+	}
+	if interval >= j.spec.CheckTime*60 {
+		return fmt.Errorf("check interval %ds must be less than the check time %dm", interval, j.spec.CheckTime)
+	}
+	if j.spec.FailureLimit < 0 {
+		return errors.New("failure limit can not be negative")
+	}
+	if len(j.spec.Metrics) == 0 {
+		return errors.New("at least one metric is required")
+	}
+	for _, metric := range j.spec.Metrics {
+		if metric.Query == "" {
+			return fmt.Errorf("metric %s: query is required", metric.Name)
+		}
+		switch metric.Mode {
+		case config.MetricCheckModeAbsolute, config.MetricCheckModeBaseline:
+		case config.MetricCheckModeCanary:
+			if metric.BaselineQuery == "" {
+				return fmt.Errorf("metric %s: baseline query is required in canary mode", metric.Name)
+			}
+		default:
+			return fmt.Errorf("metric %s: invalid mode %s", metric.Name, metric.Mode)
+		}
+		if _, err := prometheus.Compare(0, metric.Operator, metric.Threshold); err != nil {
+			return fmt.Errorf("metric %s: %v", metric.Name, err)
+		}
+	}
+	return nil
+}
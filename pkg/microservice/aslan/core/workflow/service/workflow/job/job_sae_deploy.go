@@ -50,6 +50,9 @@ func (j *SAEDeployJob) LintJob() error {
 	if err != nil {
 		return e.ErrLicenseInvalid.AddDesc("")
 	}
+	if j.spec.ServiceConfig != nil && j.spec.ServiceConfig.Source == config.SourceFromJob {
+		return lintQuotedMatrixJob(j.workflow, j.spec.JobName)
+	}
 	return nil
 }
 
@@ -131,9 +131,10 @@ func (j *SQLJob) ToJobs(taskID int64) ([]*commonmodels.JobTask, error) {
 		},
 		JobType: string(config.JobSQL),
 		Spec: &commonmodels.JobTaskSQLSpec{
-			ID:   j.spec.ID,
-			Type: j.spec.Type,
-			SQL:  j.spec.SQL,
+			ID:     j.spec.ID,
+			Type:   j.spec.Type,
+			SQL:    j.spec.SQL,
+			DryRun: j.spec.DryRun,
 		},
 		Timeout:     0,
 		ErrorPolicy: j.job.ErrorPolicy,
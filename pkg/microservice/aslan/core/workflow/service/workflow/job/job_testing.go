@@ -283,11 +283,18 @@ func (j *TestingJob) ToJobs(taskID int64) ([]*commonmodels.JobTask, error) {
 	}
 
 	if j.spec.TestType == config.ProductTestType {
-		for jobSubTaskID, testing := range j.spec.TestModules {
-			jobTask, err := j.toJobtask(jobSubTaskID, testing, defaultS3, taskID, "", "", "", logger)
+		targets, err := expandJobMatrixTargets(len(j.spec.TestModules), j.spec.Matrix)
+		if err != nil {
+			return resp, fmt.Errorf("testing job %s: %v", j.job.Name, err)
+		}
+		for jobSubTaskID, target := range targets {
+			jobTask, err := j.toJobtask(jobSubTaskID, j.spec.TestModules[target.index], defaultS3, taskID, "", "", "", logger)
 			if err != nil {
 				return resp, err
 			}
+			setJobTaskMatrix(jobTask, target.values, j.spec.Matrix)
+			// the job tasks of the matrix combinations set their own outputs
+			jobTask.Key = genJobKey(jobTask.Key, jobMatrixSuffix(target.values)...)
 			resp = append(resp, jobTask)
 		}
 	}
@@ -309,19 +316,29 @@ func (j *TestingJob) ToJobs(taskID int64) ([]*commonmodels.JobTask, error) {
 	}
 
 	if j.spec.TestType == config.ServiceTestType {
-		jobSubTaskID := 0
+		serviceTestings := []*commonmodels.ServiceAndTest{}
 		for _, target := range j.spec.TargetServices {
 			for _, testing := range j.spec.ServiceAndTests {
 				if testing.ServiceName != target.ServiceName || testing.ServiceModule != target.ServiceModule {
 					continue
 				}
-				jobTask, err := j.toJobtask(jobSubTaskID, &testing.TestModule, defaultS3, taskID, string(j.spec.TestType), testing.ServiceName, testing.ServiceModule, logger)
-				if err != nil {
-					return resp, err
-				}
-				jobSubTaskID++
-				resp = append(resp, jobTask)
+				serviceTestings = append(serviceTestings, testing)
+			}
+		}
+		targets, err := expandJobMatrixTargets(len(serviceTestings), j.spec.Matrix)
+		if err != nil {
+			return resp, fmt.Errorf("testing job %s: %v", j.job.Name, err)
+		}
+		for jobSubTaskID, target := range targets {
+			testing := serviceTestings[target.index]
+			jobTask, err := j.toJobtask(jobSubTaskID, &testing.TestModule, defaultS3, taskID, string(j.spec.TestType), testing.ServiceName, testing.ServiceModule, logger)
+			if err != nil {
+				return resp, err
 			}
+			setJobTaskMatrix(jobTask, target.values, j.spec.Matrix)
+			// the job tasks of the matrix combinations set their own outputs
+			jobTask.Key = genJobKey(jobTask.Key, jobMatrixSuffix(target.values)...)
+			resp = append(resp, jobTask)
 		}
 	}
 
@@ -681,6 +698,13 @@ func (j *TestingJob) toJobtask(jobSubTaskID int, testing *commonmodels.TestModul
 
 	// init junit report step
 	if len(testingInfo.TestResultPath) > 0 {
+		commitID := ""
+		for _, repo := range repos {
+			if repo.CommitID != "" {
+				commitID = repo.CommitID
+				break
+			}
+		}
 		junitStep := &commonmodels.StepTask{
 			Name:      config.TestJobJunitReportStepName,
 			JobName:   jobTask.Name,
@@ -699,11 +723,53 @@ func (j *TestingJob) toJobtask(jobSubTaskID int, testing *commonmodels.TestModul
 				FileName:       "merged.xml",
 				ServiceName:    serviceName,
 				ServiceModule:  serviceModule,
+				CommitID:       commitID,
+				Format:         testingInfo.TestResultFormat,
 			},
 		}
 		jobTaskSpec.Steps = append(jobTaskSpec.Steps, junitStep)
 	}
 
+	// init coverage report step
+	if testingInfo.Coverage != nil && testingInfo.Coverage.Enabled {
+		var primaryRepo *types.Repository
+		for _, repo := range repos {
+			if repo.CommitID != "" || repo.PR > 0 || len(repo.PRs) > 0 {
+				primaryRepo = repo
+				break
+			}
+		}
+		coverageSpec := &step.StepCoverageReportSpec{
+			SourceWorkflow: j.workflow.Name,
+			JobTaskName:    jobName,
+			TaskID:         taskID,
+			TestName:       testing.Name,
+			TestProject:    testing.ProjectName,
+			Format:         testingInfo.Coverage.Format,
+			ReportPath:     testingInfo.Coverage.ReportPath,
+			Threshold:      testingInfo.Coverage.Threshold,
+			MaxDrop:        testingInfo.Coverage.MaxDrop,
+			S3DestDir:      path.Join(j.workflow.Name, fmt.Sprint(taskID), jobTask.Name, "coverage"),
+			FileName:       "coverage.json",
+		}
+		if primaryRepo != nil {
+			coverageSpec.Branch = primaryRepo.Branch
+			coverageSpec.CommitID = primaryRepo.CommitID
+			coverageSpec.PR = primaryRepo.PR
+			if coverageSpec.PR == 0 && len(primaryRepo.PRs) > 0 {
+				coverageSpec.PR = primaryRepo.PRs[0]
+			}
+		}
+		coverageStep := &commonmodels.StepTask{
+			Name:      config.TestJobCoverageReportStepName,
+			JobName:   jobTask.Name,
+			StepType:  config.StepCoverageReport,
+			Onfailure: true,
+			Spec:      coverageSpec,
+		}
+		jobTaskSpec.Steps = append(jobTaskSpec.Steps, coverageStep)
+	}
+
 	// init object cache step
 	if jobTaskSpec.Properties.CacheEnable && jobTaskSpec.Properties.Cache.MediumType == types.ObjectMedium {
 		cacheDir := "/workspace"
@@ -763,6 +829,9 @@ func (j *TestingJob) LintJob() error {
 	if err := commonmodels.IToiYaml(j.job.Spec, j.spec); err != nil {
 		return err
 	}
+	if err := lintJobMatrix(j.spec.Matrix); err != nil {
+		return fmt.Errorf("testing job %s: %v", j.job.Name, err)
+	}
 	if j.spec.Source != config.SourceFromJob {
 		return nil
 	}
@@ -798,15 +867,23 @@ func (j *TestingJob) GetOutPuts(log *zap.SugaredLogger) []string {
 		return resp
 	}
 	for _, testingInfo := range testingInfos {
-		jobKey := strings.Join([]string{j.job.Name, testingInfo.Name}, ".")
+		targetKeys := []string{strings.Join([]string{j.job.Name, testingInfo.Name}, ".")}
 		if j.spec.TestType == config.ServiceTestType {
+			targetKeys = []string{}
 			for _, testing := range j.spec.ServiceAndTests {
-				jobKey = strings.Join([]string{j.job.Name, testingInfo.Name, testing.ServiceName, testing.ServiceModule}, ".")
+				targetKeys = append(targetKeys, strings.Join([]string{j.job.Name, testingInfo.Name, testing.ServiceName, testing.ServiceModule}, "."))
+			}
+			targetKeys = append(targetKeys, j.job.Name+"."+testingInfo.Name+".<SERVICE>.<MODULE>")
+		}
+		for _, targetKey := range targetKeys {
+			jobKeys, err := matrixJobKeys(targetKey, j.spec.Matrix)
+			if err != nil {
+				log.Errorf("failed to expand the matrix of job %s, err: %s", j.job.Name, err)
+				return resp
+			}
+			for _, jobKey := range jobKeys {
 				resp = append(resp, getOutputKey(jobKey, testingInfo.Outputs)...)
 			}
-			resp = append(resp, getOutputKey(j.job.Name+"."+testingInfo.Name+".<SERVICE>.<MODULE>", testingInfo.Outputs)...)
-		} else {
-			resp = append(resp, getOutputKey(jobKey, testingInfo.Outputs)...)
 		}
 	}
 
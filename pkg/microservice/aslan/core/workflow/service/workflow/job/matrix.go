@@ -0,0 +1,325 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+)
+
+const maxMatrixCombinations = 256
+
+var (
+	matrixKeyRegx = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+	// matrixSuffixRegx matches the characters not allowed in job keys and image tags
+	matrixSuffixRegx = regexp.MustCompile(`[^a-zA-Z0-9_-]`)
+)
+
+// lintQuotedMatrixJob rejects quoting the images of a matrix build job, the job keys of its job tasks differ
+// among the matrix combinations so the quoting job can not tell which image to use.
+func lintQuotedMatrixJob(workflow *commonmodels.WorkflowV4, jobName string) error {
+	for _, stage := range workflow.Stages {
+		for _, job := range stage.Jobs {
+			if job.Name != jobName || job.JobType != config.JobZadigBuild {
+				continue
+			}
+			spec := &commonmodels.ZadigBuildJobSpec{}
+			if err := commonmodels.IToiYaml(job.Spec, spec); err != nil {
+				return err
+			}
+			if spec.Matrix != nil && (len(spec.Matrix.Axes) > 0 || len(spec.Matrix.Include) > 0) {
+				return fmt.Errorf("can not quote the images of matrix build job %s", jobName)
+			}
+			return nil
+		}
+	}
+	return nil
+}
+
+// jobMatrixTarget is one job task to be generated: the target (service module, test module...) at index, with the matrix values.
+type jobMatrixTarget struct {
+	index  int
+	values map[string]string
+}
+
+func lintJobMatrix(matrix *commonmodels.JobMatrix) error {
+	if matrix == nil {
+		return nil
+	}
+	for key, values := range matrix.Axes {
+		if !matrixKeyRegx.MatchString(key) {
+			return fmt.Errorf("matrix key %s is invalid, it must be a valid env var name", key)
+		}
+		if len(values) == 0 {
+			return fmt.Errorf("matrix key %s has no value", key)
+		}
+	}
+	for _, include := range matrix.Include {
+		for key := range include {
+			if !matrixKeyRegx.MatchString(key) {
+				return fmt.Errorf("matrix include key %s is invalid, it must be a valid env var name", key)
+			}
+		}
+	}
+	combinations, err := expandJobMatrix(matrix)
+	if err != nil {
+		return err
+	}
+	if len(combinations) == 0 {
+		return fmt.Errorf("matrix has no combination left after exclusion")
+	}
+	return lintMatrixSuffixes(combinations)
+}
+
+// lintMatrixSuffixes rejects the combinations mapped to the same job key or image tag suffix, e.g. a/b and a-b,
+// the job tasks of these combinations would overwrite the outputs and images of each other.
+func lintMatrixSuffixes(combinations []map[string]string) error {
+	keySuffixes := make(map[string]map[string]string, len(combinations))
+	tagSuffixes := make(map[string]map[string]string, len(combinations))
+	for _, combination := range combinations {
+		suffix := jobMatrixSuffix(combination)
+		keySuffix, tagSuffix := strings.Join(suffix, "."), strings.Join(suffix, "-")
+		if other, ok := keySuffixes[keySuffix]; ok {
+			return fmt.Errorf("matrix combinations %s and %s generate the same job key", formatMatrixValues(other), formatMatrixValues(combination))
+		}
+		if other, ok := tagSuffixes[tagSuffix]; ok {
+			return fmt.Errorf("matrix combinations %s and %s generate the same image tag", formatMatrixValues(other), formatMatrixValues(combination))
+		}
+		keySuffixes[keySuffix] = combination
+		tagSuffixes[tagSuffix] = combination
+	}
+	return nil
+}
+
+// expandJobMatrix returns the combinations of the matrix, the behavior of include and exclude follows github actions:
+// exclude removes the combinations matching all the key/values of an entry, then include adds its key/values to every combination
+// whose original axis values are not overwritten, or is added as a new combination if it can not be added to any of them.
+func expandJobMatrix(matrix *commonmodels.JobMatrix) ([]map[string]string, error) {
+	if matrix == nil {
+		return nil, nil
+	}
+
+	keys := make([]string, 0, len(matrix.Axes))
+	for key := range matrix.Axes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	combinations := make([]map[string]string, 0)
+	if len(keys) > 0 {
+		combinations = append(combinations, map[string]string{})
+	}
+	for _, key := range keys {
+		expanded := make([]map[string]string, 0, len(combinations)*len(matrix.Axes[key]))
+		for _, combination := range combinations {
+			for _, value := range matrix.Axes[key] {
+				newCombination := make(map[string]string, len(combination)+1)
+				for k, v := range combination {
+					newCombination[k] = v
+				}
+				newCombination[key] = value
+				expanded = append(expanded, newCombination)
+			}
+		}
+		combinations = expanded
+		if len(combinations) > maxMatrixCombinations {
+			return nil, fmt.Errorf("matrix can not generate more than %d combinations", maxMatrixCombinations)
+		}
+	}
+
+	resp := make([]map[string]string, 0, len(combinations))
+	for _, combination := range combinations {
+		excluded := false
+		for _, exclude := range matrix.Exclude {
+			if matchMatrixValues(combination, exclude) {
+				excluded = true
+				break
+			}
+		}
+		if !excluded {
+			resp = append(resp, combination)
+		}
+	}
+
+	for _, include := range matrix.Include {
+		added := false
+		for _, combination := range resp {
+			conflict := false
+			for key, value := range include {
+				if _, ok := matrix.Axes[key]; ok && combination[key] != value {
+					conflict = true
+					break
+				}
+			}
+			if conflict {
+				continue
+			}
+			for key, value := range include {
+				combination[key] = value
+			}
+			added = true
+		}
+		if !added {
+			newCombination := make(map[string]string, len(include))
+			for key, value := range include {
+				newCombination[key] = value
+			}
+			resp = append(resp, newCombination)
+		}
+	}
+
+	if len(resp) > maxMatrixCombinations {
+		return nil, fmt.Errorf("matrix can not generate more than %d combinations", maxMatrixCombinations)
+	}
+	return resp, nil
+}
+
+func matchMatrixValues(combination, values map[string]string) bool {
+	if len(values) == 0 {
+		return false
+	}
+	for key, value := range values {
+		if combination[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// expandJobMatrixTargets generates a target for every combination of every original target,
+// a job without matrix keeps one target with nil values for every original target.
+func expandJobMatrixTargets(targetCount int, matrix *commonmodels.JobMatrix) ([]*jobMatrixTarget, error) {
+	combinations, err := expandJobMatrix(matrix)
+	if err != nil {
+		return nil, err
+	}
+	if matrix != nil && len(combinations) == 0 && (len(matrix.Axes) > 0 || len(matrix.Include) > 0) {
+		return nil, fmt.Errorf("matrix has no combination left after exclusion")
+	}
+	if err := lintMatrixSuffixes(combinations); err != nil {
+		return nil, err
+	}
+	if len(combinations) == 0 {
+		combinations = []map[string]string{nil}
+	}
+
+	resp := make([]*jobMatrixTarget, 0, targetCount*len(combinations))
+	for i := 0; i < targetCount; i++ {
+		for _, combination := range combinations {
+			resp = append(resp, &jobMatrixTarget{index: i, values: combination})
+		}
+	}
+	return resp, nil
+}
+
+func sortedMatrixKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// sortedMatrixValues returns the matrix values sorted by their keys
+func sortedMatrixValues(values map[string]string) []string {
+	resp := make([]string, 0, len(values))
+	for _, key := range sortedMatrixKeys(values) {
+		resp = append(resp, values[key])
+	}
+	return resp
+}
+
+// jobMatrixSuffix returns the matrix values sorted by their keys with the characters not allowed in job keys
+// and image tags replaced by "-", it tells apart the job keys and images of the job tasks of the same target.
+func jobMatrixSuffix(values map[string]string) []string {
+	resp := sortedMatrixValues(values)
+	for i, value := range resp {
+		resp[i] = matrixSuffixRegx.ReplaceAllString(value, "-")
+	}
+	return resp
+}
+
+// formatMatrixValues returns the matrix values as key=value pairs sorted by their keys
+func formatMatrixValues(values map[string]string) string {
+	resp := make([]string, 0, len(values))
+	for _, key := range sortedMatrixKeys(values) {
+		resp = append(resp, fmt.Sprintf("%s=%s", key, values[key]))
+	}
+	return strings.Join(resp, ",")
+}
+
+// matrixJobKeys returns the job keys of the job tasks generated from the job key of a target for every matrix combination.
+func matrixJobKeys(jobKey string, matrix *commonmodels.JobMatrix) ([]string, error) {
+	combinations, err := expandJobMatrix(matrix)
+	if err != nil {
+		return nil, err
+	}
+	if len(combinations) == 0 {
+		return []string{jobKey}, nil
+	}
+	resp := make([]string, 0, len(combinations))
+	for _, combination := range combinations {
+		resp = append(resp, genJobKey(jobKey, jobMatrixSuffix(combination)...))
+	}
+	return resp, nil
+}
+
+// setJobTaskMatrix injects the matrix values into the job task as env vars and appends them to the display name,
+// the job key is kept here, jobs whose outputs differ among the matrix combinations append jobMatrixSuffix to their keys.
+func setJobTaskMatrix(jobTask *commonmodels.JobTask, values map[string]string, matrix *commonmodels.JobMatrix) {
+	if len(values) == 0 {
+		return
+	}
+
+	keys := sortedMatrixKeys(values)
+	displayValues := sortedMatrixValues(values)
+	jobTask.DisplayName = genJobDisplayName(jobTask.DisplayName, displayValues...)
+	if jobInfo, ok := jobTask.JobInfo.(map[string]string); ok {
+		jobInfo["matrix"] = formatMatrixValues(values)
+	}
+	jobTask.Matrix = &commonmodels.JobTaskMatrix{
+		Values:   values,
+		FailFast: matrix.FailFast,
+	}
+
+	spec, ok := jobTask.Spec.(*commonmodels.JobTaskFreestyleSpec)
+	if !ok {
+		return
+	}
+	// the envs may be shared by the job tasks of the same target, so build a new list
+	envs := make([]*commonmodels.KeyVal, 0, len(spec.Properties.Envs)+len(keys))
+	for _, env := range spec.Properties.Envs {
+		if _, ok := values[env.Key]; ok {
+			continue
+		}
+		envs = append(envs, env)
+	}
+	for _, key := range keys {
+		envs = append(envs, &commonmodels.KeyVal{
+			Key:   key,
+			Value: values[key],
+			Type:  commonmodels.StringType,
+		})
+	}
+	spec.Properties.Envs = envs
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+)
+
+func TestExpandJobMatrix(t *testing.T) {
+	matrix := &commonmodels.JobMatrix{
+		Axes: map[string][]string{
+			"go":   {"1.20", "1.21"},
+			"arch": {"amd64", "arm64"},
+		},
+		Exclude: []map[string]string{
+			{"go": "1.20", "arch": "arm64"},
+		},
+		Include: []map[string]string{
+			{"go": "1.21", "cgo": "1"},
+			{"go": "1.22", "arch": "amd64"},
+		},
+	}
+
+	combinations, err := expandJobMatrix(matrix)
+	assert.NoError(t, err)
+	assert.Equal(t, []map[string]string{
+		{"arch": "amd64", "go": "1.20"},
+		{"arch": "amd64", "go": "1.21", "cgo": "1"},
+		{"arch": "arm64", "go": "1.21", "cgo": "1"},
+		{"arch": "amd64", "go": "1.22"},
+	}, combinations)
+}
+
+func TestExpandJobMatrixTargets(t *testing.T) {
+	targets, err := expandJobMatrixTargets(2, nil)
+	assert.NoError(t, err)
+	assert.Len(t, targets, 2)
+	assert.Nil(t, targets[1].values)
+
+	targets, err = expandJobMatrixTargets(2, &commonmodels.JobMatrix{Axes: map[string][]string{"os": {"linux", "windows"}}})
+	assert.NoError(t, err)
+	assert.Len(t, targets, 4)
+	assert.Equal(t, 1, targets[2].index)
+	assert.Equal(t, "linux", targets[2].values["os"])
+
+	_, err = expandJobMatrixTargets(1, &commonmodels.JobMatrix{
+		Axes:    map[string][]string{"os": {"linux"}},
+		Exclude: []map[string]string{{"os": "linux"}},
+	})
+	assert.Error(t, err)
+}
+
+func TestLintJobMatrix(t *testing.T) {
+	assert.NoError(t, lintJobMatrix(nil))
+	assert.Error(t, lintJobMatrix(&commonmodels.JobMatrix{Axes: map[string][]string{"go-version": {"1.21"}}}))
+	assert.Error(t, lintJobMatrix(&commonmodels.JobMatrix{Axes: map[string][]string{"go": {}}}))
+}
+
+func TestJobMatrixKeys(t *testing.T) {
+	targets, err := expandJobMatrixTargets(1, &commonmodels.JobMatrix{
+		Axes: map[string][]string{
+			"go":   {"1.21"},
+			"arch": {"amd64", "arm64"},
+		},
+	})
+	assert.NoError(t, err)
+
+	keys := map[string]bool{}
+	for _, target := range targets {
+		keys[genJobKey("build", append([]string{"svc", "module"}, jobMatrixSuffix(target.values)...)...)] = true
+	}
+	assert.Equal(t, map[string]bool{
+		"build.svc.module.amd64.1-21": true,
+		"build.svc.module.arm64.1-21": true,
+	}, keys)
+
+	assert.Empty(t, jobMatrixSuffix(nil))
+	assert.Equal(t, "build.svc.module", genJobKey("build", append([]string{"svc", "module"}, jobMatrixSuffix(nil)...)...))
+}
+
+func TestLintMatrixSuffixes(t *testing.T) {
+	assert.NoError(t, lintJobMatrix(&commonmodels.JobMatrix{Axes: map[string][]string{"platform": {"linux/amd64", "linux/arm64"}}}))
+
+	err := lintJobMatrix(&commonmodels.JobMatrix{Axes: map[string][]string{"platform": {"linux/amd64", "linux-amd64"}}})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "same job key")
+	}
+
+	// the job keys of x=a-b,y=c and x=a,y=b-c differ but their image tags are both suffixed with a-b-c
+	err = lintJobMatrix(&commonmodels.JobMatrix{Axes: map[string][]string{
+		"x": {"a-b", "a"},
+		"y": {"c", "b-c"},
+	}})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "same image tag")
+	}
+
+	_, err = expandJobMatrixTargets(1, &commonmodels.JobMatrix{Axes: map[string][]string{"platform": {"linux/amd64", "linux-amd64"}}})
+	assert.Error(t, err)
+}
+
+func TestMatrixJobKeys(t *testing.T) {
+	keys, err := matrixJobKeys("test.unit", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"test.unit"}, keys)
+
+	keys, err = matrixJobKeys("test.unit", &commonmodels.JobMatrix{Axes: map[string][]string{"go": {"1.21", "1.22"}}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"test.unit.1-21", "test.unit.1-22"}, keys)
+}
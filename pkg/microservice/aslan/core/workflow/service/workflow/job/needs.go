@@ -0,0 +1,107 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"fmt"
+	"strings"
+
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+)
+
+// ValidateJobNeeds checks the job dependencies declared by needs:
+// the needed job must exist in the current or a previous stage, and the dependency graph must not contain a cycle.
+// needs can not be used together with stage manual execution since the stages are no longer run one by one.
+func ValidateJobNeeds(workflow *commonmodels.WorkflowV4) error {
+	stageIndex := make(map[string]int)
+	needsMap := make(map[string][]string)
+	hasNeeds := false
+	for i, stage := range workflow.Stages {
+		for _, job := range stage.Jobs {
+			if JobSkiped(job) {
+				continue
+			}
+			stageIndex[job.Name] = i
+			if len(job.Needs) > 0 && stage.Finally {
+				return fmt.Errorf("job %s in the finally stage can not use needs", job.Name)
+			}
+			if len(job.Needs) > 0 {
+				needsMap[job.Name] = job.Needs
+				hasNeeds = true
+			}
+		}
+	}
+	if !hasNeeds {
+		return nil
+	}
+
+	for _, stage := range workflow.Stages {
+		if stage.ManualExec != nil && stage.ManualExec.Enabled {
+			return fmt.Errorf("stage %s: job needs can not be used with stage manual execution", stage.Name)
+		}
+	}
+
+	for jobName, needs := range needsMap {
+		for _, need := range needs {
+			if need == jobName {
+				return fmt.Errorf("job %s can not need itself", jobName)
+			}
+			index, ok := stageIndex[need]
+			if !ok {
+				return fmt.Errorf("job %s needs job %s which does not exist or is skipped", jobName, need)
+			}
+			if index > stageIndex[jobName] {
+				return fmt.Errorf("job %s needs job %s in a later stage", jobName, need)
+			}
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int)
+	var path []string
+	var visit func(jobName string) error
+	visit = func(jobName string) error {
+		switch state[jobName] {
+		case visiting:
+			return fmt.Errorf("job needs contain a cycle: %s -> %s", strings.Join(path, " -> "), jobName)
+		case visited:
+			return nil
+		}
+		state[jobName] = visiting
+		path = append(path, jobName)
+		for _, need := range needsMap[jobName] {
+			if err := visit(need); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[jobName] = visited
+		return nil
+	}
+	for _, stage := range workflow.Stages {
+		for _, job := range stage.Jobs {
+			if err := visit(job.Name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
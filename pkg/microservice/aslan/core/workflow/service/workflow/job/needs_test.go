@@ -0,0 +1,111 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+)
+
+func TestValidateJobNeeds(t *testing.T) {
+	tests := []struct {
+		name    string
+		stages  []*commonmodels.WorkflowStage
+		wantErr string
+	}{
+		{
+			name: "need on a job in the same or a previous stage",
+			stages: []*commonmodels.WorkflowStage{
+				{Name: "build", Parallel: true, Jobs: []*commonmodels.Job{{Name: "build-a"}, {Name: "build-b", Needs: []string{"build-a"}}}},
+				{Name: "deploy", Jobs: []*commonmodels.Job{{Name: "deploy", Needs: []string{"build-a"}}}},
+			},
+		},
+		{
+			name: "cycle",
+			stages: []*commonmodels.WorkflowStage{
+				{Name: "build", Parallel: true, Jobs: []*commonmodels.Job{
+					{Name: "build-a", Needs: []string{"build-b"}},
+					{Name: "build-b", Needs: []string{"build-a"}},
+				}},
+			},
+			wantErr: "cycle",
+		},
+		{
+			name: "need on a job in a later stage",
+			stages: []*commonmodels.WorkflowStage{
+				{Name: "build", Jobs: []*commonmodels.Job{{Name: "build", Needs: []string{"deploy"}}}},
+				{Name: "deploy", Jobs: []*commonmodels.Job{{Name: "deploy"}}},
+			},
+			wantErr: "later stage",
+		},
+		{
+			name: "self need",
+			stages: []*commonmodels.WorkflowStage{
+				{Name: "build", Jobs: []*commonmodels.Job{{Name: "build", Needs: []string{"build"}}}},
+			},
+			wantErr: "itself",
+		},
+		{
+			name: "unknown job",
+			stages: []*commonmodels.WorkflowStage{
+				{Name: "build", Jobs: []*commonmodels.Job{{Name: "build"}}},
+				{Name: "deploy", Jobs: []*commonmodels.Job{{Name: "deploy", Needs: []string{"unknown"}}}},
+			},
+			wantErr: "does not exist",
+		},
+		{
+			name: "need on a skipped job",
+			stages: []*commonmodels.WorkflowStage{
+				{Name: "build", Jobs: []*commonmodels.Job{{Name: "build", Skipped: true}}},
+				{Name: "deploy", Jobs: []*commonmodels.Job{{Name: "deploy", Needs: []string{"build"}}}},
+			},
+			wantErr: "does not exist",
+		},
+		{
+			name: "needs in the finally stage",
+			stages: []*commonmodels.WorkflowStage{
+				{Name: "build", Jobs: []*commonmodels.Job{{Name: "build"}}},
+				{Name: "cleanup", Finally: true, Jobs: []*commonmodels.Job{{Name: "cleanup", Needs: []string{"build"}}}},
+			},
+			wantErr: "finally",
+		},
+		{
+			name: "needs with stage manual execution",
+			stages: []*commonmodels.WorkflowStage{
+				{Name: "build", Jobs: []*commonmodels.Job{{Name: "build"}}},
+				{Name: "deploy", ManualExec: &commonmodels.ManualExec{Enabled: true}, Jobs: []*commonmodels.Job{{Name: "deploy", Needs: []string{"build"}}}},
+			},
+			wantErr: "manual execution",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateJobNeeds(&commonmodels.WorkflowV4{Stages: tt.stages})
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
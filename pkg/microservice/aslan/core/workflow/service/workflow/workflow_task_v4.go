@@ -17,7 +17,6 @@ limitations under the License.
 package workflow
 
 import (
-	"database/sql"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -54,12 +53,14 @@ import (
 	"github.com/koderover/zadig/v2/pkg/tool/nacos"
 	s3tool "github.com/koderover/zadig/v2/pkg/tool/s3"
 	"github.com/koderover/zadig/v2/pkg/tool/sonar"
+	"github.com/koderover/zadig/v2/pkg/tool/sqldb"
 	workflowtool "github.com/koderover/zadig/v2/pkg/tool/workflow"
 	"github.com/koderover/zadig/v2/pkg/types"
 	jobspec "github.com/koderover/zadig/v2/pkg/types/job"
 	"github.com/koderover/zadig/v2/pkg/types/step"
 	stepspec "github.com/koderover/zadig/v2/pkg/types/step"
 	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.uber.org/zap"
 	"gorm.io/gorm/utils"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -88,6 +89,8 @@ type WorkflowTaskPreview struct {
 	ProjectName         string                `bson:"project_name"              json:"project_key"`
 	Error               string                `bson:"error,omitempty"           json:"error,omitempty"`
 	IsRestart           bool                  `bson:"is_restart"                json:"is_restart"`
+	RetryFromTaskID     int64                 `bson:"retry_from_task_id"        json:"retry_from_task_id,omitempty"`
+	RetryFromJob        string                `bson:"retry_from_job"            json:"retry_from_job,omitempty"`
 	Debug               bool                  `bson:"debug"                     json:"debug"`
 	ApprovalTicketID    string                `bson:"approval_ticket_id"        json:"approval_ticket_id"`
 	ApprovalID          string                `bson:"approval_id"               json:"approval_id"`
@@ -102,6 +105,18 @@ type StageTaskPreview struct {
 	ManualExec *commonmodels.ManualExec `bson:"manual_exec"      json:"manual_exec"`
 	Jobs       []*JobTaskPreview        `bson:"jobs"          json:"jobs"`
 	Error      string                   `bson:"error" json:"error""`
+	// Matrices is the aggregated result of the matrix jobs in the stage
+	Matrices []*MatrixJobPreview `bson:"matrices"      json:"matrices,omitempty"`
+}
+
+type MatrixJobPreview struct {
+	OriginName string        `bson:"origin_name"   json:"origin_name"`
+	Status     config.Status `bson:"status"        json:"status"`
+	StartTime  int64         `bson:"start_time"    json:"start_time,omitempty"`
+	EndTime    int64         `bson:"end_time"      json:"end_time,omitempty"`
+	Total      int           `bson:"total"         json:"total"`
+	Passed     int           `bson:"passed"        json:"passed"`
+	Failed     int           `bson:"failed"        json:"failed"`
 }
 
 type JobTaskPreview struct {
@@ -125,6 +140,8 @@ type JobTaskPreview struct {
 	RetryCount           int                          `bson:"retry_count"           yaml:"retry_count"               json:"retry_count"`
 	// JobInfo contains the fields that make up the job task name, for frontend display
 	JobInfo interface{} `bson:"job_info" json:"job_info"`
+	// Matrix is the matrix combination of the job task
+	Matrix map[string]string `bson:"matrix" json:"matrix,omitempty"`
 }
 
 type ZadigBuildJobSpec struct {
@@ -538,7 +555,11 @@ func CreateWorkflowTaskV4(args *CreateWorkflowTaskV4Args, workflow *commonmodels
 		}
 
 		workflowTask.Hash = originalWorkflow.Hash
+		// the priority is taken from the saved workflow, the workflow in the request can not raise it
+		workflowTask.Priority = originalWorkflow.Priority
 	} else {
+		// the workflows of the other task types are generated by the server
+		workflowTask.Priority = workflow.Priority
 		if workflow.Disabled {
 			return resp, e.ErrCreateTask.AddDesc("workflow is disabled")
 		}
@@ -605,9 +626,13 @@ func CreateWorkflowTaskV4(args *CreateWorkflowTaskV4Args, workflow *commonmodels
 
 	for _, stage := range workflow.Stages {
 		stageTask := &commonmodels.StageTask{
-			Name:       stage.Name,
-			Parallel:   stage.Parallel,
-			ManualExec: stage.ManualExec,
+			Name:                 stage.Name,
+			Parallel:             stage.Parallel,
+			ManualExec:           stage.ManualExec,
+			When:                 stage.When,
+			Timeout:              stage.Timeout,
+			Finally:              stage.Finally,
+			FinallyAffectsStatus: stage.FinallyAffectsStatus,
 		}
 		for _, job := range stage.Jobs {
 			if jobctl.JobSkiped(job) {
@@ -657,6 +682,8 @@ func CreateWorkflowTaskV4(args *CreateWorkflowTaskV4Args, workflow *commonmodels
 			}
 			// add breakpoint_before when workflowTask is debug mode
 			for _, jobTask := range jobs {
+				jobTask.When = job.When
+				jobTask.Needs = job.Needs
 				switch config.JobType(jobTask.JobType) {
 				case config.JobFreestyle, config.JobZadigTesting, config.JobZadigBuild, config.JobZadigScanning:
 					if workflowTask.IsDebug {
@@ -672,6 +699,14 @@ func CreateWorkflowTaskV4(args *CreateWorkflowTaskV4Args, workflow *commonmodels
 		}
 	}
 
+	if workflow.ConcurrencyGroup != nil && workflow.ConcurrencyGroup.Key != "" {
+		// the key has been rendered with the workflow params together with the jobs
+		if strings.Contains(workflow.ConcurrencyGroup.Key, "{{.") {
+			return resp, e.ErrCreateTask.AddDesc(fmt.Sprintf("concurrency group %s is not fully rendered", workflow.ConcurrencyGroup.Key))
+		}
+		workflowTask.ConcurrencyGroup = workflow.ConcurrencyGroup.Key
+	}
+
 	if err := workflowTaskLint(workflowTask, log); err != nil {
 		return resp, err
 	}
@@ -702,6 +737,10 @@ func CreateWorkflowTaskV4(args *CreateWorkflowTaskV4Args, workflow *commonmodels
 		log.Errorf("send workflow task notification failed, error: %v", err)
 	}
 
+	if workflowTask.ConcurrencyGroup != "" && workflow.ConcurrencyGroup.CancelInProgress {
+		cancelConcurrencyGroupTasks(workflowTask, log)
+	}
+
 	if err := workflowcontroller.CreateTask(workflowTask); err != nil {
 		log.Errorf("create workflow task error: %v", err)
 		return resp, e.ErrCreateTask.AddDesc(err.Error())
@@ -717,6 +756,23 @@ func CreateWorkflowTaskV4(args *CreateWorkflowTaskV4Args, workflow *commonmodels
 	return resp, nil
 }
 
+// cancelConcurrencyGroupTasks cancels the unfinished tasks in the same concurrency group of the project as the new task,
+// no matter how they are triggered.
+func cancelConcurrencyGroupTasks(workflowTask *commonmodels.WorkflowTask, log *zap.SugaredLogger) {
+	for _, t := range workflowcontroller.ListTasks() {
+		if t.ProjectName != workflowTask.ProjectName || t.ConcurrencyGroup != workflowTask.ConcurrencyGroup {
+			continue
+		}
+		if t.WorkflowName == workflowTask.WorkflowName && t.TaskID == workflowTask.TaskID {
+			continue
+		}
+		log.Infof("cancel task %s:%d in concurrency group %s by the new task %s:%d", t.WorkflowName, t.TaskID, t.ConcurrencyGroup, workflowTask.WorkflowName, workflowTask.TaskID)
+		if err := workflowcontroller.CancelWorkflowTask(workflowTask.TaskCreator, t.WorkflowName, t.TaskID, log); err != nil {
+			log.Errorf("failed to cancel task %s:%d in concurrency group %s, error: %v", t.WorkflowName, t.TaskID, t.ConcurrencyGroup, err)
+		}
+	}
+}
+
 func GetManualExecWorkflowTaskV4Info(workflowName string, taskID int64, logger *zap.SugaredLogger) (*commonmodels.WorkflowV4, error) {
 	originWorkflow, err := commonrepo.NewWorkflowV4Coll().Find(workflowName)
 	if err != nil {
@@ -855,6 +911,185 @@ func RetryWorkflowTaskV4(workflowName string, taskID int64, logger *zap.SugaredL
 	return nil
 }
 
+// RetryWorkflowTaskV4FromJob creates a new task from a finished one, the jobs before the given job keep their results,
+// the given job, all the jobs after it and all the jobs not passed are run again with the original params and global context.
+func RetryWorkflowTaskV4FromJob(workflowName string, taskID int64, jobName, username, userID string, logger *zap.SugaredLogger) (*CreateTaskV4Resp, error) {
+	task, err := commonrepo.NewworkflowTaskv4Coll().Find(workflowName, taskID)
+	if err != nil {
+		logger.Errorf("find workflowTaskV4 error: %s", err)
+		return nil, e.ErrGetTask.AddErr(err)
+	}
+	switch task.Status {
+	case config.StatusFailed, config.StatusTimeout, config.StatusCancelled, config.StatusReject:
+	default:
+		return nil, errors.New("工作流任务状态无法重试")
+	}
+	if task.OriginWorkflowArgs == nil || task.OriginWorkflowArgs.Stages == nil || task.WorkflowArgs == nil {
+		return nil, errors.New("工作流任务数据异常, 无法重试")
+	}
+
+	workflow, err := commonrepo.NewWorkflowV4Coll().Find(workflowName)
+	if err != nil {
+		logger.Errorf("find workflowV4 error: %s", err)
+		return nil, e.ErrFindWorkflow.AddErr(err)
+	}
+	if workflow.Disabled {
+		return nil, e.ErrCreateTask.AddDesc("workflow is disabled")
+	}
+
+	retryJobs, err := getRetryJobTasks(task.Stages, jobName)
+	if err != nil {
+		return nil, e.ErrInvalidParam.AddErr(err)
+	}
+
+	newTask := &commonmodels.WorkflowTask{}
+	if err := commonmodels.IToi(task, newTask); err != nil {
+		return nil, e.ErrCreateTask.AddErr(fmt.Errorf("clone workflow task error: %v", err))
+	}
+
+	// the job specs are generated with the original task id, so that the artifacts of the passed jobs can still be referred
+	jobTaskMap := make(map[string]*commonmodels.JobTask)
+	for _, stage := range newTask.WorkflowArgs.Stages {
+		for _, job := range stage.Jobs {
+			if job.Skipped {
+				continue
+			}
+			jobCtl, err := jobctl.InitJobCtl(job, newTask.WorkflowArgs)
+			if err != nil {
+				return nil, e.ErrCreateTask.AddErr(fmt.Errorf("init jobCtl %s error: %s", job.Name, err))
+			}
+			jobTasks, err := jobCtl.ToJobs(taskID)
+			if err != nil {
+				return nil, e.ErrCreateTask.AddErr(fmt.Errorf("job %s toJobs error: %s", job.Name, err))
+			}
+			for _, jobTask := range jobTasks {
+				jobTaskMap[jobTask.Name] = jobTask
+			}
+		}
+	}
+
+	if err := resetRetryJobTasks(newTask.Stages, retryJobs, jobTaskMap); err != nil {
+		return nil, e.ErrCreateTask.AddErr(err)
+	}
+
+	nextTaskID, err := commonrepo.NewCounterColl().GetNextSeq(fmt.Sprintf(setting.WorkflowTaskV4Fmt, workflowName))
+	if err != nil {
+		logger.Errorf("Counter.GetNextSeq error: %v", err)
+		return nil, e.ErrGetCounter.AddDesc(err.Error())
+	}
+
+	newTask.ID = primitive.NilObjectID
+	newTask.TaskID = nextTaskID
+	newTask.TaskCreator = username
+	newTask.TaskCreatorID = userID
+	newTask.TaskRevoker = username
+	newTask.TaskRevokerID = userID
+	newTask.TaskCreatorEmail = ""
+	newTask.TaskCreatorPhone = ""
+	if userID != "" {
+		userInfo, err := user.New().GetUserByID(userID)
+		if err != nil || userInfo == nil {
+			return nil, errors.New("failed to get user info by uid")
+		}
+		newTask.TaskCreatorEmail = userInfo.Email
+		newTask.TaskCreatorPhone = userInfo.Phone
+	}
+	newTask.CreateTime = time.Now().Unix()
+	newTask.StartTime = time.Now().Unix()
+	newTask.EndTime = 0
+	newTask.Status = config.StatusCreated
+	newTask.Error = ""
+	newTask.Reverted = false
+	newTask.IsRestart = true
+	newTask.RetryFromTaskID = taskID
+	newTask.RetryFromJob = jobName
+	if newTask.GlobalContext == nil {
+		newTask.GlobalContext = make(map[string]string)
+	}
+
+	if err := instantmessage.NewWeChatClient().SendWorkflowTaskNotifications(newTask); err != nil {
+		log.Errorf("send workflow task notification failed, error: %v", err)
+	}
+
+	if err := workflowcontroller.CreateTask(newTask); err != nil {
+		log.Errorf("create workflow task error: %v", err)
+		return nil, e.ErrCreateTask.AddDesc(err.Error())
+	}
+
+	return &CreateTaskV4Resp{
+		ProjectName:  newTask.ProjectName,
+		WorkflowName: newTask.WorkflowName,
+		TaskID:       newTask.TaskID,
+	}, nil
+}
+
+// getRetryJobTasks returns the names of the job tasks to run again when retrying from the job:
+// the job tasks of the job, the job tasks after them in stage order, and all the job tasks not passed.
+// the job can be a job task name, or the name of the job which may be split into multiple job tasks.
+func getRetryJobTasks(stages []*commonmodels.StageTask, jobName string) (sets.String, error) {
+	resp := sets.NewString()
+	found := false
+	for _, stage := range stages {
+		for _, jobTask := range stage.Jobs {
+			if jobTask.Name == jobName || jobTask.OriginName == jobName {
+				found = true
+			}
+			if found || (jobTask.Status != config.StatusPassed && jobTask.Status != config.StatusSkipped) {
+				resp.Insert(jobTask.Name)
+			}
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("job %s not found in the workflow task", jobName)
+	}
+	return resp, nil
+}
+
+// resetRetryJobTasks clears the results of the job tasks to retry and of their stages, and regenerates their specs.
+// the other job tasks keep their status and outputs.
+func resetRetryJobTasks(stages []*commonmodels.StageTask, retryJobs sets.String, jobTaskMap map[string]*commonmodels.JobTask) error {
+	for _, stage := range stages {
+		retryStage := false
+		for _, jobTask := range stage.Jobs {
+			if !retryJobs.Has(jobTask.Name) {
+				continue
+			}
+			retryStage = true
+			jobTask.Status = ""
+			jobTask.StartTime = 0
+			jobTask.EndTime = 0
+			jobTask.Error = ""
+			jobTask.SkipReason = ""
+			jobTask.RetryCount = 0
+			jobTask.Reverted = false
+			jobTask.ErrorHandlerUserID = ""
+			jobTask.ErrorHandlerUserName = ""
+			t, ok := jobTaskMap[jobTask.Name]
+			if !ok {
+				return fmt.Errorf("failed to get jobTask %s origin spec", jobTask.Name)
+			}
+			jobTask.Spec = t.Spec
+		}
+		if !retryStage {
+			continue
+		}
+		stage.Status = ""
+		stage.StartTime = 0
+		stage.EndTime = 0
+		stage.Error = ""
+	}
+	return nil
+}
+
+// checkSQLJobRevertible refuses to revert a dry run sql job, its statements were rolled back
+// so running the rollback sql would change the database for real.
+func checkSQLJobRevertible(spec *commonmodels.JobTaskSQLSpec) error {
+	if spec.DryRun {
+		return fmt.Errorf("sql job in dry run mode can not be reverted, the database was not changed")
+	}
+	return nil
+}
+
 type ManualExecWorkflowTaskV4Request struct {
 	Jobs []*commonmodels.Job `json:"jobs"`
 }
@@ -1207,6 +1442,9 @@ func RevertWorkflowTaskV4Job(workflowName, jobName string, taskID int64, input i
 						logger.Error(err)
 						return fmt.Errorf("failed to decode nacos job spec, error: %s", err)
 					}
+					if err := checkSQLJobRevertible(jobTaskSpec); err != nil {
+						return err
+					}
 					inputSpec := new(SQLRevertInput)
 					err = commonmodels.IToi(input, inputSpec)
 					if err != nil {
@@ -1233,21 +1471,24 @@ func RevertWorkflowTaskV4Job(workflowName, jobName string, taskID int64, input i
 						Results: make([]*commonmodels.SQLExecResult, 0),
 					}
 
-					db, err := sql.Open("mysql", fmt.Sprintf("%s:%s@tcp(%s:%s)/?charset=utf8&multiStatements=true", info.Username, info.Password, info.Host, info.Port))
+					driver, err := sqldb.GetDriver(string(info.Type))
+					if err != nil {
+						return fmt.Errorf("failed to run rollback sql, error: %s", err)
+					}
+					db, err := driver.Open(info.Host, info.Port, info.Username, info.Password, info.Database, info.SSLMode)
 					if err != nil {
 						return errors.Errorf("failed to run rollback sql, connect db error: %v", err)
 					}
 					defer db.Close()
 
-					sqls := strings.SplitAfter(inputSpec.SQL, ";")
+					sqls, err := driver.SplitStatements(inputSpec.SQL)
+					if err != nil {
+						return fmt.Errorf("failed to split rollback sql, error: %s", err)
+					}
 					for _, sql := range sqls {
-						if sql == "" {
-							continue
-						}
-
 						execResult := &commonmodels.SQLExecResult{}
 
-						execResult.SQL = strings.TrimSpace(sql)
+						execResult.SQL = sql
 						execResult.Status = setting.SQLExecStatusNotExec
 
 						revertTaskSpec.Results = append(revertTaskSpec.Results, execResult)
@@ -1258,6 +1499,7 @@ func RevertWorkflowTaskV4Job(workflowName, jobName string, taskID int64, input i
 						result, err := db.Exec(execResult.SQL)
 						if err != nil {
 							execResult.Status = setting.SQLExecStatusFailed
+							execResult.Error = err.Error()
 							_, err = commonrepo.NewWorkflowTaskRevertColl().Create(&commonmodels.WorkflowTaskRevert{
 								TaskID:        taskID,
 								WorkflowName:  workflowName,
@@ -1601,6 +1843,8 @@ func GetWorkflowTaskV4(workflowName string, taskID int64, logger *zap.SugaredLog
 		EndTime:             task.EndTime,
 		Error:               task.Error,
 		IsRestart:           task.IsRestart,
+		RetryFromTaskID:     task.RetryFromTaskID,
+		RetryFromJob:        task.RetryFromJob,
 		Debug:               task.IsDebug,
 		ApprovalTicketID:    task.ApprovalTicketID,
 		ApprovalID:          task.ApprovalID,
@@ -1616,11 +1860,71 @@ func GetWorkflowTaskV4(workflowName string, taskID int64, logger *zap.SugaredLog
 			ManualExec: stage.ManualExec,
 			Jobs:       jobsToJobPreviews(stage.Jobs, task.GlobalContext, timeNow, task.ProjectName),
 			Error:      stage.Error,
+			Matrices:   jobsToMatrixPreviews(stage.Jobs),
 		})
 	}
 	return resp, nil
 }
 
+// jobsToMatrixPreviews aggregates the job tasks expanded from the same matrix job into a single status
+func jobsToMatrixPreviews(jobs []*commonmodels.JobTask) []*MatrixJobPreview {
+	statusRank := map[config.Status]int{
+		config.StatusCancelled: 7,
+		config.StatusTimeout:   6,
+		config.StatusFailed:    5,
+		config.StatusPause:     4,
+		config.StatusReject:    3,
+		config.StatusPassed:    2,
+		config.StatusUnstable:  1,
+		config.StatusSkipped:   0,
+	}
+
+	resp := make([]*MatrixJobPreview, 0)
+	matrixMap := make(map[string]*MatrixJobPreview)
+	running := make(map[string]bool)
+	for _, job := range jobs {
+		if job.Matrix == nil {
+			continue
+		}
+		preview, ok := matrixMap[job.OriginName]
+		if !ok {
+			preview = &MatrixJobPreview{OriginName: job.OriginName}
+			matrixMap[job.OriginName] = preview
+			resp = append(resp, preview)
+		}
+		preview.Total++
+		if job.StartTime != 0 && (preview.StartTime == 0 || job.StartTime < preview.StartTime) {
+			preview.StartTime = job.StartTime
+		}
+		if job.EndTime > preview.EndTime {
+			preview.EndTime = job.EndTime
+		}
+
+		rank, finished := statusRank[job.Status]
+		switch {
+		case job.Status == "" || job.Status == config.StatusCreated:
+			continue
+		case !finished:
+			running[job.OriginName] = true
+			continue
+		case job.Status == config.StatusPassed || job.Status == config.StatusUnstable || job.Status == config.StatusSkipped:
+			preview.Passed++
+		default:
+			preview.Failed++
+		}
+		if currentRank, ok := statusRank[preview.Status]; !ok || rank > currentRank {
+			preview.Status = job.Status
+		}
+	}
+	for _, preview := range resp {
+		if running[preview.OriginName] {
+			preview.Status = config.StatusRunning
+			preview.EndTime = 0
+		}
+	}
+	return resp
+}
+
 func ApproveStage(workflowName, jobName, userName, userID, comment string, taskID int64, approve bool, logger *zap.SugaredLogger) error {
 	if workflowName == "" || jobName == "" || taskID == 0 {
 		errMsg := fmt.Sprintf("can not find approved workflow: %s, taskID: %d,jobName: %s", workflowName, taskID, jobName)
@@ -1746,6 +2050,9 @@ func jobsToJobPreviews(jobs []*commonmodels.JobTask, context map[string]string,
 			ErrorHandlerUserName: job.ErrorHandlerUserName,
 			RetryCount:           job.RetryCount,
 		}
+		if job.Matrix != nil {
+			jobPreview.Matrix = job.Matrix.Values
+		}
 		switch job.JobType {
 		case string(config.JobFreestyle):
 			fallthrough
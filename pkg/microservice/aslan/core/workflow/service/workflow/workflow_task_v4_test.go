@@ -0,0 +1,125 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+)
+
+var _ = Describe("Testing retry workflow task from job", func() {
+	var stages []*commonmodels.StageTask
+
+	BeforeEach(func() {
+		stages = []*commonmodels.StageTask{
+			{
+				Name:   "build",
+				Status: config.StatusPassed,
+				Jobs: []*commonmodels.JobTask{
+					{Name: "build-0", OriginName: "build", Status: config.StatusPassed, EndTime: 1, Spec: "old", Outputs: []*commonmodels.Output{{Name: "IMAGE"}}},
+					{Name: "build-1", OriginName: "build", Status: config.StatusPassed, EndTime: 1, Spec: "old", Outputs: []*commonmodels.Output{{Name: "IMAGE"}}},
+				},
+			},
+			{
+				Name:   "test",
+				Status: config.StatusFailed,
+				Jobs: []*commonmodels.JobTask{
+					{Name: "test", Status: config.StatusPassed, EndTime: 2, Spec: "old"},
+					{Name: "scan", Status: config.StatusFailed, EndTime: 2, Error: "failed", Spec: "old"},
+				},
+			},
+			{
+				Name:   "deploy",
+				Status: config.StatusCancelled,
+				Jobs: []*commonmodels.JobTask{
+					{Name: "deploy", Status: config.StatusCancelled, Spec: "old"},
+					{Name: "notify", Status: config.StatusSkipped, Spec: "old"},
+				},
+			},
+		}
+	})
+
+	Context("getRetryJobTasks", func() {
+		It("should retry the passed job, the jobs after it and the jobs not passed", func() {
+			retryJobs, err := getRetryJobTasks(stages, "test")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(retryJobs.List()).To(ConsistOf("test", "scan", "deploy", "notify"))
+		})
+		It("should retry all the job tasks split from the job", func() {
+			retryJobs, err := getRetryJobTasks(stages, "build")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(retryJobs.List()).To(ConsistOf("build-0", "build-1", "test", "scan", "deploy", "notify"))
+		})
+		It("should retry the failed and cancelled jobs when retrying from a later job", func() {
+			retryJobs, err := getRetryJobTasks(stages, "notify")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(retryJobs.List()).To(ConsistOf("scan", "deploy", "notify"))
+		})
+		It("should raise error for unknown job", func() {
+			_, err := getRetryJobTasks(stages, "unknown")
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+
+	Context("resetRetryJobTasks", func() {
+		It("should reset the retried jobs and keep the outputs of the passed jobs", func() {
+			retryJobs, err := getRetryJobTasks(stages, "test")
+			Expect(err).ShouldNot(HaveOccurred())
+			jobTaskMap := map[string]*commonmodels.JobTask{}
+			for _, name := range retryJobs.List() {
+				jobTaskMap[name] = &commonmodels.JobTask{Name: name, Spec: "new"}
+			}
+
+			Expect(resetRetryJobTasks(stages, retryJobs, jobTaskMap)).To(Succeed())
+			Expect(stages[0].Status).To(Equal(config.StatusPassed))
+			for _, jobTask := range stages[0].Jobs {
+				Expect(jobTask.Status).To(Equal(config.StatusPassed))
+				Expect(jobTask.EndTime).To(Equal(int64(1)))
+				Expect(jobTask.Spec).To(Equal("old"))
+				Expect(jobTask.Outputs).To(HaveLen(1))
+			}
+			for _, stage := range stages[1:] {
+				Expect(stage.Status).To(BeEmpty())
+				for _, jobTask := range stage.Jobs {
+					Expect(jobTask.Status).To(BeEmpty())
+					Expect(jobTask.EndTime).To(BeZero())
+					Expect(jobTask.Error).To(BeEmpty())
+					Expect(jobTask.Spec).To(Equal("new"))
+				}
+			}
+		})
+		It("should raise error if the spec of a retried job is missing", func() {
+			retryJobs, err := getRetryJobTasks(stages, "deploy")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resetRetryJobTasks(stages, retryJobs, map[string]*commonmodels.JobTask{})).ShouldNot(Succeed())
+		})
+	})
+})
+
+var _ = Describe("Testing revert workflow task job", func() {
+	Context("checkSQLJobRevertible", func() {
+		It("should refuse to revert a dry run sql job", func() {
+			Expect(checkSQLJobRevertible(&commonmodels.JobTaskSQLSpec{DryRun: true})).ShouldNot(Succeed())
+		})
+		It("should revert a sql job which changed the database", func() {
+			Expect(checkSQLJobRevertible(&commonmodels.JobTaskSQLSpec{})).To(Succeed())
+		})
+	})
+})
@@ -77,6 +77,8 @@ import (
 	"github.com/koderover/zadig/v2/pkg/tool/kube/serializer"
 	"github.com/koderover/zadig/v2/pkg/tool/lark"
 	"github.com/koderover/zadig/v2/pkg/tool/log"
+	"github.com/koderover/zadig/v2/pkg/tool/sqldb"
+	workflowtool "github.com/koderover/zadig/v2/pkg/tool/workflow"
 	"github.com/koderover/zadig/v2/pkg/types"
 )
 
@@ -1005,6 +1007,13 @@ func LintWorkflowV4(workflow *commonmodels.WorkflowV4, logger *zap.SugaredLogger
 			return e.ErrUpsertWorkflow.AddDesc("common workflow only support k8s and helm project")
 		}
 	}
+	if workflow.Timeout < 0 {
+		return e.ErrUpsertWorkflow.AddDesc("workflow timeout can not be negative")
+	}
+	if err := lintConcurrencyGroup(workflow); err != nil {
+		logger.Errorf("lint concurrency group failed: %v", err)
+		return e.ErrUpsertWorkflow.AddErr(err)
+	}
 	stageNameMap := make(map[string]bool)
 	jobNameMap := make(map[string]string)
 
@@ -1013,7 +1022,7 @@ func LintWorkflowV4(workflow *commonmodels.WorkflowV4, logger *zap.SugaredLogger
 		logger.Errorf("reg compile failed: %v", err)
 		return e.ErrUpsertWorkflow.AddErr(err)
 	}
-	for _, stage := range workflow.Stages {
+	for i, stage := range workflow.Stages {
 		if !commonutil.ValidateZadigProfessionalLicense(licenseStatus) {
 			if stage.ManualExec != nil && stage.ManualExec.Enabled {
 				return e.ErrLicenseInvalid.AddDesc("基础版不支持工作流手动执行")
@@ -1026,6 +1035,21 @@ func LintWorkflowV4(workflow *commonmodels.WorkflowV4, logger *zap.SugaredLogger
 			logger.Errorf("duplicated stage name: %s", stage.Name)
 			return e.ErrUpsertWorkflow.AddDesc(fmt.Sprintf("duplicated stage name: %s", stage.Name))
 		}
+		if err := workflowtool.ValidateCondition(stage.When); err != nil {
+			logger.Errorf("lint stage %s condition failed: %v", stage.Name, err)
+			return e.ErrUpsertWorkflow.AddDesc(fmt.Sprintf("stage %s: %s", stage.Name, err))
+		}
+		if stage.Timeout < 0 {
+			return e.ErrUpsertWorkflow.AddDesc(fmt.Sprintf("stage %s: timeout can not be negative", stage.Name))
+		}
+		if stage.Finally {
+			if i != len(workflow.Stages)-1 {
+				return e.ErrUpsertWorkflow.AddDesc(fmt.Sprintf("stage %s: the finally stage must be the last stage", stage.Name))
+			}
+			if stage.ManualExec != nil && stage.ManualExec.Enabled {
+				return e.ErrUpsertWorkflow.AddDesc(fmt.Sprintf("stage %s: the finally stage can not be executed manually", stage.Name))
+			}
+		}
 		for _, job := range stage.Jobs {
 			if jobctl.JobSkiped(job) {
 				continue
@@ -1041,12 +1065,46 @@ func LintWorkflowV4(workflow *commonmodels.WorkflowV4, logger *zap.SugaredLogger
 				logger.Errorf("duplicated job name: %s", job.Name)
 				return e.ErrUpsertWorkflow.AddDesc(fmt.Sprintf("duplicated job name: %s", job.Name))
 			}
+			if err := workflowtool.ValidateCondition(job.When); err != nil {
+				logger.Errorf("lint job %s condition failed: %v", job.Name, err)
+				return e.ErrUpsertWorkflow.AddDesc(fmt.Sprintf("job %s: %s", job.Name, err))
+			}
 			if err := jobctl.LintJob(job, workflow); err != nil {
 				logger.Errorf("lint job %s failed: %v", job.Name, err)
 				return e.ErrUpsertWorkflow.AddErr(err)
 			}
 		}
 	}
+	if err := jobctl.LintWorkflowOutputs(workflow); err != nil {
+		logger.Errorf("lint workflow outputs failed: %v", err)
+		return e.ErrUpsertWorkflow.AddErr(err)
+	}
+	if err := jobctl.ValidateJobNeeds(workflow); err != nil {
+		logger.Errorf("lint job needs failed: %v", err)
+		return e.ErrUpsertWorkflow.AddErr(err)
+	}
+	return nil
+}
+
+var concurrencyGroupParamRegex = regexp.MustCompile(`{{\.workflow\.params\.([^}]+)}}`)
+
+// lintConcurrencyGroup checks the concurrency group key refers to the declared params only.
+func lintConcurrencyGroup(workflow *commonmodels.WorkflowV4) error {
+	if workflow.ConcurrencyGroup == nil {
+		return nil
+	}
+	if workflow.ConcurrencyGroup.Key == "" {
+		return fmt.Errorf("concurrency group key can not be empty")
+	}
+	params := sets.NewString()
+	for _, param := range workflow.Params {
+		params.Insert(param.Name)
+	}
+	for _, match := range concurrencyGroupParamRegex.FindAllStringSubmatch(workflow.ConcurrencyGroup.Key, -1) {
+		if !params.Has(match[1]) {
+			return fmt.Errorf("concurrency group key refers to undefined param %s", match[1])
+		}
+	}
 	return nil
 }
 
@@ -2959,11 +3017,29 @@ func ValidateSQL(_type config.DBInstanceType, sql string) error {
 	switch _type {
 	case config.DBInstanceTypeMySQL, config.DBInstanceTypeMariaDB:
 		return ValidateMySQL(sql)
+	case config.DBInstanceTypePostgreSQL:
+		return ValidatePostgreSQL(sql)
 	default:
 		return errors.Errorf("not supported db type: %s", _type)
 	}
 }
 
+// ValidatePostgreSQL only checks the statements can be split, the syntax is checked by the database when the job runs in dry-run mode.
+func ValidatePostgreSQL(sql string) error {
+	driver, err := sqldb.GetDriver(sqldb.TypePostgreSQL)
+	if err != nil {
+		return err
+	}
+	statements, err := driver.SplitStatements(sql)
+	if err != nil {
+		return errors.Errorf("parse sql statement error: %v", err)
+	}
+	if len(statements) == 0 {
+		return errors.New("no sql statement found")
+	}
+	return nil
+}
+
 func ValidateMySQL(sql string) error {
 	p := parser.New()
 
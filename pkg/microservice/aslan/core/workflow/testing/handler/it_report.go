@@ -88,3 +88,16 @@ func GetTestLocalTestSuite(c *gin.Context) {
 
 	ctx.Resp, ctx.RespErr = service.GetTestLocalTestSuite(c.Param("testName"), ctx.Logger)
 }
+
+func GetWorkflowV4TestCoverage(c *gin.Context) {
+	ctx := internalhandler.NewContext(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	taskID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		ctx.RespErr = e.ErrInvalidParam.AddDesc("invalid task id")
+		return
+	}
+
+	ctx.Resp, ctx.RespErr = service.GetWorkflowV4TestCoverage(c.Param("workflowName"), c.Param("jobName"), taskID, ctx.Logger)
+}
@@ -43,6 +43,7 @@ func (*Router) Inject(router *gin.RouterGroup) {
 	{
 		itReport.GET("/pipelines/:pipelineName/id/:id/names/:testName", GetLocalTestSuite)
 		itReport.GET("/workflowv4/:workflowName/id/:id/job/:jobName", GetWorkflowV4LocalTestSuite)
+		itReport.GET("/workflowv4/:workflowName/id/:id/job/:jobName/coverage", GetWorkflowV4TestCoverage)
 		itReport.GET("/workflow/:pipelineName/id/:id/names/:testName/service/:serviceName", GetWorkflowLocalTestSuite)
 		itReport.GET("/latest/service/:testName", GetTestLocalTestSuite)
 	}
@@ -57,6 +58,13 @@ func (*Router) Inject(router *gin.RouterGroup) {
 		tester.GET("", ListTestModules)
 		tester.GET("/:name", GetTestModule)
 		tester.DELETE("/:name", DeleteTestModule)
+
+		tester.GET("/:name/cases", ListTestCaseStats)
+		tester.GET("/:name/cases/history", ListTestCaseHistory)
+		tester.GET("/:name/quarantine", ListTestCaseQuarantines)
+		tester.POST("/:name/quarantine", CreateTestCaseQuarantine)
+		tester.DELETE("/:name/quarantine/:id", DeleteTestCaseQuarantine)
+		tester.GET("/:name/coverage", ListTestCoverages)
 	}
 
 	// ---------------------------------------------------------------------------------------
@@ -0,0 +1,243 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/workflow/testing/service"
+	internalhandler "github.com/koderover/zadig/v2/pkg/shared/handler"
+	e "github.com/koderover/zadig/v2/pkg/tool/errors"
+)
+
+func canViewTestCase(ctx *internalhandler.Context, projectKey string) bool {
+	if ctx.Resources.IsSystemAdmin {
+		return true
+	}
+	projectAuthInfo, ok := ctx.Resources.ProjectAuthInfo[projectKey]
+	if !ok {
+		return false
+	}
+	return projectAuthInfo.IsProjectAdmin || projectAuthInfo.Test.View
+}
+
+func canEditTestCase(ctx *internalhandler.Context, projectKey string) bool {
+	if ctx.Resources.IsSystemAdmin {
+		return true
+	}
+	projectAuthInfo, ok := ctx.Resources.ProjectAuthInfo[projectKey]
+	if !ok {
+		return false
+	}
+	return projectAuthInfo.IsProjectAdmin || projectAuthInfo.Test.Edit
+}
+
+// @Summary 获取测试用例统计
+// @Description 统计最近days天内测试用例的执行结果、不稳定性评分和耗时，sort可选flakiness和duration
+// @Tags 	testing
+// @Produce json
+// @Param 	name 			path		string							true	"测试名称"
+// @Param 	projectName 	query		string							true	"项目标识"
+// @Param 	days 			query		int								false	"统计天数，默认30"
+// @Param 	sort 			query		string							false	"排序方式"
+// @Param 	limit 			query		int								false	"返回数量"
+// @Success 200 			{array} 	service.TestCaseStat
+// @Router /api/aslan/testing/test/{name}/cases [get]
+func ListTestCaseStats(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	projectKey := c.Query("projectName")
+	if !canViewTestCase(ctx, projectKey) {
+		ctx.UnAuthorized = true
+		return
+	}
+
+	days, _ := strconv.Atoi(c.Query("days"))
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	ctx.Resp, ctx.RespErr = service.ListTestCaseStats(projectKey, c.Param("name"), days, c.Query("sort"), limit, ctx.Logger)
+}
+
+// @Summary 获取测试用例历史
+// @Description
+// @Tags 	testing
+// @Produce json
+// @Param 	name 			path		string							true	"测试名称"
+// @Param 	projectName 	query		string							true	"项目标识"
+// @Param 	className 		query		string							false	"用例类名"
+// @Param 	caseName 		query		string							true	"用例名称"
+// @Param 	limit 			query		int								false	"返回数量"
+// @Success 200 			{array} 	commonmodels.TestCaseHistory
+// @Router /api/aslan/testing/test/{name}/cases/history [get]
+func ListTestCaseHistory(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	projectKey := c.Query("projectName")
+	if !canViewTestCase(ctx, projectKey) {
+		ctx.UnAuthorized = true
+		return
+	}
+
+	limit, _ := strconv.ParseInt(c.Query("limit"), 10, 64)
+	ctx.Resp, ctx.RespErr = service.ListTestCaseHistory(projectKey, c.Param("name"), c.Query("className"), c.Query("caseName"), limit, ctx.Logger)
+}
+
+// @Summary 获取隔离测试用例列表
+// @Description
+// @Tags 	testing
+// @Produce json
+// @Param 	name 			path		string							true	"测试名称"
+// @Param 	projectName 	query		string							true	"项目标识"
+// @Success 200 			{array} 	commonmodels.TestCaseQuarantine
+// @Router /api/aslan/testing/test/{name}/quarantine [get]
+func ListTestCaseQuarantines(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	projectKey := c.Query("projectName")
+	if !canViewTestCase(ctx, projectKey) {
+		ctx.UnAuthorized = true
+		return
+	}
+
+	ctx.Resp, ctx.RespErr = service.ListTestCaseQuarantines(projectKey, c.Param("name"), ctx.Logger)
+}
+
+// @Summary 隔离测试用例
+// @Description 隔离后测试任务不会因该用例失败而失败，但仍会报告其结果
+// @Tags 	testing
+// @Accept 	json
+// @Produce json
+// @Param 	name 			path		string							true	"测试名称"
+// @Param 	projectName 	query		string							true	"项目标识"
+// @Param 	body 			body 		service.TestCaseQuarantineArgs 	true 	"body"
+// @Success 200
+// @Router /api/aslan/testing/test/{name}/quarantine [post]
+func CreateTestCaseQuarantine(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	projectKey := c.Query("projectName")
+	args := new(service.TestCaseQuarantineArgs)
+	data, err := c.GetRawData()
+	if err != nil {
+		ctx.RespErr = e.ErrInvalidParam.AddErr(err)
+		return
+	}
+	if err = json.Unmarshal(data, args); err != nil {
+		ctx.RespErr = e.ErrInvalidParam.AddErr(err)
+		return
+	}
+
+	internalhandler.InsertOperationLog(c, ctx.UserName, projectKey, "新增", "项目管理-测试-隔离用例", c.Param("name"), string(data), ctx.Logger)
+
+	if !canEditTestCase(ctx, projectKey) {
+		ctx.UnAuthorized = true
+		return
+	}
+
+	ctx.RespErr = service.CreateTestCaseQuarantine(ctx.UserName, projectKey, c.Param("name"), args, ctx.Logger)
+}
+
+// @Summary 取消隔离测试用例
+// @Description
+// @Tags 	testing
+// @Produce json
+// @Param 	name 			path		string							true	"测试名称"
+// @Param 	id 				path		string							true	"隔离记录ID"
+// @Param 	projectName 	query		string							true	"项目标识"
+// @Success 200
+// @Router /api/aslan/testing/test/{name}/quarantine/{id} [delete]
+func DeleteTestCaseQuarantine(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	projectKey := c.Query("projectName")
+	internalhandler.InsertOperationLog(c, ctx.UserName, projectKey, "删除", "项目管理-测试-隔离用例", c.Param("name"), "", ctx.Logger)
+
+	if !canEditTestCase(ctx, projectKey) {
+		ctx.UnAuthorized = true
+		return
+	}
+
+	ctx.RespErr = service.DeleteTestCaseQuarantine(projectKey, c.Param("name"), c.Param("id"), ctx.Logger)
+}
+
+// @Summary 获取代码覆盖率趋势
+// @Description
+// @Tags 	testing
+// @Produce json
+// @Param 	name 			path		string							true	"测试名称"
+// @Param 	projectName 	query		string							true	"项目标识"
+// @Param 	branch 			query		string							false	"分支"
+// @Param 	limit 			query		int								false	"返回数量"
+// @Success 200 			{array} 	commonmodels.TestCoverage
+// @Router /api/aslan/testing/test/{name}/coverage [get]
+func ListTestCoverages(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	projectKey := c.Query("projectName")
+	if !canViewTestCase(ctx, projectKey) {
+		ctx.UnAuthorized = true
+		return
+	}
+
+	limit, _ := strconv.ParseInt(c.Query("limit"), 10, 64)
+	ctx.Resp, ctx.RespErr = service.ListTestCoverages(projectKey, c.Param("name"), c.Query("branch"), limit, ctx.Logger)
+}
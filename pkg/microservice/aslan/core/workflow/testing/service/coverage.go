@@ -0,0 +1,52 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	e "github.com/koderover/zadig/v2/pkg/tool/errors"
+)
+
+// GetWorkflowV4TestCoverage returns the coverage with file details collected by the testing job of the workflow task
+func GetWorkflowV4TestCoverage(workflowName, jobName string, taskID int64, log *zap.SugaredLogger) (*commonmodels.TestCoverage, error) {
+	coverages, err := commonrepo.NewTestCoverageColl().ListByTask(workflowName, taskID, true)
+	if err != nil {
+		log.Errorf("failed to list coverage of workflow %s task %d, error: %s", workflowName, taskID, err)
+		return nil, e.ErrGetTestCoverage.AddErr(err)
+	}
+	for _, coverage := range coverages {
+		if coverage.JobTaskName == jobName {
+			return coverage, nil
+		}
+	}
+	return nil, e.ErrGetTestCoverage.AddErr(fmt.Errorf("no coverage found for job %s of workflow %s task %d", jobName, workflowName, taskID))
+}
+
+// ListTestCoverages returns the coverage trend of the testing, the file details are not included
+func ListTestCoverages(projectName, testName, branch string, limit int64, log *zap.SugaredLogger) ([]*commonmodels.TestCoverage, error) {
+	resp, err := commonrepo.NewTestCoverageColl().ListByTest(projectName, testName, branch, limit)
+	if err != nil {
+		log.Errorf("failed to list coverage of testing %s, error: %s", testName, err)
+		return nil, e.ErrListTestCoverage.AddErr(err)
+	}
+	return resp, nil
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	e "github.com/koderover/zadig/v2/pkg/tool/errors"
+)
+
+const (
+	TestCaseStatSortByFlakiness = "flakiness"
+	TestCaseStatSortByDuration  = "duration"
+
+	defaultTestCaseStatDays = 30
+)
+
+type TestCaseStat struct {
+	ClassName   string  `json:"class_name"`
+	CaseName    string  `json:"case_name"`
+	Runs        int     `json:"runs"`
+	Passed      int     `json:"passed"`
+	Failed      int     `json:"failed"`
+	Skipped     int     `json:"skipped"`
+	PassRate    float64 `json:"pass_rate"`
+	AvgDuration float64 `json:"avg_duration"`
+	MaxDuration float64 `json:"max_duration"`
+	// FlakyCommits is the number of commits on which the case both passed and failed
+	FlakyCommits int `json:"flaky_commits"`
+	// FlakinessScore is FlakyCommits divided by the number of commits the case ran on
+	FlakinessScore float64 `json:"flakiness_score"`
+	Quarantined    bool    `json:"quarantined"`
+	LastStatus     string  `json:"last_status"`
+	LastRunTime    int64   `json:"last_run_time"`
+}
+
+type TestCaseQuarantineArgs struct {
+	ClassName string `json:"class_name"`
+	CaseName  string `json:"case_name"`
+	Reason    string `json:"reason"`
+}
+
+// ListTestCaseStats summarizes the test case results of the testing module in the last days,
+// the result is sorted by flakiness or duration and cut to limit if it is set
+func ListTestCaseStats(projectName, testName string, days int, sortBy string, limit int, log *zap.SugaredLogger) ([]*TestCaseStat, error) {
+	if days <= 0 {
+		days = defaultTestCaseStatDays
+	}
+	stats, err := commonrepo.NewTestCaseHistoryColl().ListStats(projectName, testName, time.Now().AddDate(0, 0, -days).Unix())
+	if err != nil {
+		log.Errorf("failed to list test case stats of %s, error: %s", testName, err)
+		return nil, e.ErrListTestCaseStat.AddErr(err)
+	}
+	quarantines, err := commonrepo.NewTestCaseQuarantineColl().List(projectName, testName)
+	if err != nil {
+		log.Errorf("failed to list quarantined test cases of %s, error: %s", testName, err)
+		return nil, e.ErrListTestCaseStat.AddErr(err)
+	}
+
+	resp := calculateTestCaseStats(stats, quarantines)
+	sortTestCaseStats(resp, sortBy)
+	if limit > 0 && len(resp) > limit {
+		resp = resp[:limit]
+	}
+	return resp, nil
+}
+
+func ListTestCaseHistory(projectName, testName, className, caseName string, limit int64, log *zap.SugaredLogger) ([]*commonmodels.TestCaseHistory, error) {
+	if caseName == "" {
+		return nil, e.ErrListTestCaseHistory.AddDesc("empty case name")
+	}
+	resp, err := commonrepo.NewTestCaseHistoryColl().List(&commonrepo.TestCaseHistoryListOption{
+		ProjectName: projectName,
+		TestName:    testName,
+		ClassName:   className,
+		CaseName:    caseName,
+		Limit:       limit,
+	})
+	if err != nil {
+		log.Errorf("failed to list history of test case %s, error: %s", caseName, err)
+		return nil, e.ErrListTestCaseHistory.AddErr(err)
+	}
+	return resp, nil
+}
+
+func ListTestCaseQuarantines(projectName, testName string, log *zap.SugaredLogger) ([]*commonmodels.TestCaseQuarantine, error) {
+	resp, err := commonrepo.NewTestCaseQuarantineColl().List(projectName, testName)
+	if err != nil {
+		log.Errorf("failed to list quarantined test cases of %s, error: %s", testName, err)
+		return nil, e.ErrListTestCaseQuarantine.AddErr(err)
+	}
+	return resp, nil
+}
+
+func CreateTestCaseQuarantine(username, projectName, testName string, args *TestCaseQuarantineArgs, log *zap.SugaredLogger) error {
+	if args.CaseName == "" {
+		return e.ErrCreateTestCaseQuarantine.AddDesc("empty case name")
+	}
+	if _, err := commonrepo.NewTestingColl().Find(testName, projectName); err != nil {
+		return e.ErrCreateTestCaseQuarantine.AddErr(fmt.Errorf("failed to find testing %s, error: %s", testName, err))
+	}
+
+	err := commonrepo.NewTestCaseQuarantineColl().Create(&commonmodels.TestCaseQuarantine{
+		ProjectName: projectName,
+		TestName:    testName,
+		ClassName:   args.ClassName,
+		CaseName:    args.CaseName,
+		Reason:      args.Reason,
+		CreatedBy:   username,
+		CreateTime:  time.Now().Unix(),
+	})
+	if err != nil {
+		log.Errorf("failed to quarantine test case %s of %s, error: %s", args.CaseName, testName, err)
+		return e.ErrCreateTestCaseQuarantine.AddErr(err)
+	}
+	return nil
+}
+
+func DeleteTestCaseQuarantine(projectName, testName, id string, log *zap.SugaredLogger) error {
+	if err := commonrepo.NewTestCaseQuarantineColl().Delete(projectName, testName, id); err != nil {
+		log.Errorf("failed to delete quarantined test case %s of %s, error: %s", id, testName, err)
+		return e.ErrDeleteTestCaseQuarantine.AddErr(err)
+	}
+	return nil
+}
+
+func testCaseKey(className, caseName string) string {
+	return className + "/" + caseName
+}
+
+// calculateTestCaseStats calculates the rates of the test cases aggregated from their histories
+func calculateTestCaseStats(stats []*commonmodels.TestCaseHistoryStat, quarantines []*commonmodels.TestCaseQuarantine) []*TestCaseStat {
+	resp := make([]*TestCaseStat, 0, len(stats))
+	for _, caseStat := range stats {
+		stat := &TestCaseStat{
+			ClassName:    caseStat.ClassName,
+			CaseName:     caseStat.CaseName,
+			Runs:         caseStat.Runs,
+			Passed:       caseStat.Passed,
+			Failed:       caseStat.Failed,
+			Skipped:      caseStat.Skipped,
+			MaxDuration:  caseStat.MaxDuration,
+			FlakyCommits: caseStat.FlakyCommits,
+			LastStatus:   caseStat.LastStatus,
+			LastRunTime:  caseStat.LastRunTime,
+		}
+		if executed := stat.Passed + stat.Failed; executed > 0 {
+			stat.PassRate = roundTestCaseStat(float64(stat.Passed) / float64(executed))
+			stat.AvgDuration = roundTestCaseStat(caseStat.TotalDuration / float64(executed))
+		}
+		if caseStat.Commits > 0 {
+			stat.FlakinessScore = roundTestCaseStat(float64(caseStat.FlakyCommits) / float64(caseStat.Commits))
+		}
+		for _, quarantine := range quarantines {
+			if stat.CaseName == quarantine.CaseName && (quarantine.ClassName == "" || quarantine.ClassName == stat.ClassName) {
+				stat.Quarantined = true
+			}
+		}
+		resp = append(resp, stat)
+	}
+	return resp
+}
+
+func sortTestCaseStats(stats []*TestCaseStat, sortBy string) {
+	sort.SliceStable(stats, func(i, j int) bool {
+		switch sortBy {
+		case TestCaseStatSortByDuration:
+			if stats[i].AvgDuration != stats[j].AvgDuration {
+				return stats[i].AvgDuration > stats[j].AvgDuration
+			}
+		default:
+			if stats[i].FlakinessScore != stats[j].FlakinessScore {
+				return stats[i].FlakinessScore > stats[j].FlakinessScore
+			}
+			if stats[i].Failed != stats[j].Failed {
+				return stats[i].Failed > stats[j].Failed
+			}
+		}
+		return strings.Compare(testCaseKey(stats[i].ClassName, stats[i].CaseName), testCaseKey(stats[j].ClassName, stats[j].CaseName)) < 0
+	})
+}
+
+func roundTestCaseStat(f float64) float64 {
+	return math.Round(f*1000) / 1000
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+)
+
+func TestCalculateTestCaseStats(t *testing.T) {
+	stats := []*commonmodels.TestCaseHistoryStat{
+		{ClassName: "pkg", CaseName: "TestFlaky", Runs: 3, Passed: 2, Failed: 1, TotalDuration: 6, MaxDuration: 3, Commits: 2, FlakyCommits: 1, LastStatus: commonmodels.TestCaseStatusPassed, LastRunTime: 4},
+		{ClassName: "pkg", CaseName: "TestSlow", Runs: 3, Passed: 1, Failed: 1, Skipped: 1, TotalDuration: 30, MaxDuration: 20, Commits: 2, LastStatus: commonmodels.TestCaseStatusPassed, LastRunTime: 4},
+	}
+	quarantines := []*commonmodels.TestCaseQuarantine{{CaseName: "TestFlaky"}}
+
+	resp := calculateTestCaseStats(stats, quarantines)
+	assert.Len(t, resp, 2)
+
+	flaky := resp[0]
+	assert.Equal(t, "TestFlaky", flaky.CaseName)
+	assert.Equal(t, 3, flaky.Runs)
+	assert.Equal(t, 2, flaky.Passed)
+	assert.Equal(t, 1, flaky.Failed)
+	assert.Equal(t, 1, flaky.FlakyCommits)
+	assert.Equal(t, 0.5, flaky.FlakinessScore)
+	assert.Equal(t, 0.667, flaky.PassRate)
+	assert.Equal(t, float64(2), flaky.AvgDuration)
+	assert.Equal(t, commonmodels.TestCaseStatusPassed, flaky.LastStatus)
+	assert.True(t, flaky.Quarantined)
+
+	slow := resp[1]
+	assert.Equal(t, 3, slow.Runs)
+	assert.Equal(t, 1, slow.Skipped)
+	assert.Equal(t, 0, slow.FlakyCommits)
+	assert.Equal(t, float64(15), slow.AvgDuration)
+	assert.Equal(t, float64(20), slow.MaxDuration)
+	assert.False(t, slow.Quarantined)
+
+	sortTestCaseStats(resp, TestCaseStatSortByDuration)
+	assert.Equal(t, "TestSlow", resp[0].CaseName)
+	sortTestCaseStats(resp, TestCaseStatSortByFlakiness)
+	assert.Equal(t, "TestFlaky", resp[0].CaseName)
+}
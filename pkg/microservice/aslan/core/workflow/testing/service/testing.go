@@ -41,9 +41,11 @@ import (
 	commonutil "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/util"
 	workflowservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/workflow/service/workflow"
 	"github.com/koderover/zadig/v2/pkg/setting"
+	"github.com/koderover/zadig/v2/pkg/tool/coverage"
 	e "github.com/koderover/zadig/v2/pkg/tool/errors"
 	s3tool "github.com/koderover/zadig/v2/pkg/tool/s3"
 	tartool "github.com/koderover/zadig/v2/pkg/tool/tar"
+	"github.com/koderover/zadig/v2/pkg/tool/testreport"
 	"github.com/koderover/zadig/v2/pkg/types"
 	"github.com/koderover/zadig/v2/pkg/types/step"
 )
@@ -55,6 +57,12 @@ func CreateTesting(username string, testing *commonmodels.Testing, log *zap.Suga
 	if err := commonutil.CheckDefineResourceParam(testing.PreTest.ResReq, testing.PreTest.ResReqSpec); err != nil {
 		return e.ErrCreateTestModule.AddDesc(err.Error())
 	}
+	if err := validateCoverageSetting(testing.Coverage); err != nil {
+		return e.ErrCreateTestModule.AddErr(err)
+	}
+	if !testreport.IsValidFormat(testing.TestResultFormat) {
+		return e.ErrCreateTestModule.AddDesc(fmt.Sprintf("unsupported test result format: %s", testing.TestResultFormat))
+	}
 	err := HandleCronjob(testing, log)
 	if err != nil {
 		return e.ErrCreateTestModule.AddErr(err)
@@ -118,6 +126,12 @@ func UpdateTesting(username string, testing *commonmodels.Testing, log *zap.Suga
 	if err := commonutil.CheckDefineResourceParam(testing.PreTest.ResReq, testing.PreTest.ResReqSpec); err != nil {
 		return e.ErrUpdateTestModule.AddDesc(err.Error())
 	}
+	if err := validateCoverageSetting(testing.Coverage); err != nil {
+		return e.ErrUpdateTestModule.AddErr(err)
+	}
+	if !testreport.IsValidFormat(testing.TestResultFormat) {
+		return e.ErrUpdateTestModule.AddDesc(fmt.Sprintf("unsupported test result format: %s", testing.TestResultFormat))
+	}
 	err := HandleCronjob(testing, log)
 	if err != nil {
 		return e.ErrUpdateTestModule.AddErr(err)
@@ -623,3 +637,22 @@ func validateTestReportParam(pipelineName, pipelineType, taskIDStr, testName str
 
 	return nil
 }
+
+func validateCoverageSetting(setting *commonmodels.CoverageSetting) error {
+	if setting == nil || !setting.Enabled {
+		return nil
+	}
+	if !coverage.IsValidFormat(setting.Format) {
+		return fmt.Errorf("unsupported coverage format: %s", setting.Format)
+	}
+	if setting.ReportPath == "" {
+		return fmt.Errorf("coverage report path is empty")
+	}
+	if setting.Threshold < 0 || setting.Threshold > 100 {
+		return fmt.Errorf("coverage threshold should be between 0 and 100")
+	}
+	if setting.MaxDrop < 0 || setting.MaxDrop > 100 {
+		return fmt.Errorf("coverage max drop should be between 0 and 100")
+	}
+	return nil
+}
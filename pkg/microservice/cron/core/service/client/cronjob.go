@@ -67,6 +67,28 @@ func (c *Client) TriggerCleanProducts(log *zap.SugaredLogger) error {
 	return err
 }
 
+// TriggerEnvDriftScan triggers aslan to scan the resources modified outside Zadig in the envs
+func (c *Client) TriggerEnvDriftScan(log *zap.SugaredLogger) error {
+	url := fmt.Sprintf("%s/environment/cron/drift", c.APIBase)
+	log.Info("start scan env drifts..")
+	err := c.sendRequest(url)
+	if err != nil {
+		log.Errorf("trigger scan env drifts error :%v", err)
+	}
+	return err
+}
+
+// TriggerEnvTTLCheck triggers aslan to warn the creators of the envs about to expire and delete the expired envs
+func (c *Client) TriggerEnvTTLCheck(log *zap.SugaredLogger) error {
+	url := fmt.Sprintf("%s/environment/cron/ttl", c.APIBase)
+	log.Info("start check env ttl..")
+	err := c.sendRequest(url)
+	if err != nil {
+		log.Errorf("trigger check env ttl error :%v", err)
+	}
+	return err
+}
+
 // TriggerCleanCIResources trigger clean CollaborationInstance Resources
 func (c *Client) TriggerCleanCIResources(log *zap.SugaredLogger) error {
 	url := fmt.Sprintf("%s/collaboration/collaborations/cron/clean", c.APIBase)
@@ -146,6 +146,10 @@ const (
 	InitHelmEnvSyncValuesScheduler = "InitHelmEnvSyncValuesScheduler"
 
 	EnvResourceSyncScheduler = "EnvResourceSyncScheduler"
+
+	EnvDriftScanScheduler = "EnvDriftScanScheduler"
+
+	EnvTTLCheckScheduler = "EnvTTLCheckScheduler"
 )
 
 // NewCronClient ...
@@ -229,6 +233,10 @@ func (c *CronClient) Init() {
 	c.InitHelmEnvSyncValuesScheduler()
 	// sync env resources from git at regular intervals
 	c.InitEnvResourceSyncScheduler()
+	// scan the resources modified outside Zadig every 10 minutes
+	c.InitEnvDriftScanScheduler()
+	// delete the expired ephemeral envs every 5 minutes
+	c.InitEnvTTLCheckScheduler()
 }
 
 func (c *CronClient) InitCleanJobScheduler() {
@@ -318,3 +326,19 @@ func (c *CronClient) InitEnvResourceSyncScheduler() {
 
 	c.Schedulers[EnvResourceSyncScheduler].Start()
 }
+
+func (c *CronClient) InitEnvDriftScanScheduler() {
+	c.Schedulers[EnvDriftScanScheduler] = gocron.NewScheduler()
+
+	c.Schedulers[EnvDriftScanScheduler].Every(10).Minutes().Do(c.AslanCli.TriggerEnvDriftScan, c.log)
+
+	c.Schedulers[EnvDriftScanScheduler].Start()
+}
+
+func (c *CronClient) InitEnvTTLCheckScheduler() {
+	c.Schedulers[EnvTTLCheckScheduler] = gocron.NewScheduler()
+
+	c.Schedulers[EnvTTLCheckScheduler].Every(5).Minutes().Do(c.AslanCli.TriggerEnvTTLCheck, c.log)
+
+	c.Schedulers[EnvTTLCheckScheduler].Start()
+}
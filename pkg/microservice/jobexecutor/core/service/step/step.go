@@ -82,6 +82,11 @@ func RunStep(ctx context.Context, step *meta.Step, workspace, paths string, envs
 		if err != nil {
 			return err
 		}
+	case "coverage_report":
+		stepInstance, err = NewCoverageReportStep(step.Spec, workspace, envs, secretEnvs)
+		if err != nil {
+			return err
+		}
 	case "tar_archive":
 		stepInstance, err = NewTarArchiveStep(step.Spec, workspace, envs, secretEnvs)
 		if err != nil {
@@ -102,6 +107,21 @@ func RunStep(ctx context.Context, step *meta.Step, workspace, paths string, envs
 		if err != nil {
 			return err
 		}
+	case "image_scan":
+		stepInstance, err = NewImageScanStep(step.Spec, workspace, paths, envs, secretEnvs)
+		if err != nil {
+			return err
+		}
+	case "image_sign":
+		stepInstance, err = NewImageSignStep(step.Spec, workspace, paths, envs, secretEnvs)
+		if err != nil {
+			return err
+		}
+	case "sbom":
+		stepInstance, err = NewSBOMStep(step.Spec, workspace, paths, envs, secretEnvs)
+		if err != nil {
+			return err
+		}
 	case "debug_before":
 		stepInstance, err = NewDebugStep("before", workspace, envs, secretEnvs, updater)
 		if err != nil {
@@ -117,6 +137,16 @@ func RunStep(ctx context.Context, step *meta.Step, workspace, paths string, envs
 		if err != nil {
 			return err
 		}
+	case "cache_restore":
+		stepInstance, err = NewCacheRestoreStep(step.Spec, workspace, envs, secretEnvs)
+		if err != nil {
+			return err
+		}
+	case "cache_save":
+		stepInstance, err = NewCacheSaveStep(step.Spec, workspace, envs, secretEnvs)
+		if err != nil {
+			return err
+		}
 	default:
 		err := fmt.Errorf("step type: %s does not match any known type", step.StepType)
 		log.Error(err)
@@ -0,0 +1,121 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package step
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/koderover/zadig/v2/pkg/tool/buildcache"
+	"github.com/koderover/zadig/v2/pkg/tool/log"
+	"github.com/koderover/zadig/v2/pkg/tool/s3"
+	"github.com/koderover/zadig/v2/pkg/types/step"
+	"github.com/koderover/zadig/v2/pkg/util"
+)
+
+type CacheRestoreStep struct {
+	spec       *step.StepCacheRestoreSpec
+	envs       []string
+	secretEnvs []string
+	workspace  string
+}
+
+func NewCacheRestoreStep(spec interface{}, workspace string, envs, secretEnvs []string) (*CacheRestoreStep, error) {
+	cacheStep := &CacheRestoreStep{workspace: workspace, envs: envs, secretEnvs: secretEnvs}
+	yamlBytes, err := yaml.Marshal(spec)
+	if err != nil {
+		return cacheStep, fmt.Errorf("marshal spec %+v failed", spec)
+	}
+	if err := yaml.Unmarshal(yamlBytes, &cacheStep.spec); err != nil {
+		return cacheStep, fmt.Errorf("unmarshal spec %s to cache restore spec failed", yamlBytes)
+	}
+	return cacheStep, nil
+}
+
+func (s *CacheRestoreStep) Run(ctx context.Context) error {
+	start := time.Now()
+	defer func() {
+		log.Infof("Cache restore ended. Duration: %.2f seconds", time.Since(start).Seconds())
+	}()
+
+	if err := s.run(); err != nil {
+		if s.spec.IgnoreErr {
+			log.Errorf("failed to restore cache, err: %s", err)
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *CacheRestoreStep) run() error {
+	envMap := util.MakeEnvMap(s.envs, s.secretEnvs)
+	key, err := buildcache.RenderKey(util.ReplaceEnvWithValue(s.spec.Key, envMap), s.workspace)
+	if err != nil {
+		return err
+	}
+	restoreKeys := make([]string, 0, len(s.spec.RestoreKeys))
+	for _, restoreKey := range s.spec.RestoreKeys {
+		restoreKey, err = buildcache.RenderKey(util.ReplaceEnvWithValue(restoreKey, envMap), s.workspace)
+		if err != nil {
+			return err
+		}
+		restoreKeys = append(restoreKeys, restoreKey)
+	}
+	log.Infof("Start restore cache %s.", key)
+
+	if s.spec.S3 == nil {
+		return fmt.Errorf("no object storage to restore cache")
+	}
+
+	client, err := s3.NewClient(s.spec.S3.Endpoint, s.spec.S3.Ak, s.spec.S3.Sk, s.spec.S3.Region, s.spec.S3.Insecure, s.spec.S3.Provider)
+	if err != nil {
+		return fmt.Errorf("failed to create s3 client to restore cache, err: %s", err)
+	}
+	prefix := buildcache.ProjectPrefix(s.spec.S3.Subfolder, s.spec.ProjectName)
+	objectKey, exactMatch, err := buildcache.Lookup(client, s.spec.S3.Bucket, prefix, key, restoreKeys)
+	if err != nil {
+		return fmt.Errorf("failed to find cache %s, err: %s", key, err)
+	}
+	if objectKey == "" {
+		log.Infof("Cache %s not found.", key)
+		return nil
+	}
+	if !exactMatch {
+		log.Infof("Cache %s not found, restore from %s.", key, objectKey)
+	}
+
+	sourceFilename, err := util.GenerateTmpFile()
+	if err != nil {
+		return fmt.Errorf("failed to GenerateTmpFile, err: %s", err)
+	}
+	defer func() {
+		_ = os.Remove(sourceFilename)
+	}()
+	if err := client.Download(s.spec.S3.Bucket, objectKey, sourceFilename); err != nil {
+		return fmt.Errorf("failed to download cache from s3, bucketName: %s, objectKey: %s, err: %s", s.spec.S3.Bucket, objectKey, err)
+	}
+	if err := buildcache.Extract(s.workspace, sourceFilename); err != nil {
+		return err
+	}
+	log.Infof("Cache restored from %s.", objectKey)
+	return nil
+}
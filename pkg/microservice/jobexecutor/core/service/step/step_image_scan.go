@@ -0,0 +1,153 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package step
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/koderover/zadig/v2/pkg/setting"
+	"github.com/koderover/zadig/v2/pkg/tool/log"
+	"github.com/koderover/zadig/v2/pkg/tool/trivy"
+	"github.com/koderover/zadig/v2/pkg/types/job"
+	"github.com/koderover/zadig/v2/pkg/types/step"
+)
+
+type ImageScanStep struct {
+	spec       *step.StepImageScanSpec
+	envs       []string
+	secretEnvs []string
+	workspace  string
+	paths      string
+}
+
+func NewImageScanStep(spec interface{}, workspace, paths string, envs, secretEnvs []string) (*ImageScanStep, error) {
+	imageScanStep := &ImageScanStep{workspace: workspace, paths: paths, envs: envs, secretEnvs: secretEnvs}
+	yamlBytes, err := yaml.Marshal(spec)
+	if err != nil {
+		return imageScanStep, fmt.Errorf("marshal spec %+v failed", spec)
+	}
+	if err := yaml.Unmarshal(yamlBytes, &imageScanStep.spec); err != nil {
+		return imageScanStep, fmt.Errorf("unmarshal spec %s to image scan spec failed", yamlBytes)
+	}
+	return imageScanStep, nil
+}
+
+func (s *ImageScanStep) Run(ctx context.Context) error {
+	log.Info("Start scanning images.")
+	trivyBin, err := lookPath("trivy", s.paths)
+	if err != nil {
+		return err
+	}
+
+	results := make([]*step.ImageScanResult, 0)
+	failed := make([]string, 0)
+	for i, target := range s.spec.Targets {
+		result := &step.ImageScanResult{
+			ServiceName:   target.ServiceName,
+			ServiceModule: target.ServiceModule,
+			Image:         target.Image,
+		}
+		results = append(results, result)
+
+		summary, err := s.scan(ctx, trivyBin, target.Image, filepath.Join(os.TempDir(), fmt.Sprintf("trivy-report-%d.json", i)))
+		if err != nil {
+			result.Error = err.Error()
+			failed = append(failed, fmt.Sprintf("%s: %s", target.Image, err))
+			continue
+		}
+		result.Summary = summary
+		result.Counted, err = summary.CountAtLeast(s.spec.Severity)
+		if err != nil {
+			return err
+		}
+		result.Passed = result.Counted <= s.spec.Threshold
+		log.Infof("image %s: %d critical, %d high, %d medium, %d low, %d unknown vulnerabilities", target.Image, summary.Critical, summary.High, summary.Medium, summary.Low, summary.Unknown)
+		if !result.Passed {
+			failed = append(failed, fmt.Sprintf("%s: %d vulnerabilities at or above %s, more than %d", target.Image, result.Counted, s.spec.Severity, s.spec.Threshold))
+		}
+	}
+
+	resultBytes, err := json.Marshal(results)
+	if err != nil {
+		return fmt.Errorf("marshal image scan results error: %v", err)
+	}
+	outputFileName := filepath.Join(job.JobOutputDir, setting.WorkflowImageScanJobOutputKey)
+	if err := os.WriteFile(outputFileName, resultBytes, 0644); err != nil {
+		return fmt.Errorf("write image scan results to output file %s error: %v", outputFileName, err)
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("image scan failed:\n%s", strings.Join(failed, "\n"))
+	}
+	log.Info("Finish scanning images.")
+	return nil
+}
+
+func (s *ImageScanStep) scan(ctx context.Context, trivyBin, image, reportFile string) (*trivy.Summary, error) {
+	log.Infof("Scanning image %s", image)
+	cmd := exec.CommandContext(ctx, trivyBin, "image", "--format", "json", "--quiet", "--output", reportFile, image)
+	cmd.Dir = s.workspace
+	cmd.Env = s.envs
+	if reg := s.spec.Registry; reg != nil && reg.AccessKey != "" && strings.HasPrefix(image, registryHost(reg.RegAddr)) {
+		cmd.Env = append(cmd.Env, "TRIVY_USERNAME="+reg.AccessKey, "TRIVY_PASSWORD="+reg.SecretKey)
+		if !reg.TLSEnabled {
+			cmd.Env = append(cmd.Env, "TRIVY_INSECURE=true")
+		}
+	}
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("trivy scan failed: %v, %s", err, out)
+	}
+
+	data, err := os.ReadFile(reportFile)
+	if err != nil {
+		return nil, fmt.Errorf("read trivy report error: %v", err)
+	}
+	report, err := trivy.ParseReport(data)
+	if err != nil {
+		return nil, err
+	}
+	return report.Summarize(), nil
+}
+
+// lookPath finds the binary in the given paths first, which contains the tools installed by the tool install step
+func lookPath(name, paths string) (string, error) {
+	for _, dir := range filepath.SplitList(paths) {
+		bin := filepath.Join(dir, name)
+		if info, err := os.Stat(bin); err == nil && !info.IsDir() {
+			return bin, nil
+		}
+	}
+	bin, err := exec.LookPath(name)
+	if err != nil {
+		return "", fmt.Errorf("%s not found, install it with the tool install step: %v", name, err)
+	}
+	return bin, nil
+}
+
+func registryHost(regAddr string) string {
+	regAddr = strings.TrimPrefix(regAddr, "http://")
+	return strings.TrimPrefix(regAddr, "https://")
+}
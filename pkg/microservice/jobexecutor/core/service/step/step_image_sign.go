@@ -0,0 +1,90 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package step
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/koderover/zadig/v2/pkg/tool/log"
+	"github.com/koderover/zadig/v2/pkg/types/step"
+)
+
+type ImageSignStep struct {
+	spec       *step.StepImageSignSpec
+	envs       []string
+	secretEnvs []string
+	workspace  string
+	paths      string
+}
+
+func NewImageSignStep(spec interface{}, workspace, paths string, envs, secretEnvs []string) (*ImageSignStep, error) {
+	imageSignStep := &ImageSignStep{workspace: workspace, paths: paths, envs: envs, secretEnvs: secretEnvs}
+	yamlBytes, err := yaml.Marshal(spec)
+	if err != nil {
+		return imageSignStep, fmt.Errorf("marshal spec %+v failed", spec)
+	}
+	if err := yaml.Unmarshal(yamlBytes, &imageSignStep.spec); err != nil {
+		return imageSignStep, fmt.Errorf("unmarshal spec %s to image sign spec failed", yamlBytes)
+	}
+	return imageSignStep, nil
+}
+
+// Run signs the image with cosign (v2 or later), the signature is pushed to the registry of the image
+// and is not uploaded to the transparency log. The signing key is read by cosign from the secret envs of the job.
+func (s *ImageSignStep) Run(ctx context.Context) error {
+	log.Infof("Start signing image %s.", s.spec.Image)
+	// the private key is not looked up by util.MakeEnvMap since the PEM content contains "="
+	hasKey := false
+	for _, env := range s.secretEnvs {
+		if strings.HasPrefix(env, step.ImageSignPrivateKeyEnv+"=") && len(env) > len(step.ImageSignPrivateKeyEnv)+1 {
+			hasKey = true
+			break
+		}
+	}
+	if !hasKey {
+		return fmt.Errorf("no private key to sign the image")
+	}
+	cosignBin, err := lookPath("cosign", s.paths)
+	if err != nil {
+		return err
+	}
+
+	envs := append(append([]string{}, s.envs...), s.secretEnvs...)
+	if reg := s.spec.DockerRegistry; reg != nil && reg.UserName != "" {
+		cmd := exec.CommandContext(ctx, cosignBin, "login", registryHost(reg.Host), "-u", reg.UserName, "--password-stdin")
+		cmd.Env = envs
+		cmd.Stdin = strings.NewReader(reg.Password)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to login to registry %s: %v, %s", reg.Host, err, strings.TrimSpace(string(out)))
+		}
+	}
+
+	cmd := exec.CommandContext(ctx, cosignBin, "sign", "--key", "env://"+step.ImageSignPrivateKeyEnv, "--yes", "--tlog-upload=false", s.spec.Image)
+	cmd.Dir = s.workspace
+	cmd.Env = envs
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to sign image %s: %v, %s", s.spec.Image, err, strings.TrimSpace(string(out)))
+	}
+	log.Infof("Image %s signed.", s.spec.Image)
+	return nil
+}
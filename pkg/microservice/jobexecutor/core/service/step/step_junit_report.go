@@ -33,6 +33,7 @@ import (
 	"github.com/koderover/zadig/v2/pkg/microservice/reaper/core/service/meta"
 	"github.com/koderover/zadig/v2/pkg/tool/log"
 	"github.com/koderover/zadig/v2/pkg/tool/s3"
+	"github.com/koderover/zadig/v2/pkg/tool/testreport"
 	"github.com/koderover/zadig/v2/pkg/types/step"
 	"github.com/koderover/zadig/v2/pkg/util"
 )
@@ -71,7 +72,7 @@ func (s *JunitReportStep) Run(ctx context.Context) error {
 	s.spec.ReportDir = util.ReplaceEnvWithValue(s.spec.ReportDir, envMap)
 
 	reportDir := filepath.Join(s.workspace, s.spec.ReportDir)
-	results, err := mergeGinkgoTestResults(s.spec.FileName, reportDir, s.spec.DestDir, time.Now())
+	results, err := mergeGinkgoTestResults(s.spec.Format, s.spec.FileName, reportDir, s.spec.DestDir, time.Now())
 	if err != nil {
 		return fmt.Errorf("failed to merge test result: %s", err)
 	}
@@ -110,13 +111,17 @@ func (s *JunitReportStep) Run(ctx context.Context) error {
 		}
 	}
 	log.Infof("Finish archive %s to %s.", s.spec.FileName)
-	if results.Failures > 0 || results.Errors > 0 {
-		return fmt.Errorf("%d case(s) failed, %d case(s) error", results.Failures, results.Errors)
+	quarantinedFailures, quarantinedErrors := results.CountQuarantined(s.spec.IsQuarantined)
+	if quarantinedFailures > 0 || quarantinedErrors > 0 {
+		log.Infof("%d quarantined case(s) failed, %d quarantined case(s) error, ignored.", quarantinedFailures, quarantinedErrors)
+	}
+	if failures, errors := results.Failures-quarantinedFailures, results.Errors-quarantinedErrors; failures > 0 || errors > 0 {
+		return fmt.Errorf("%d case(s) failed, %d case(s) error", failures, errors)
 	}
 	return nil
 }
 
-func mergeGinkgoTestResults(testResultFile, testResultPath, testUploadPath string, startTime time.Time) (*meta.TestSuite, error) {
+func mergeGinkgoTestResults(format, testResultFile, testResultPath, testUploadPath string, startTime time.Time) (*meta.TestSuite, error) {
 	var (
 		err           error
 		newXMLBytes   []byte
@@ -138,7 +143,21 @@ func mergeGinkgoTestResults(testResultFile, testResultPath, testUploadPath strin
 	sort.SliceStable(files, func(i, j int) bool {
 		return files[i].ModTime().Before(files[j].ModTime())
 	})
+	// junit reports are merged as they are, other formats are normalized by the parser
+	var parser testreport.Parser
+	if format != "" && format != testreport.FormatJUnit {
+		parser, err = testreport.GetParser(format)
+		if err != nil {
+			return summaryResult, err
+		}
+	}
 	for _, file := range files {
+		if parser != nil {
+			if !file.IsDir() && parser.Match(file.Name()) {
+				mergeParsedTestResult(summaryResult, parser, path.Join(testResultPath, file.Name()))
+			}
+			continue
+		}
 		if filepath.Ext(file.Name()) == ".xml" {
 			filePath := path.Join(testResultPath, file.Name())
 			log.Infof("name %s mod time: %v", file.Name(), file.ModTime())
@@ -222,6 +241,26 @@ func mergeGinkgoTestResults(testResultFile, testResultPath, testUploadPath strin
 	return summaryResult, nil
 }
 
+func mergeParsedTestResult(summaryResult *meta.TestSuite, parser testreport.Parser, filePath string) {
+	log.Infof("parse file %s", filePath)
+	data, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		log.Warningf("Read file [%s], error: %v", filePath, err)
+		return
+	}
+	result, err := parser.Parse(filePath, data)
+	if err != nil {
+		log.Warningf("Parse file [%s], error: %v", filePath, err)
+		return
+	}
+	summaryResult.Tests += result.Tests
+	summaryResult.Failures += result.Failures
+	summaryResult.Errors += result.Errors
+	summaryResult.Skips += result.Skips
+	summaryResult.TestCases = append(summaryResult.TestCases, result.TestCases...)
+	summaryResult.SuiteType = ReploaceTestSuites
+}
+
 func getSecondSince(startTime time.Time) float64 {
 	return float64(time.Since(startTime).Round(time.Millisecond).Nanoseconds()) / float64(time.Second)
 }
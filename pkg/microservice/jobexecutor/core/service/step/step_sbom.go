@@ -0,0 +1,94 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package step
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/koderover/zadig/v2/pkg/tool/log"
+	"github.com/koderover/zadig/v2/pkg/tool/s3"
+	"github.com/koderover/zadig/v2/pkg/tool/sbom"
+	"github.com/koderover/zadig/v2/pkg/types/step"
+)
+
+type SBOMStep struct {
+	spec       *step.StepSBOMSpec
+	envs       []string
+	secretEnvs []string
+	workspace  string
+	paths      string
+}
+
+func NewSBOMStep(spec interface{}, workspace, paths string, envs, secretEnvs []string) (*SBOMStep, error) {
+	sbomStep := &SBOMStep{workspace: workspace, paths: paths, envs: envs, secretEnvs: secretEnvs}
+	yamlBytes, err := yaml.Marshal(spec)
+	if err != nil {
+		return sbomStep, fmt.Errorf("marshal spec %+v failed", spec)
+	}
+	if err := yaml.Unmarshal(yamlBytes, &sbomStep.spec); err != nil {
+		return sbomStep, fmt.Errorf("unmarshal spec %s to sbom spec failed", yamlBytes)
+	}
+	return sbomStep, nil
+}
+
+func (s *SBOMStep) Run(ctx context.Context) error {
+	start := time.Now()
+	defer func() {
+		log.Infof("SBOM generation ended. Duration: %.2f seconds", time.Since(start).Seconds())
+	}()
+
+	if !sbom.IsValidFormat(s.spec.Format) {
+		return fmt.Errorf("unsupported sbom format: %s", s.spec.Format)
+	}
+	trivyBin, err := lookPath("trivy", s.paths)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Generating %s sbom for image %s", s.spec.Format, s.spec.Image)
+	sbomFile := filepath.Join(s.workspace, sbom.FileName(s.spec.Format))
+	cmd := exec.CommandContext(ctx, trivyBin, "image", "--format", s.spec.Format, "--quiet", "--output", sbomFile, s.spec.Image)
+	cmd.Dir = s.workspace
+	cmd.Env = s.envs
+	if reg := s.spec.DockerRegistry; reg != nil && reg.UserName != "" {
+		cmd.Env = append(cmd.Env, "TRIVY_USERNAME="+reg.UserName, "TRIVY_PASSWORD="+reg.Password)
+	}
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to generate sbom for image %s: %v, %s", s.spec.Image, err, strings.TrimSpace(string(out)))
+	}
+
+	if s.spec.S3 == nil || s.spec.ObjectKey == "" {
+		return fmt.Errorf("no object storage configured to archive the sbom")
+	}
+	client, err := s3.NewClient(s.spec.S3.Endpoint, s.spec.S3.Ak, s.spec.S3.Sk, s.spec.S3.Region, s.spec.S3.Insecure, s.spec.S3.Provider)
+	if err != nil {
+		return fmt.Errorf("failed to create s3 client to upload sbom, err: %s", err)
+	}
+	if err := client.Upload(s.spec.S3.Bucket, sbomFile, s.spec.ObjectKey); err != nil {
+		return fmt.Errorf("failed to upload sbom to %s, err: %s", s.spec.ObjectKey, err)
+	}
+	log.Infof("SBOM of image %s archived to %s", s.spec.Image, s.spec.ObjectKey)
+	return nil
+}
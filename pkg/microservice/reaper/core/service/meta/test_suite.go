@@ -65,3 +65,18 @@ type Error struct {
 	Type    string `bson:"type"     json:"type"    xml:"type,attr"`
 	Text    string `bson:"text"     json:"text"    xml:",chardata"`
 }
+
+// CountQuarantined returns the number of failed and error cases which are quarantined
+func (s *TestSuite) CountQuarantined(isQuarantined func(className, name string) bool) (failures, errors int) {
+	for _, tc := range s.TestCases {
+		if !isQuarantined(tc.ClassName, tc.Name) {
+			continue
+		}
+		if tc.Failure != nil {
+			failures++
+		} else if tc.Error != nil {
+			errors++
+		}
+	}
+	return failures, errors
+}
@@ -281,6 +281,8 @@ const (
 	SourceFromHelm = "helm"
 	//SourceFromExternal
 	SourceFromExternal = "external"
+	// SourceFromKustomize The k8s service is built from a kustomization directory in the code host
+	SourceFromKustomize = "kustomize"
 	// service from yaml template
 	ServiceSourceTemplate = "template"
 	SourceFromPM          = "pm"
@@ -936,6 +938,8 @@ const (
 	WorkflowScanningJobOutputKey        = "SonarCETaskID"
 	WorkflowScanningJobOutputKeyProject = "SonarProjectKey"
 	WorkflowScanningJobOutputKeyBranch  = "SonarBranchKey"
+
+	WorkflowImageScanJobOutputKey = "IMAGE_SCAN_RESULT"
 )
 
 type NotifyWebHookType string
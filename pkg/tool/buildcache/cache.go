@@ -0,0 +1,291 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buildcache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/koderover/zadig/v2/pkg/tool/s3"
+)
+
+const (
+	// DirName is the directory of the cache entries in the object storage, entries are grouped by project under it
+	DirName = "workflow-cache"
+
+	archiveSuffix = ".tar.gz"
+)
+
+type Entry struct {
+	Key          string `json:"key"`
+	ObjectKey    string `json:"object_key"`
+	Size         int64  `json:"size"`
+	LastModified int64  `json:"last_modified"`
+}
+
+// ProjectPrefix returns the object prefix of all the cache entries of the project
+func ProjectPrefix(subfolder, projectName string) string {
+	return strings.TrimLeft(path.Join(subfolder, DirName, projectName), "/") + "/"
+}
+
+func ObjectKey(subfolder, projectName, key string) string {
+	return ProjectPrefix(subfolder, projectName) + key + archiveSuffix
+}
+
+// RenderKey renders the key template in the workspace, the hashFiles function returns the sha256 of the files matching
+// the glob patterns relative to the workspace, "**" matches any number of directories.
+func RenderKey(key, workspace string) (string, error) {
+	tmpl, err := parseKey(key, workspace)
+	if err != nil {
+		return "", err
+	}
+	buf := &bytes.Buffer{}
+	if err := tmpl.Execute(buf, nil); err != nil {
+		return "", fmt.Errorf("failed to render cache key %s: %v", key, err)
+	}
+	return buf.String(), ValidateKey(buf.String())
+}
+
+// ValidateKeyTemplate checks the syntax of the key template without rendering it
+func ValidateKeyTemplate(key string) error {
+	if strings.TrimSpace(key) == "" {
+		return fmt.Errorf("cache key is empty")
+	}
+	_, err := parseKey(key, "")
+	return err
+}
+
+func parseKey(key, workspace string) (*template.Template, error) {
+	tmpl, err := template.New("cache-key").Funcs(template.FuncMap{
+		"hashFiles": func(patterns ...string) (string, error) {
+			return HashFiles(workspace, patterns...)
+		},
+	}).Option("missingkey=error").Parse(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cache key %s: %v", key, err)
+	}
+	return tmpl, nil
+}
+
+func ValidateKey(key string) error {
+	if key == "" {
+		return fmt.Errorf("cache key is empty")
+	}
+	if strings.ContainsAny(key, "/\\") || strings.Contains(key, "..") {
+		return fmt.Errorf("cache key %s can not contain path separators or \"..\"", key)
+	}
+	return nil
+}
+
+// HashFiles returns the sha256 of the files matching the patterns, an empty string is returned if no file matches.
+// the hash is calculated over the hashes of the files sorted by path, so it does not depend on the order of patterns.
+func HashFiles(workspace string, patterns ...string) (string, error) {
+	files := make([]string, 0)
+	err := filepath.Walk(workspace, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if info.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, err := filepath.Rel(workspace, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		for _, pattern := range patterns {
+			if MatchPattern(strings.TrimPrefix(pattern, "./"), rel) {
+				files = append(files, rel)
+				break
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to walk workspace %s: %v", workspace, err)
+	}
+	if len(files) == 0 {
+		return "", nil
+	}
+	sort.Strings(files)
+
+	sum := sha256.New()
+	for _, file := range files {
+		fileSum, err := hashFile(filepath.Join(workspace, file))
+		if err != nil {
+			return "", err
+		}
+		sum.Write(fileSum)
+	}
+	return hex.EncodeToString(sum.Sum(nil)), nil
+}
+
+func hashFile(file string) ([]byte, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	sum := sha256.New()
+	if _, err := io.Copy(sum, f); err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %v", file, err)
+	}
+	return sum.Sum(nil), nil
+}
+
+// MatchPattern reports whether the slash separated name matches the glob pattern, "**" matches zero or more directories
+func MatchPattern(pattern, name string) bool {
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchSegments(patterns, names []string) bool {
+	for len(patterns) > 0 {
+		if patterns[0] == "**" {
+			for i := 0; i <= len(names); i++ {
+				if matchSegments(patterns[1:], names[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(names) == 0 {
+			return false
+		}
+		if ok, err := path.Match(patterns[0], names[0]); err != nil || !ok {
+			return false
+		}
+		patterns, names = patterns[1:], names[1:]
+	}
+	return len(names) == 0
+}
+
+// Lookup finds the entry to restore: the entry of the key if exists, or the latest entry matching the first matched restore key.
+// an empty object key is returned if nothing matches.
+func Lookup(client *s3.Client, bucket, prefix, key string, restoreKeys []string) (objectKey string, exactMatch bool, err error) {
+	objects, err := client.ListObjectInfos(bucket, prefix)
+	if err != nil {
+		return "", false, err
+	}
+
+	exactKey := prefix + key + archiveSuffix
+	for _, object := range objects {
+		if object.Key == exactKey {
+			return exactKey, true, nil
+		}
+	}
+
+	for _, restoreKey := range restoreKeys {
+		if restoreKey == "" {
+			continue
+		}
+		var latest *s3.ObjectInfo
+		for _, object := range objects {
+			if !strings.HasPrefix(object.Key, prefix+restoreKey) || !strings.HasSuffix(object.Key, archiveSuffix) {
+				continue
+			}
+			if latest == nil || object.LastModified.After(latest.LastModified) {
+				latest = object
+			}
+		}
+		if latest != nil {
+			return latest.Key, false, nil
+		}
+	}
+	return "", false, nil
+}
+
+// List returns all the cache entries under the prefix
+func List(client *s3.Client, bucket, prefix string) ([]*Entry, error) {
+	objects, err := client.ListObjectInfos(bucket, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]*Entry, 0, len(objects))
+	for _, object := range objects {
+		if !strings.HasSuffix(object.Key, archiveSuffix) {
+			continue
+		}
+		resp = append(resp, &Entry{
+			Key:          strings.TrimSuffix(strings.TrimPrefix(object.Key, prefix), archiveSuffix),
+			ObjectKey:    object.Key,
+			Size:         object.Size,
+			LastModified: object.LastModified.Unix(),
+		})
+	}
+	return resp, nil
+}
+
+// Archive packs the paths into a gzipped tarball, relative paths are resolved against the workspace and kept relative in the tarball,
+// absolute paths are kept as they are. It returns false if none of the paths exists.
+func Archive(workspace, dest string, paths []string) (bool, error) {
+	args := []string{"-czPf", dest, "-C", workspace}
+	found := false
+	for _, p := range paths {
+		if p == "" {
+			continue
+		}
+		fullPath := p
+		if !filepath.IsAbs(p) {
+			fullPath = filepath.Join(workspace, p)
+		}
+		if _, err := os.Stat(fullPath); err != nil {
+			continue
+		}
+		args = append(args, p)
+		found = true
+	}
+	if !found {
+		return false, nil
+	}
+
+	out := &bytes.Buffer{}
+	cmd := exec.Command("tar", args...)
+	cmd.Stderr = out
+	if err := cmd.Run(); err != nil {
+		return false, fmt.Errorf("cmd: %s, err: %s %v", cmd.String(), out.String(), err)
+	}
+	return true, nil
+}
+
+// Extract unpacks the tarball created by Archive into the workspace
+func Extract(workspace, src string) error {
+	if err := os.MkdirAll(workspace, os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create workspace %s: %v", workspace, err)
+	}
+	out := &bytes.Buffer{}
+	cmd := exec.Command("tar", "-xzPf", src, "-C", workspace)
+	cmd.Stderr = out
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("cmd: %s, err: %s %v", cmd.String(), out.String(), err)
+	}
+	return nil
+}
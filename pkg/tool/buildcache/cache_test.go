@@ -0,0 +1,58 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buildcache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchPattern(t *testing.T) {
+	assert.True(t, MatchPattern("go.sum", "go.sum"))
+	assert.False(t, MatchPattern("go.sum", "sub/go.sum"))
+	assert.True(t, MatchPattern("**/go.sum", "go.sum"))
+	assert.True(t, MatchPattern("**/go.sum", "a/b/go.sum"))
+	assert.True(t, MatchPattern("web/**/*.json", "web/pkg/package-lock.json"))
+	assert.False(t, MatchPattern("web/*.json", "web/pkg/package-lock.json"))
+}
+
+func TestRenderKey(t *testing.T) {
+	workspace := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(workspace, "sub"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(workspace, "go.sum"), []byte("a"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(workspace, "sub", "go.sum"), []byte("b"), 0644))
+
+	key, err := RenderKey(`go-{{ hashFiles "go.sum" }}`, workspace)
+	assert.NoError(t, err)
+	all, err := RenderKey(`go-{{ hashFiles "**/go.sum" }}`, workspace)
+	assert.NoError(t, err)
+	assert.NotEqual(t, key, all)
+
+	again, err := RenderKey(`go-{{ hashFiles "sub/go.sum" "go.sum" }}`, workspace)
+	assert.NoError(t, err)
+	assert.Equal(t, all, again)
+
+	empty, err := RenderKey(`go-{{ hashFiles "missing" }}`, workspace)
+	assert.NoError(t, err)
+	assert.Equal(t, "go-", empty)
+
+	_, err = RenderKey(`../{{ hashFiles "go.sum" }}`, workspace)
+	assert.Error(t, err)
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cosign
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// SignatureAnnotation is the layer annotation holding the base64 encoded signature of the layer payload
+	SignatureAnnotation = "dev.cosignproject.cosign/signature"
+	// SimpleSigningMediaType is the media type of the signed payload layers
+	SimpleSigningMediaType = "application/vnd.dev.cosign.simplesigning.v1+json"
+)
+
+// Signature is a signature attached to an image by cosign
+type Signature struct {
+	Payload         []byte
+	Base64Signature string
+}
+
+// SimpleSigning is the payload signed by cosign
+type SimpleSigning struct {
+	Critical struct {
+		Identity struct {
+			DockerReference string `json:"docker-reference"`
+		} `json:"identity"`
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+		Type string `json:"type"`
+	} `json:"critical"`
+}
+
+// SignatureTag returns the tag where cosign stores the signatures of the image with the given manifest digest
+func SignatureTag(digest string) (string, error) {
+	algorithm, hex, ok := strings.Cut(digest, ":")
+	if !ok || algorithm == "" || hex == "" {
+		return "", fmt.Errorf("invalid image digest: %s", digest)
+	}
+	return fmt.Sprintf("%s-%s.sig", algorithm, hex), nil
+}
+
+// ParsePublicKey parses a PEM encoded ecdsa, rsa or ed25519 public key
+func ParsePublicKey(publicKey string) (crypto.PublicKey, error) {
+	block, _ := pem.Decode([]byte(publicKey))
+	if block == nil {
+		return nil, errors.New("invalid public key: no PEM block found")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %s", err)
+	}
+	switch key.(type) {
+	case *ecdsa.PublicKey, *rsa.PublicKey, ed25519.PublicKey:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T", key)
+	}
+}
+
+// VerifySignature checks that the signature is made by the key and signs the image with the given digest
+func VerifySignature(key crypto.PublicKey, signature *Signature, digest string) error {
+	sig, err := base64.StdEncoding.DecodeString(signature.Base64Signature)
+	if err != nil {
+		return fmt.Errorf("invalid signature encoding: %s", err)
+	}
+
+	hash := sha256.Sum256(signature.Payload)
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(k, hash[:], sig) {
+			return errors.New("signature does not match the key")
+		}
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(k, crypto.SHA256, hash[:], sig); err != nil {
+			return errors.New("signature does not match the key")
+		}
+	case ed25519.PublicKey:
+		if !ed25519.Verify(k, signature.Payload, sig) {
+			return errors.New("signature does not match the key")
+		}
+	default:
+		return fmt.Errorf("unsupported public key type %T", key)
+	}
+
+	payload := &SimpleSigning{}
+	if err := json.Unmarshal(signature.Payload, payload); err != nil {
+		return fmt.Errorf("invalid signature payload: %s", err)
+	}
+	if payload.Critical.Image.DockerManifestDigest != digest {
+		return fmt.Errorf("signature is made for digest %s, not %s", payload.Critical.Image.DockerManifestDigest, digest)
+	}
+	return nil
+}
+
+// Verify succeeds if any of the signatures is verified by any of the trusted public keys
+func Verify(publicKeys []string, signatures []*Signature, digest string) error {
+	if len(signatures) == 0 {
+		return errors.New("no signature found")
+	}
+
+	keys := make([]crypto.PublicKey, 0, len(publicKeys))
+	for _, publicKey := range publicKeys {
+		key, err := ParsePublicKey(publicKey)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, key)
+	}
+
+	var lastErr error
+	for _, signature := range signatures {
+		for _, key := range keys {
+			if lastErr = VerifySignature(key, signature, digest); lastErr == nil {
+				return nil
+			}
+		}
+	}
+	if lastErr == nil {
+		return errors.New("no trusted public key")
+	}
+	return fmt.Errorf("no signature verified by the trusted keys, last error: %s", lastErr)
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cosign
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testDigest = "sha256:4c6d4a1b8f0c0f5ad1bc5c5e3f7d3b61f20b6b2a5b3c6d0d7b4dfa0c9e1f2a3b"
+
+func newTestKey(t *testing.T) (*ecdsa.PrivateKey, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	assert.NoError(t, err)
+	return key, string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func sign(t *testing.T, key *ecdsa.PrivateKey, digest string) *Signature {
+	payload := []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":"example.com/app"},"image":{"docker-manifest-digest":"%s"},"type":"cosign container image signature"},"optional":null}`, digest))
+	hash := sha256.Sum256(payload)
+	sig, err := ecdsa.SignASN1(rand.Reader, key, hash[:])
+	assert.NoError(t, err)
+	return &Signature{Payload: payload, Base64Signature: base64.StdEncoding.EncodeToString(sig)}
+}
+
+func TestSignatureTag(t *testing.T) {
+	tag, err := SignatureTag(testDigest)
+	assert.NoError(t, err)
+	assert.Equal(t, "sha256-4c6d4a1b8f0c0f5ad1bc5c5e3f7d3b61f20b6b2a5b3c6d0d7b4dfa0c9e1f2a3b.sig", tag)
+
+	_, err = SignatureTag("latest")
+	assert.Error(t, err)
+}
+
+func TestVerify(t *testing.T) {
+	trustedKey, trustedPub := newTestKey(t)
+	otherKey, otherPub := newTestKey(t)
+
+	// signed by the trusted key
+	assert.NoError(t, Verify([]string{otherPub, trustedPub}, []*Signature{sign(t, otherKey, testDigest), sign(t, trustedKey, testDigest)}, testDigest))
+	// signed by an untrusted key only
+	assert.Error(t, Verify([]string{trustedPub}, []*Signature{sign(t, otherKey, testDigest)}, testDigest))
+	// signature made for another image
+	assert.Error(t, Verify([]string{trustedPub}, []*Signature{sign(t, trustedKey, "sha256:0000")}, testDigest))
+	// unsigned
+	assert.Error(t, Verify([]string{trustedPub}, nil, testDigest))
+	// tampered payload
+	sig := sign(t, trustedKey, testDigest)
+	sig.Payload = append(sig.Payload, ' ')
+	assert.Error(t, Verify([]string{trustedPub}, []*Signature{sig}, testDigest))
+	// invalid key
+	assert.Error(t, Verify([]string{"not a key"}, []*Signature{sign(t, trustedKey, testDigest)}, testDigest))
+}
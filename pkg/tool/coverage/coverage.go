@@ -0,0 +1,328 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package coverage
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	FormatCobertura = "cobertura"
+	FormatJaCoCo    = "jacoco"
+	FormatGo        = "go"
+	FormatLCOV      = "lcov"
+)
+
+// FileCoverage is the coverage of a source file, Covered and Total are lines,
+// except for the go format which has statements only
+type FileCoverage struct {
+	Name    string `bson:"name"    json:"name"`
+	Covered int    `bson:"covered" json:"covered"`
+	Total   int    `bson:"total"   json:"total"`
+}
+
+type Report struct {
+	Format  string          `bson:"format"  json:"format"`
+	Covered int             `bson:"covered" json:"covered"`
+	Total   int             `bson:"total"   json:"total"`
+	Files   []*FileCoverage `bson:"files"   json:"files"`
+}
+
+func IsValidFormat(format string) bool {
+	switch format {
+	case FormatCobertura, FormatJaCoCo, FormatGo, FormatLCOV:
+		return true
+	}
+	return false
+}
+
+// Rate returns the coverage percentage rounded to 2 decimal places
+func Rate(covered, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(covered)*10000/float64(total)) / 100
+}
+
+func (r *Report) Rate() float64 {
+	return Rate(r.Covered, r.Total)
+}
+
+func (f *FileCoverage) Rate() float64 {
+	return Rate(f.Covered, f.Total)
+}
+
+// Parse parses the coverage report of the given format
+func Parse(format string, data []byte) (*Report, error) {
+	var (
+		lines map[string]map[string]bool
+		err   error
+	)
+	switch format {
+	case FormatCobertura:
+		lines, err = parseCobertura(data)
+	case FormatJaCoCo:
+		lines, err = parseJaCoCo(data)
+	case FormatGo:
+		return parseGoCoverProfile(data)
+	case FormatLCOV:
+		lines, err = parseLCOV(data)
+	default:
+		return nil, fmt.Errorf("unsupported coverage format: %s", format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s coverage report: %s", format, err)
+	}
+	return newReport(format, lines), nil
+}
+
+// newReport summarizes the lines of each file, the key of the inner map is the line and the value is whether it is covered
+func newReport(format string, files map[string]map[string]bool) *Report {
+	report := &Report{Format: format, Files: make([]*FileCoverage, 0, len(files))}
+	for name, lines := range files {
+		file := &FileCoverage{Name: name, Total: len(lines)}
+		for _, covered := range lines {
+			if covered {
+				file.Covered++
+			}
+		}
+		report.Covered += file.Covered
+		report.Total += file.Total
+		report.Files = append(report.Files, file)
+	}
+	sort.Slice(report.Files, func(i, j int) bool {
+		return report.Files[i].Name < report.Files[j].Name
+	})
+	return report
+}
+
+func markLine(files map[string]map[string]bool, file, line string, covered bool) {
+	if _, ok := files[file]; !ok {
+		files[file] = make(map[string]bool)
+	}
+	// a line is covered if any of the records covers it
+	files[file][line] = files[file][line] || covered
+}
+
+type coberturaReport struct {
+	Packages []struct {
+		Classes []struct {
+			FileName string `xml:"filename,attr"`
+			Lines    []struct {
+				Number string `xml:"number,attr"`
+				Hits   int64  `xml:"hits,attr"`
+			} `xml:"lines>line"`
+		} `xml:"classes>class"`
+	} `xml:"packages>package"`
+}
+
+func parseCobertura(data []byte) (map[string]map[string]bool, error) {
+	report := new(coberturaReport)
+	if err := xml.Unmarshal(data, report); err != nil {
+		return nil, err
+	}
+	files := make(map[string]map[string]bool)
+	for _, pkg := range report.Packages {
+		for _, class := range pkg.Classes {
+			if _, ok := files[class.FileName]; !ok {
+				files[class.FileName] = make(map[string]bool)
+			}
+			for _, line := range class.Lines {
+				markLine(files, class.FileName, line.Number, line.Hits > 0)
+			}
+		}
+	}
+	return files, nil
+}
+
+type jacocoReport struct {
+	Packages []jacocoPackage `xml:"package"`
+	Groups   []struct {
+		Packages []jacocoPackage `xml:"package"`
+	} `xml:"group"`
+}
+
+type jacocoPackage struct {
+	Name        string `xml:"name,attr"`
+	SourceFiles []struct {
+		Name  string `xml:"name,attr"`
+		Lines []struct {
+			Nr string `xml:"nr,attr"`
+			CI int64  `xml:"ci,attr"`
+		} `xml:"line"`
+	} `xml:"sourcefile"`
+}
+
+func parseJaCoCo(data []byte) (map[string]map[string]bool, error) {
+	report := new(jacocoReport)
+	if err := xml.Unmarshal(data, report); err != nil {
+		return nil, err
+	}
+
+	packages := report.Packages
+	for _, group := range report.Groups {
+		packages = append(packages, group.Packages...)
+	}
+	files := make(map[string]map[string]bool)
+	for _, pkg := range packages {
+		for _, sourceFile := range pkg.SourceFiles {
+			name := sourceFile.Name
+			if pkg.Name != "" {
+				name = pkg.Name + "/" + sourceFile.Name
+			}
+			if _, ok := files[name]; !ok {
+				files[name] = make(map[string]bool)
+			}
+			for _, line := range sourceFile.Lines {
+				markLine(files, name, line.Nr, line.CI > 0)
+			}
+		}
+	}
+	return files, nil
+}
+
+// parseGoCoverProfile parses the go coverprofile, whose lines are like `file:startLine.startCol,endLine.endCol numStmts count`
+func parseGoCoverProfile(data []byte) (*Report, error) {
+	type block struct {
+		stmts   int
+		covered bool
+	}
+
+	blocks := make(map[string]map[string]*block)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "mode:") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("invalid go coverprofile line %d: %s", lineNum, line)
+		}
+		idx := strings.LastIndex(fields[0], ":")
+		if idx < 0 {
+			return nil, fmt.Errorf("invalid go coverprofile line %d: %s", lineNum, line)
+		}
+		stmts, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid go coverprofile line %d: %s", lineNum, line)
+		}
+		count, err := strconv.ParseInt(fields[2], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid go coverprofile line %d: %s", lineNum, line)
+		}
+
+		file, pos := fields[0][:idx], fields[0][idx+1:]
+		if _, ok := blocks[file]; !ok {
+			blocks[file] = make(map[string]*block)
+		}
+		// the same block appears multiple times if the profiles of several packages are merged
+		if b, ok := blocks[file][pos]; ok {
+			b.covered = b.covered || count > 0
+		} else {
+			blocks[file][pos] = &block{stmts: stmts, covered: count > 0}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	report := &Report{Format: FormatGo, Files: make([]*FileCoverage, 0, len(blocks))}
+	for name, fileBlocks := range blocks {
+		file := &FileCoverage{Name: name}
+		for _, b := range fileBlocks {
+			file.Total += b.stmts
+			if b.covered {
+				file.Covered += b.stmts
+			}
+		}
+		report.Covered += file.Covered
+		report.Total += file.Total
+		report.Files = append(report.Files, file)
+	}
+	sort.Slice(report.Files, func(i, j int) bool {
+		return report.Files[i].Name < report.Files[j].Name
+	})
+	return report, nil
+}
+
+func parseLCOV(data []byte) (map[string]map[string]bool, error) {
+	files := make(map[string]map[string]bool)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	current := ""
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(line, "SF:"):
+			current = strings.TrimPrefix(line, "SF:")
+			if _, ok := files[current]; !ok {
+				files[current] = make(map[string]bool)
+			}
+		case strings.HasPrefix(line, "DA:"):
+			if current == "" {
+				return nil, fmt.Errorf("line record %s found before source file", line)
+			}
+			// DA:<line number>,<execution count>[,<checksum>]
+			fields := strings.Split(strings.TrimPrefix(line, "DA:"), ",")
+			if len(fields) < 2 {
+				return nil, fmt.Errorf("invalid line record: %s", line)
+			}
+			hits, err := strconv.ParseFloat(fields[1], 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid line record: %s", line)
+			}
+			markLine(files, current, fields[0], hits > 0)
+		case line == "end_of_record":
+			current = ""
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// Delta returns the coverage change in percentage points compared with the base rate
+func Delta(rate, base float64) float64 {
+	return math.Round((rate-base)*100) / 100
+}
+
+// CheckGate returns an error if the coverage rate is lower than the threshold,
+// or drops more than maxDrop percentage points compared with the base rate, zero threshold and maxDrop mean no limit
+func CheckGate(rate, threshold, maxDrop float64, base *float64) error {
+	if threshold > 0 && rate < threshold {
+		return fmt.Errorf("coverage %.2f%% is lower than the threshold %.2f%%", rate, threshold)
+	}
+	if maxDrop > 0 && base != nil {
+		if drop := -Delta(rate, *base); drop > maxDrop {
+			return fmt.Errorf("coverage %.2f%% drops %.2f%% compared with the base coverage %.2f%%, more than the allowed %.2f%%", rate, drop, *base, maxDrop)
+		}
+	}
+	return nil
+}
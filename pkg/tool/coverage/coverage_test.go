@@ -0,0 +1,132 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package coverage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCobertura(t *testing.T) {
+	data := `<?xml version="1.0" ?>
+<coverage line-rate="0.6" branch-rate="0" version="1.9">
+  <packages>
+    <package name="app">
+      <classes>
+        <class name="Foo" filename="app/foo.py">
+          <lines>
+            <line number="1" hits="1"/>
+            <line number="2" hits="0"/>
+            <line number="3" hits="2"/>
+          </lines>
+        </class>
+        <class name="Bar" filename="app/foo.py">
+          <lines>
+            <line number="2" hits="3"/>
+            <line number="4" hits="0"/>
+          </lines>
+        </class>
+        <class name="Baz" filename="app/baz.py">
+          <lines>
+            <line number="1" hits="0"/>
+          </lines>
+        </class>
+      </classes>
+    </package>
+  </packages>
+</coverage>`
+	report, err := Parse(FormatCobertura, []byte(data))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, report.Covered)
+	assert.Equal(t, 5, report.Total)
+	assert.Equal(t, 60.0, report.Rate())
+	assert.Equal(t, []*FileCoverage{
+		{Name: "app/baz.py", Covered: 0, Total: 1},
+		{Name: "app/foo.py", Covered: 3, Total: 4},
+	}, report.Files)
+}
+
+func TestParseJaCoCo(t *testing.T) {
+	data := `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<!DOCTYPE report PUBLIC "-//JACOCO//DTD Report 1.1//EN" "report.dtd">
+<report name="demo">
+  <package name="com/example">
+    <class name="com/example/App" sourcefilename="App.java"/>
+    <sourcefile name="App.java">
+      <line nr="3" mi="0" ci="3" mb="0" cb="0"/>
+      <line nr="5" mi="2" ci="0" mb="0" cb="0"/>
+      <counter type="LINE" missed="1" covered="1"/>
+    </sourcefile>
+  </package>
+</report>`
+	report, err := Parse(FormatJaCoCo, []byte(data))
+	assert.NoError(t, err)
+	assert.Equal(t, []*FileCoverage{{Name: "com/example/App.java", Covered: 1, Total: 2}}, report.Files)
+	assert.Equal(t, 50.0, report.Rate())
+}
+
+func TestParseGoCoverProfile(t *testing.T) {
+	data := `mode: atomic
+github.com/koderover/demo/main.go:10.2,12.3 2 1
+github.com/koderover/demo/main.go:14.2,16.3 3 0
+github.com/koderover/demo/main.go:14.2,16.3 3 5
+github.com/koderover/demo/util.go:3.1,4.2 5 0
+`
+	report, err := Parse(FormatGo, []byte(data))
+	assert.NoError(t, err)
+	assert.Equal(t, 5, report.Covered)
+	assert.Equal(t, 10, report.Total)
+	assert.Equal(t, []*FileCoverage{
+		{Name: "github.com/koderover/demo/main.go", Covered: 5, Total: 5},
+		{Name: "github.com/koderover/demo/util.go", Covered: 0, Total: 5},
+	}, report.Files)
+
+	_, err = Parse(FormatGo, []byte("mode: set\nmain.go:1.1,2.2 x 1\n"))
+	assert.Error(t, err)
+}
+
+func TestParseLCOV(t *testing.T) {
+	data := `TN:
+SF:src/index.js
+DA:1,1
+DA:2,0
+DA:3,4,abcdef
+LF:3
+LH:2
+end_of_record
+SF:src/util.js
+DA:1,0
+end_of_record
+`
+	report, err := Parse(FormatLCOV, []byte(data))
+	assert.NoError(t, err)
+	assert.Equal(t, []*FileCoverage{
+		{Name: "src/index.js", Covered: 2, Total: 3},
+		{Name: "src/util.js", Covered: 0, Total: 1},
+	}, report.Files)
+	assert.Equal(t, 50.0, report.Rate())
+}
+
+func TestCheckGate(t *testing.T) {
+	base := 80.0
+	assert.NoError(t, CheckGate(70, 0, 0, &base))
+	assert.Error(t, CheckGate(59.99, 60, 0, nil))
+	assert.NoError(t, CheckGate(79.5, 60, 0.5, &base))
+	assert.Error(t, CheckGate(79.4, 60, 0.5, &base))
+	assert.NoError(t, CheckGate(50, 0, 1, nil))
+}
@@ -179,6 +179,15 @@ var (
 	ErrRollbackEnvServiceVersion = NewHTTPError(6079, "回滚环境服务版本失败")
 	ErrSetupPortalService        = NewHTTPError(6079, "设置入口服务失败")
 	ErrGetPortalService          = NewHTTPError(6079, "获取入口服务配置失败")
+	ErrCreateEnvSnapshot         = NewHTTPError(6079, "创建环境快照失败")
+	ErrListEnvSnapshots          = NewHTTPError(6079, "列出环境快照失败")
+	ErrDeleteEnvSnapshot         = NewHTTPError(6079, "删除环境快照失败")
+	ErrDiffEnvSnapshots          = NewHTTPError(6079, "Diff环境快照失败")
+	ErrRestoreEnvSnapshot        = NewHTTPError(6079, "恢复环境快照失败")
+	ErrListEnvDrifts             = NewHTTPError(6079, "列出环境配置漂移失败")
+	ErrReconcileEnvDrift         = NewHTTPError(6079, "修复环境配置漂移失败")
+	ErrAdoptEnvDrift             = NewHTTPError(6079, "接受环境配置漂移失败")
+	ErrExtendEnvTTL              = NewHTTPError(6079, "延长环境有效期失败")
 
 	//-----------------------------------------------------------------------------------------------
 	// Product Service APIs Range: 6080 - 6099 AND 6150 -6199
@@ -632,6 +641,9 @@ var (
 	ErrCreateActivity       = NewHTTPError(6664, "添加交付事件失败")
 	ErrFindActivities       = NewHTTPError(6665, "获取交付事件列表失败")
 	ErrCreateArtifactFailed = NewHTTPError(6666, "该交付物已经存在")
+	ErrFindSBOM             = NewHTTPError(6667, "获取SBOM信息失败")
+	ErrSearchSBOM           = NewHTTPError(6668, "搜索SBOM软件包失败")
+	ErrDownloadSBOM         = NewHTTPError(6669, "下载SBOM文件失败")
 
 	//-----------------------------------------------------------------------------------------------
 	// basicImage APIs Range: 6670 - 6679
@@ -974,4 +986,24 @@ var (
 	//-----------------------------------------------------------------------------------------------
 	ErrCreateApprovalTicket = NewHTTPError(7100, "创建预审批单失败")
 	ErrListApprovalTicket   = NewHTTPError(7101, "列出预审批单失败")
+
+	//-----------------------------------------------------------------------------------------------
+	// image signing key Error Range: 7120 - 7129
+	//-----------------------------------------------------------------------------------------------
+	ErrCreateImageSigningKey = NewHTTPError(7120, "创建镜像签名密钥失败")
+	ErrListImageSigningKey   = NewHTTPError(7121, "获取镜像签名密钥列表失败")
+	ErrUpdateImageSigningKey = NewHTTPError(7122, "更新镜像签名密钥失败")
+	ErrDeleteImageSigningKey = NewHTTPError(7123, "删除镜像签名密钥失败")
+	ErrGetImageSigningKey    = NewHTTPError(7124, "获取镜像签名密钥详情失败")
+
+	//-----------------------------------------------------------------------------------------------
+	// test analysis Error Range: 7130 - 7139
+	//-----------------------------------------------------------------------------------------------
+	ErrListTestCaseStat         = NewHTTPError(7130, "获取测试用例统计失败")
+	ErrListTestCaseHistory      = NewHTTPError(7131, "获取测试用例历史失败")
+	ErrListTestCaseQuarantine   = NewHTTPError(7132, "获取隔离测试用例列表失败")
+	ErrCreateTestCaseQuarantine = NewHTTPError(7133, "隔离测试用例失败")
+	ErrDeleteTestCaseQuarantine = NewHTTPError(7134, "取消隔离测试用例失败")
+	ErrGetTestCoverage          = NewHTTPError(7135, "获取代码覆盖率失败")
+	ErrListTestCoverage         = NewHTTPError(7136, "获取代码覆盖率趋势失败")
 )
@@ -22,7 +22,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
-	"net"
 	"net/http"
 	"net/url"
 	"os"
@@ -663,8 +662,7 @@ func (hClient *HelmClient) FetchIndexYaml(repoEntry *repo.Entry) (*repo.IndexFil
 }
 
 // DownloadChart works like executing `helm pull repoName/chartName --version=version'
-// since pulling from OCI Registry is still considered as an EXPERIMENTAL feature
-// we DO NOT support pulling charts by pulling OCI Artifacts from OCI Registry
+// charts in OCI Registry are pulled as OCI Artifacts, chartRef is resolved to oci://registry/ns/chartName
 // NOTE consider using os.execCommand('helm pull') to reduce code complexity of offering compatibility since third-party plugins CANNOT be used as SDK
 // if unTar is true, no need to mkdir for destDir
 // if unTar is no, your need to mkdir for destDir yourself
@@ -701,21 +699,11 @@ func (hClient *HelmClient) DownloadChart(repoEntry *repo.Entry, chartRef string,
 }
 
 func (hClient *HelmClient) downloadOCIChart(repoEntry *repo.Entry, chartRef string, chartVersion string, destDir string, unTar bool) error {
-	pullConfig := &action.Configuration{}
-	var err error
-	pullConfig.RegistryClient, err = registry.NewClient(
-		registry.ClientOptEnableCache(true),
-		registry.ClientOptDebug(true),
-		registry.ClientOptWriter(os.Stdout),
-	)
-	if err != nil {
-		return err
-	}
-	hostUrl := strings.TrimPrefix(repoEntry.URL, fmt.Sprintf("%s://", registry.OCIScheme))
-	err = pullConfig.RegistryClient.Login(hostUrl, registry.LoginOptBasicAuth(repoEntry.Username, repoEntry.Password))
+	registryClient, err := hClient.newOCIRegistryClient(repoEntry)
 	if err != nil {
 		return err
 	}
+	pullConfig := &action.Configuration{RegistryClient: registryClient}
 	pull := action.NewPullWithOpts(action.WithConfig(pullConfig))
 	pull.Password = repoEntry.Username
 	pull.Username = repoEntry.Password
@@ -864,43 +852,12 @@ func (hClient *HelmClient) pushChartMuseum(repoEntry *repo.Entry, chartPath stri
 }
 
 func (hClient *HelmClient) pushOCIRegistry(repoEntry *repo.Entry, chartPath string) error {
-	var err error
-
-	// copy from helm.sh/helm/v3/pkg/registry
-	httpclient := &http.Client{
-		// From https://github.com/google/go-containerregistry/blob/31786c6cbb82d6ec4fb8eb79cd9387905130534e/pkg/v1/remote/options.go#L87
-		Transport: &http.Transport{
-			DialContext: (&net.Dialer{
-				// By default we wrap the transport in retries, so reduce the
-				// default dial timeout to 5s to avoid 5x 30s of connection
-				// timeouts when doing the "ping" on certain http registries.
-				Timeout:   5 * time.Second,
-				KeepAlive: 30 * time.Second,
-			}).DialContext,
-			ForceAttemptHTTP2:     true,
-			MaxIdleConns:          100,
-			IdleConnTimeout:       90 * time.Second,
-			TLSHandshakeTimeout:   10 * time.Second,
-			ExpectContinueTimeout: 1 * time.Second,
-			Proxy:                 hClient.Transport.Proxy,
-			TLSClientConfig:       hClient.Transport.TLSClientConfig,
-		},
-	}
-
-	pushConfig := &action.Configuration{}
-	pushConfig.RegistryClient, err = registry.NewClient(
-		registry.ClientOptEnableCache(true),
-		registry.ClientOptDebug(true),
-		registry.ClientOptWriter(os.Stdout),
-		registry.ClientOptHTTPClient(httpclient),
-	)
-
-	hostUrl := strings.TrimPrefix(repoEntry.URL, fmt.Sprintf("%s://", registry.OCIScheme))
-	err = pushConfig.RegistryClient.Login(hostUrl, registry.LoginOptBasicAuth(repoEntry.Username, repoEntry.Password))
+	registryClient, err := hClient.newOCIRegistryClient(repoEntry)
 	if err != nil {
 		return err
 	}
 
+	pushConfig := &action.Configuration{RegistryClient: registryClient}
 	push := action.NewPushWithOpts(action.WithPushConfig(pushConfig))
 	push.Settings = generalSettings
 	_, err = push.Run(chartPath, repoEntry.URL)
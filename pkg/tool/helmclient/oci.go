@@ -0,0 +1,138 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helmclient
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/registry"
+	"helm.sh/helm/v3/pkg/repo"
+
+	"github.com/koderover/zadig/v2/pkg/tool/log"
+)
+
+// ParseOCIChartRef parses the chart reference like oci://registry/ns/chart:version into
+// the repository url oci://registry/ns, the chart name and the version which could be empty
+func ParseOCIChartRef(ref string) (repoURL, chartName, version string, err error) {
+	if !registry.IsOCI(ref) {
+		return "", "", "", fmt.Errorf("chart reference %s is not an oci reference", ref)
+	}
+	trimmed := strings.TrimSuffix(strings.TrimPrefix(ref, fmt.Sprintf("%s://", registry.OCIScheme)), "/")
+	idx := strings.LastIndex(trimmed, "/")
+	if idx <= 0 || idx == len(trimmed)-1 {
+		return "", "", "", fmt.Errorf("invalid oci chart reference: %s", ref)
+	}
+	repoURL = fmt.Sprintf("%s://%s", registry.OCIScheme, trimmed[:idx])
+	chartName = trimmed[idx+1:]
+	// the port is in the registry host, so the colon of the last element is the version separator
+	if i := strings.LastIndex(chartName, ":"); i >= 0 {
+		chartName, version = chartName[:i], chartName[i+1:]
+	}
+	if chartName == "" {
+		return "", "", "", fmt.Errorf("invalid oci chart reference: %s", ref)
+	}
+	return repoURL, chartName, version, nil
+}
+
+// OCIHost returns the registry host of the oci url
+func OCIHost(ociURL string) string {
+	trimmed := strings.TrimPrefix(ociURL, fmt.Sprintf("%s://", registry.OCIScheme))
+	return strings.SplitN(trimmed, "/", 2)[0]
+}
+
+func (hClient *HelmClient) newOCIRegistryClient(repoEntry *repo.Entry) (*registry.Client, error) {
+	// copy from helm.sh/helm/v3/pkg/registry
+	transport := &http.Transport{
+		// From https://github.com/google/go-containerregistry/blob/31786c6cbb82d6ec4fb8eb79cd9387905130534e/pkg/v1/remote/options.go#L87
+		DialContext: (&net.Dialer{
+			// By default we wrap the transport in retries, so reduce the
+			// default dial timeout to 5s to avoid 5x 30s of connection
+			// timeouts when doing the "ping" on certain http registries.
+			Timeout:   5 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+	if hClient.Transport != nil {
+		transport.Proxy = hClient.Transport.Proxy
+		transport.TLSClientConfig = hClient.Transport.TLSClientConfig
+	}
+
+	client, err := registry.NewClient(
+		registry.ClientOptEnableCache(true),
+		registry.ClientOptDebug(true),
+		registry.ClientOptWriter(os.Stdout),
+		registry.ClientOptHTTPClient(&http.Client{Transport: transport}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	// login with the registry host, the namespace in the repo url is not accepted by the registry client
+	if repoEntry.Username != "" || repoEntry.Password != "" {
+		err = client.Login(OCIHost(repoEntry.URL), registry.LoginOptBasicAuth(repoEntry.Username, repoEntry.Password))
+		if err != nil {
+			return nil, fmt.Errorf("failed to login oci registry %s, err: %s", OCIHost(repoEntry.URL), err)
+		}
+	}
+	return client, nil
+}
+
+// ListOCIChartVersions lists the semver tags of the chart in the oci repository, latest first
+func (hClient *HelmClient) ListOCIChartVersions(repoEntry *repo.Entry, chartName string) ([]string, error) {
+	client, err := hClient.newOCIRegistryClient(repoEntry)
+	if err != nil {
+		return nil, err
+	}
+	ref := fmt.Sprintf("%s/%s", strings.TrimPrefix(repoEntry.URL, fmt.Sprintf("%s://", registry.OCIScheme)), chartName)
+	return client.Tags(ref)
+}
+
+// FetchOCIIndex builds the index of the given charts from the oci repository since oci registries have no index.yaml,
+// charts which are not found in the repository are skipped
+func (hClient *HelmClient) FetchOCIIndex(repoEntry *repo.Entry, chartNames []string) (*repo.IndexFile, error) {
+	hClient.lock.Lock()
+	defer hClient.lock.Unlock()
+
+	index := repo.NewIndexFile()
+	for _, chartName := range chartNames {
+		versions, err := hClient.ListOCIChartVersions(repoEntry, chartName)
+		if err != nil {
+			log.Warnf("failed to list versions of chart %s from %s, err: %s", chartName, repoEntry.URL, err)
+			continue
+		}
+		for _, version := range versions {
+			index.Entries[chartName] = append(index.Entries[chartName], &repo.ChartVersion{
+				Metadata: &chart.Metadata{
+					Name:    chartName,
+					Version: version,
+				},
+				URLs: []string{fmt.Sprintf("%s/%s:%s", repoEntry.URL, chartName, version)},
+			})
+		}
+	}
+	return index, nil
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helmclient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseOCIChartRef(t *testing.T) {
+	repoURL, chartName, version, err := ParseOCIChartRef("oci://registry.local:5000/charts/nginx:1.2.0")
+	assert.NoError(t, err)
+	assert.Equal(t, "oci://registry.local:5000/charts", repoURL)
+	assert.Equal(t, "nginx", chartName)
+	assert.Equal(t, "1.2.0", version)
+
+	repoURL, chartName, version, err = ParseOCIChartRef("oci://registry.local/nginx")
+	assert.NoError(t, err)
+	assert.Equal(t, "oci://registry.local", repoURL)
+	assert.Equal(t, "nginx", chartName)
+	assert.Equal(t, "", version)
+
+	_, _, _, err = ParseOCIChartRef("oci://registry.local")
+	assert.Error(t, err)
+	_, _, _, err = ParseOCIChartRef("https://charts.local/nginx")
+	assert.Error(t, err)
+}
+
+func TestOCIHost(t *testing.T) {
+	assert.Equal(t, "registry.local:5000", OCIHost("oci://registry.local:5000/charts"))
+	assert.Equal(t, "registry.local", OCIHost("registry.local"))
+}
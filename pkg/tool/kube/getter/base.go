@@ -77,6 +77,19 @@ func ListUnstructuredResourceInCache(ns string, selector labels.Selector, fieldS
 	return res, err
 }
 
+// GetUnstructuredResourceInCache gets a specific Kubernetes object in local cache, and return a representation in unstructured format.
+// Return true if object is found, false if not, or an error if something bad happened.
+func GetUnstructuredResourceInCache(ns, name string, gvk schema.GroupVersionKind, cl client.Reader) (*unstructured.Unstructured, bool, error) {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(gvk)
+
+	found, err := GetResourceInCache(ns, name, u, cl)
+	if err != nil || !found {
+		return nil, false, err
+	}
+	return u, true, nil
+}
+
 // GetResourceJSONInCache gets a specific Kubernetes object in local cache, and return a representation in json format.
 // Return true if object is found, false if not, or an error if something bad happened.
 func GetResourceJSONInCache(ns, name string, gvk schema.GroupVersionKind, cl client.Reader) ([]byte, bool, error) {
@@ -0,0 +1,98 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kustomize
+
+import (
+	"fmt"
+	"path"
+
+	"sigs.k8s.io/kustomize/api/konfig"
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+	"sigs.k8s.io/yaml"
+)
+
+// imageOverrideDir holds the generated kustomization which sets the images on top of the target kustomization
+const imageOverrideDir = "/.zadig-images"
+
+type Image struct {
+	// Name is the image name without tag in the manifests
+	Name    string
+	NewName string
+	NewTag  string
+}
+
+// IsKustomizationDir checks whether the kustomization file exists in the directory
+func IsKustomizationDir(files map[string][]byte, dir string) bool {
+	for _, name := range konfig.RecognizedKustomizationFileNames() {
+		if _, ok := files[path.Join(dir, name)]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Build renders the kustomization in dir, files are keyed by the path relative to the kustomization root,
+// remote bases and helm charts are not supported since the files are built in memory
+func Build(files map[string][]byte, dir string, images []*Image) (string, error) {
+	fSys := filesys.MakeFsInMemory()
+	for name, content := range files {
+		filePath := path.Join("/", name)
+		if err := fSys.MkdirAll(path.Dir(filePath)); err != nil {
+			return "", fmt.Errorf("failed to create dir for %s: %s", name, err)
+		}
+		if err := fSys.WriteFile(filePath, content); err != nil {
+			return "", fmt.Errorf("failed to write file %s: %s", name, err)
+		}
+	}
+
+	target := path.Join("/", dir)
+	if len(images) > 0 {
+		kustomization := &types.Kustomization{
+			Resources: []string{path.Join("..", target)},
+		}
+		for _, image := range images {
+			kustomization.Images = append(kustomization.Images, types.Image{
+				Name:    image.Name,
+				NewName: image.NewName,
+				NewTag:  image.NewTag,
+			})
+		}
+		content, err := yaml.Marshal(kustomization)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal image kustomization: %s", err)
+		}
+		if err := fSys.MkdirAll(imageOverrideDir); err != nil {
+			return "", err
+		}
+		if err := fSys.WriteFile(path.Join(imageOverrideDir, konfig.DefaultKustomizationFileName()), content); err != nil {
+			return "", err
+		}
+		target = imageOverrideDir
+	}
+
+	resMap, err := krusty.MakeKustomizer(krusty.MakeDefaultOptions()).Run(fSys, target)
+	if err != nil {
+		return "", fmt.Errorf("failed to build kustomization %s: %s", dir, err)
+	}
+	out, err := resMap.AsYaml()
+	if err != nil {
+		return "", fmt.Errorf("failed to convert kustomize output to yaml: %s", err)
+	}
+	return string(out), nil
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kustomize
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testFiles = map[string][]byte{
+	"base/kustomization.yaml": []byte(`resources:
+- deployment.yaml
+`),
+	"base/deployment.yaml": []byte(`apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  template:
+    spec:
+      containers:
+      - name: web
+        image: nginx:1.25
+`),
+	"overlays/dev/kustomization.yaml": []byte(`resources:
+- ../../base
+namePrefix: dev-
+`),
+}
+
+func TestBuild(t *testing.T) {
+	out, err := Build(testFiles, "base", nil)
+	assert.NoError(t, err)
+	assert.Contains(t, out, "name: web\n")
+	assert.Contains(t, out, "image: nginx:1.25")
+
+	out, err = Build(testFiles, "overlays/dev", []*Image{{Name: "nginx", NewName: "registry.local/nginx", NewTag: "1.26"}})
+	assert.NoError(t, err)
+	assert.Contains(t, out, "name: dev-web")
+	assert.Contains(t, out, "image: registry.local/nginx:1.26")
+
+	_, err = Build(testFiles, "overlays/prod", nil)
+	assert.Error(t, err)
+}
+
+func TestIsKustomizationDir(t *testing.T) {
+	assert.True(t, IsKustomizationDir(testFiles, "overlays/dev"))
+	assert.False(t, IsKustomizationDir(testFiles, "overlays"))
+}
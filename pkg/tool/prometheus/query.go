@@ -0,0 +1,113 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prometheus
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type queryResp struct {
+	Status    string    `json:"status"`
+	Data      queryData `json:"data"`
+	ErrorType string    `json:"errorType"`
+	Error     string    `json:"error"`
+}
+
+type queryData struct {
+	ResultType string `json:"resultType"`
+	// Result is [unix timestamp, "value"] for a scalar, or a list of series for a vector
+	Result json.RawMessage `json:"result"`
+}
+
+type queryResult struct {
+	Metric map[string]string `json:"metric"`
+	// Value is [unix timestamp, "value"]
+	Value []interface{} `json:"value"`
+}
+
+// Query runs an instant query at ts and returns the value of the result,
+// the query is expected to return exactly one series or a scalar, e.g. sum(rate(http_requests_total{code=~"5.."}[5m])).
+func (c *Client) Query(query string, ts time.Time) (float64, error) {
+	resp := &queryResp{}
+	_, err := c.R().
+		SetQueryParam("query", query).
+		SetQueryParam("time", strconv.FormatInt(ts.Unix(), 10)).
+		SetSuccessResult(resp).
+		Get("/api/v1/query")
+	if err != nil {
+		return 0, err
+	}
+	if resp.Status != "success" {
+		return 0, errors.Errorf("query %s failed, %s: %s", query, resp.ErrorType, resp.Error)
+	}
+	return parseQueryResult(query, &resp.Data)
+}
+
+func parseQueryResult(query string, data *queryData) (float64, error) {
+	var value []interface{}
+	switch data.ResultType {
+	case "scalar":
+		if err := json.Unmarshal(data.Result, &value); err != nil {
+			return 0, errors.Errorf("query %s returns invalid scalar: %v", query, err)
+		}
+	case "vector":
+		result := make([]*queryResult, 0)
+		if err := json.Unmarshal(data.Result, &result); err != nil {
+			return 0, errors.Errorf("query %s returns invalid vector: %v", query, err)
+		}
+		if len(result) == 0 {
+			return 0, errors.Errorf("query %s returns no data", query)
+		}
+		if len(result) > 1 {
+			return 0, errors.Errorf("query %s returns %d series, it should be aggregated into one", query, len(result))
+		}
+		value = result[0].Value
+	default:
+		return 0, errors.Errorf("query %s returns unsupported result type %s", query, data.ResultType)
+	}
+	if len(value) != 2 {
+		return 0, errors.Errorf("query %s returns invalid value %v", query, value)
+	}
+	s, ok := value[1].(string)
+	if !ok {
+		return 0, errors.Errorf("query %s returns invalid value %v", query, value[1])
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+// Compare reports whether value meets the condition "value operator threshold"
+func Compare(value float64, operator string, threshold float64) (bool, error) {
+	switch operator {
+	case "<":
+		return value < threshold, nil
+	case "<=":
+		return value <= threshold, nil
+	case ">":
+		return value > threshold, nil
+	case ">=":
+		return value >= threshold, nil
+	case "==":
+		return value == threshold, nil
+	default:
+		return false, fmt.Errorf("invalid operator: %s", operator)
+	}
+}
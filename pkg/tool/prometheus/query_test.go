@@ -0,0 +1,50 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prometheus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseQueryResult(t *testing.T) {
+	value, err := parseQueryResult("q", &queryData{ResultType: "scalar", Result: []byte(`[1700000000.1, "0.5"]`)})
+	assert.NoError(t, err)
+	assert.Equal(t, 0.5, value)
+
+	value, err = parseQueryResult("q", &queryData{ResultType: "vector", Result: []byte(`[{"metric": {}, "value": [1700000000, "12"]}]`)})
+	assert.NoError(t, err)
+	assert.Equal(t, float64(12), value)
+
+	_, err = parseQueryResult("q", &queryData{ResultType: "vector", Result: []byte(`[]`)})
+	assert.Error(t, err)
+	_, err = parseQueryResult("q", &queryData{ResultType: "vector", Result: []byte(`[{"value": [1, "1"]}, {"value": [1, "2"]}]`)})
+	assert.Error(t, err)
+	_, err = parseQueryResult("q", &queryData{ResultType: "matrix", Result: []byte(`[]`)})
+	assert.Error(t, err)
+}
+
+func TestCompare(t *testing.T) {
+	ok, err := Compare(0.01, "<", 0.05)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	ok, _ = Compare(2, "<=", 1)
+	assert.False(t, ok)
+	_, err = Compare(1, "!=", 1)
+	assert.Error(t, err)
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sbom
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	FormatCycloneDX = "cyclonedx"
+	FormatSPDX      = "spdx-json"
+)
+
+func IsValidFormat(format string) bool {
+	return format == FormatCycloneDX || format == FormatSPDX
+}
+
+// FileName returns the name of the sbom file generated for the given format
+func FileName(format string) string {
+	if format == FormatSPDX {
+		return "sbom.spdx.json"
+	}
+	return "sbom.cdx.json"
+}
+
+// Package is a software component listed in a sbom
+type Package struct {
+	Name    string `bson:"name"    json:"name"`
+	Version string `bson:"version" json:"version"`
+	PURL    string `bson:"purl"    json:"purl"`
+}
+
+type cycloneDXDocument struct {
+	BOMFormat  string                `json:"bomFormat"`
+	Components []*cycloneDXComponent `json:"components"`
+}
+
+type cycloneDXComponent struct {
+	Type       string                `json:"type"`
+	Name       string                `json:"name"`
+	Version    string                `json:"version"`
+	PURL       string                `json:"purl"`
+	Components []*cycloneDXComponent `json:"components"`
+}
+
+type spdxDocument struct {
+	SPDXVersion string         `json:"spdxVersion"`
+	Packages    []*spdxPackage `json:"packages"`
+}
+
+type spdxPackage struct {
+	Name         string             `json:"name"`
+	VersionInfo  string             `json:"versionInfo"`
+	ExternalRefs []*spdxExternalRef `json:"externalRefs"`
+}
+
+type spdxExternalRef struct {
+	ReferenceType    string `json:"referenceType"`
+	ReferenceLocator string `json:"referenceLocator"`
+}
+
+// ParsePackages extracts the packages from a sbom document of the given format,
+// components without a package url (the image itself, os, layers) are ignored
+func ParsePackages(format string, data []byte) ([]*Package, error) {
+	var packages []*Package
+	switch format {
+	case FormatCycloneDX:
+		doc := &cycloneDXDocument{}
+		if err := json.Unmarshal(data, doc); err != nil {
+			return nil, fmt.Errorf("failed to parse cyclonedx sbom: %s", err)
+		}
+		if doc.BOMFormat != "CycloneDX" {
+			return nil, fmt.Errorf("invalid cyclonedx sbom: bomFormat is %q", doc.BOMFormat)
+		}
+		packages = collectCycloneDXComponents(doc.Components, packages)
+	case FormatSPDX:
+		doc := &spdxDocument{}
+		if err := json.Unmarshal(data, doc); err != nil {
+			return nil, fmt.Errorf("failed to parse spdx sbom: %s", err)
+		}
+		if !strings.HasPrefix(doc.SPDXVersion, "SPDX-") {
+			return nil, fmt.Errorf("invalid spdx sbom: spdxVersion is %q", doc.SPDXVersion)
+		}
+		for _, pkg := range doc.Packages {
+			purl := ""
+			for _, ref := range pkg.ExternalRefs {
+				if ref.ReferenceType == "purl" {
+					purl = ref.ReferenceLocator
+					break
+				}
+			}
+			if purl == "" {
+				continue
+			}
+			packages = append(packages, &Package{Name: pkg.Name, Version: pkg.VersionInfo, PURL: purl})
+		}
+	default:
+		return nil, fmt.Errorf("unsupported sbom format: %s", format)
+	}
+
+	return dedupPackages(packages), nil
+}
+
+func collectCycloneDXComponents(components []*cycloneDXComponent, packages []*Package) []*Package {
+	for _, component := range components {
+		if component.PURL != "" {
+			packages = append(packages, &Package{Name: component.Name, Version: component.Version, PURL: component.PURL})
+		}
+		packages = collectCycloneDXComponents(component.Components, packages)
+	}
+	return packages
+}
+
+func dedupPackages(packages []*Package) []*Package {
+	seen := make(map[string]bool)
+	resp := make([]*Package, 0, len(packages))
+	for _, pkg := range packages {
+		if seen[pkg.PURL] {
+			continue
+		}
+		seen[pkg.PURL] = true
+		resp = append(resp, pkg)
+	}
+	sort.Slice(resp, func(i, j int) bool {
+		if resp[i].Name != resp[j].Name {
+			return resp[i].Name < resp[j].Name
+		}
+		return resp[i].Version < resp[j].Version
+	})
+	return resp
+}
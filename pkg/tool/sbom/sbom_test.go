@@ -0,0 +1,74 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sbom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const cycloneDXSBOM = `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "components": [
+    {"type": "operating-system", "name": "alpine", "version": "3.19.1"},
+    {"type": "library", "name": "openssl", "version": "3.1.4-r5", "purl": "pkg:apk/alpine/openssl@3.1.4-r5"},
+    {"type": "library", "name": "musl", "version": "1.2.4-r2", "purl": "pkg:apk/alpine/musl@1.2.4-r2"},
+    {"type": "application", "name": "app", "components": [
+      {"type": "library", "name": "github.com/gin-gonic/gin", "version": "v1.9.1", "purl": "pkg:golang/github.com/gin-gonic/gin@v1.9.1"},
+      {"type": "library", "name": "openssl", "version": "3.1.4-r5", "purl": "pkg:apk/alpine/openssl@3.1.4-r5"}
+    ]}
+  ]
+}`
+
+const spdxSBOM = `{
+  "spdxVersion": "SPDX-2.3",
+  "packages": [
+    {"name": "nginx:1.25", "SPDXID": "SPDXRef-ContainerImage"},
+    {"name": "zlib", "versionInfo": "1.3-r2", "externalRefs": [
+      {"referenceCategory": "PACKAGE-MANAGER", "referenceType": "purl", "referenceLocator": "pkg:apk/alpine/zlib@1.3-r2"}
+    ]},
+    {"name": "curl", "versionInfo": "8.5.0-r0", "externalRefs": [
+      {"referenceCategory": "SECURITY", "referenceType": "cpe23Type", "referenceLocator": "cpe:2.3:a:curl:curl:8.5.0"},
+      {"referenceCategory": "PACKAGE-MANAGER", "referenceType": "purl", "referenceLocator": "pkg:apk/alpine/curl@8.5.0-r0"}
+    ]}
+  ]
+}`
+
+func TestParsePackages(t *testing.T) {
+	packages, err := ParsePackages(FormatCycloneDX, []byte(cycloneDXSBOM))
+	assert.NoError(t, err)
+	assert.Equal(t, []*Package{
+		{Name: "github.com/gin-gonic/gin", Version: "v1.9.1", PURL: "pkg:golang/github.com/gin-gonic/gin@v1.9.1"},
+		{Name: "musl", Version: "1.2.4-r2", PURL: "pkg:apk/alpine/musl@1.2.4-r2"},
+		{Name: "openssl", Version: "3.1.4-r5", PURL: "pkg:apk/alpine/openssl@3.1.4-r5"},
+	}, packages)
+
+	packages, err = ParsePackages(FormatSPDX, []byte(spdxSBOM))
+	assert.NoError(t, err)
+	assert.Equal(t, []*Package{
+		{Name: "curl", Version: "8.5.0-r0", PURL: "pkg:apk/alpine/curl@8.5.0-r0"},
+		{Name: "zlib", Version: "1.3-r2", PURL: "pkg:apk/alpine/zlib@1.3-r2"},
+	}, packages)
+
+	_, err = ParsePackages(FormatSPDX, []byte(cycloneDXSBOM))
+	assert.Error(t, err)
+
+	_, err = ParsePackages("syft-json", []byte(cycloneDXSBOM))
+	assert.Error(t, err)
+}
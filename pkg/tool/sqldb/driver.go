@@ -0,0 +1,53 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sqldb
+
+import (
+	"database/sql"
+	"fmt"
+
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+)
+
+const (
+	TypeMySQL      = "mysql"
+	TypeMariaDB    = "mariadb"
+	TypePostgreSQL = "postgresql"
+)
+
+// Driver opens a db instance and runs the sql statements for a kind of database
+type Driver interface {
+	// Open opens the database, the default database is used if the database is empty,
+	// sslMode is only used by PostgreSQL and TLS is disabled if it's empty
+	Open(host, port, username, password, database, sslMode string) (*sql.DB, error)
+	// SplitStatements splits the sql into statements which can be executed one by one
+	SplitStatements(sql string) ([]string, error)
+	// CanRollback reports whether the statement can be rolled back in a transaction
+	CanRollback(statement string) bool
+}
+
+func GetDriver(dbType string) (Driver, error) {
+	switch dbType {
+	case TypeMySQL, TypeMariaDB:
+		return &mysqlDriver{}, nil
+	case TypePostgreSQL:
+		return &postgresDriver{}, nil
+	default:
+		return nil, fmt.Errorf("not supported db type: %s", dbType)
+	}
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sqldb
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/pingcap/tidb/parser"
+	"github.com/pingcap/tidb/parser/ast"
+	_ "github.com/pingcap/tidb/parser/test_driver"
+)
+
+type mysqlDriver struct{}
+
+func (d *mysqlDriver) Open(host, port, username, password, database, sslMode string) (*sql.DB, error) {
+	return sql.Open("mysql", fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8&multiStatements=true", username, password, host, port, database))
+}
+
+func (d *mysqlDriver) SplitStatements(sql string) ([]string, error) {
+	return splitStatements(sql, false)
+}
+
+// CanRollback returns false for the DDL statements, which cause an implicit commit in MySQL
+func (d *mysqlDriver) CanRollback(statement string) bool {
+	stmt, err := parser.New().ParseOneStmt(statement, "", "")
+	if err != nil {
+		// let the database report the error
+		return true
+	}
+	_, isDDL := stmt.(ast.DDLNode)
+	return !isDDL
+}
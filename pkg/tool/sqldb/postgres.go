@@ -0,0 +1,52 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sqldb
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+)
+
+type postgresDriver struct{}
+
+func (d *postgresDriver) Open(host, port, username, password, database, sslMode string) (*sql.DB, error) {
+	if database == "" {
+		database = "postgres"
+	}
+	if sslMode == "" {
+		sslMode = "disable"
+	}
+	dsn := &url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(username, password),
+		Host:     fmt.Sprintf("%s:%s", host, port),
+		Path:     database,
+		RawQuery: url.Values{"sslmode": []string{sslMode}}.Encode(),
+	}
+	return sql.Open("postgres", dsn.String())
+}
+
+func (d *postgresDriver) SplitStatements(sql string) ([]string, error) {
+	return splitStatements(sql, true)
+}
+
+// CanRollback returns true since the DDL statements are transactional in PostgreSQL,
+// the statements can not run in a transaction, like CREATE DATABASE, fail and are reported by the database.
+func (d *postgresDriver) CanRollback(statement string) bool {
+	return true
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sqldb
+
+import (
+	"fmt"
+	"strings"
+)
+
+// splitStatements splits the sql by the semicolons out of the quotes and the comments,
+// the dollar quoted strings like $$...$$ or $body$...$body$ are recognized for PostgreSQL,
+// and the # comments are recognized for MySQL.
+func splitStatements(sql string, dollarQuote bool) ([]string, error) {
+	resp := make([]string, 0)
+	start := 0
+	appendStatement := func(end int) {
+		if statement := strings.TrimSpace(sql[start:end]); statement != "" && statement != ";" {
+			resp = append(resp, statement)
+		}
+		start = end
+	}
+
+	for i := 0; i < len(sql); i++ {
+		switch c := sql[i]; {
+		case c == '\'' || c == '"' || c == '`':
+			end := i + 1
+			for ; end < len(sql); end++ {
+				if sql[end] == '\\' && c != '`' && !dollarQuote {
+					end++
+					continue
+				}
+				if sql[end] == c {
+					// a doubled quote is an escaped quote
+					if end+1 < len(sql) && sql[end+1] == c {
+						end++
+						continue
+					}
+					break
+				}
+			}
+			if end >= len(sql) {
+				return nil, fmt.Errorf("unterminated quoted string: %s", sql[i:])
+			}
+			i = end
+		case c == '-' && strings.HasPrefix(sql[i:], "--"), c == '#' && !dollarQuote:
+			end := strings.IndexByte(sql[i:], '\n')
+			if end == -1 {
+				i = len(sql)
+			} else {
+				i += end
+			}
+		case c == '/' && strings.HasPrefix(sql[i:], "/*"):
+			end := strings.Index(sql[i+2:], "*/")
+			if end == -1 {
+				return nil, fmt.Errorf("unterminated comment: %s", sql[i:])
+			}
+			i += end + 3
+		case c == '$' && dollarQuote:
+			tag := dollarQuoteTag(sql[i:])
+			if tag == "" {
+				continue
+			}
+			end := strings.Index(sql[i+len(tag):], tag)
+			if end == -1 {
+				return nil, fmt.Errorf("unterminated dollar quoted string: %s", sql[i:])
+			}
+			i += len(tag) + end + len(tag) - 1
+		case c == ';':
+			appendStatement(i + 1)
+		}
+	}
+	appendStatement(len(sql))
+	return resp, nil
+}
+
+// dollarQuoteTag returns the tag like $$ or $body$ at the beginning of s, or empty if it is not a dollar quote
+func dollarQuoteTag(s string) string {
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+		if c == '$' {
+			return s[:i+1]
+		}
+		if !(c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' && i > 1) {
+			return ""
+		}
+	}
+	return ""
+}
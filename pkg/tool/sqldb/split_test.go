@@ -0,0 +1,52 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sqldb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitStatements(t *testing.T) {
+	statements, err := splitStatements("insert into t values ('a;b', \"c;d\");\n-- comment; here\n# mysql comment;\nupdate t set a = 'it''s';\n/* block; */ delete from t\n", false)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"insert into t values ('a;b', \"c;d\");",
+		"-- comment; here\n# mysql comment;\nupdate t set a = 'it''s';",
+		"/* block; */ delete from t",
+	}, statements)
+
+	statements, err = splitStatements("create function f() returns int as $body$ begin return 1; end; $body$ language plpgsql;\nselect $1;\nselect $$a;b$$;", true)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"create function f() returns int as $body$ begin return 1; end; $body$ language plpgsql;",
+		"select $1;",
+		"select $$a;b$$;",
+	}, statements)
+
+	_, err = splitStatements("select 'a;", false)
+	assert.Error(t, err)
+	_, err = splitStatements("select $$a;", true)
+	assert.Error(t, err)
+}
+
+func TestMySQLCanRollback(t *testing.T) {
+	d := &mysqlDriver{}
+	assert.True(t, d.CanRollback("update t set a = 1"))
+	assert.False(t, d.CanRollback("alter table t add column b int"))
+}
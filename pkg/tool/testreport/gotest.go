@@ -0,0 +1,116 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testreport
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/reaper/core/service/meta"
+)
+
+// goTestParser parses the `go test -json` (test2json) output, non-json lines are ignored
+type goTestParser struct{}
+
+type goTestEvent struct {
+	Action  string  `json:"Action"`
+	Package string  `json:"Package"`
+	Test    string  `json:"Test"`
+	Elapsed float64 `json:"Elapsed"`
+	Output  string  `json:"Output"`
+}
+
+func (p *goTestParser) Match(fileName string) bool {
+	return matchExt(fileName, ".json", ".jsonl")
+}
+
+func (p *goTestParser) Parse(fileName string, data []byte) (*meta.TestSuite, error) {
+	var (
+		cases []meta.TestCase
+		// outputs is keyed by package and test, package level output has an empty test name
+		outputs = make(map[[2]string]*strings.Builder)
+		// failed is keyed by package which has failed tests
+		failed = make(map[string]bool)
+	)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		event := &goTestEvent{}
+		if err := json.Unmarshal(scanner.Bytes(), event); err != nil || event.Action == "" {
+			continue
+		}
+		key := [2]string{event.Package, event.Test}
+
+		switch event.Action {
+		case "output":
+			if outputs[key] == nil {
+				outputs[key] = &strings.Builder{}
+			}
+			outputs[key].WriteString(event.Output)
+		case "pass", "fail", "skip":
+			output := ""
+			if outputs[key] != nil {
+				output = outputs[key].String()
+			}
+			delete(outputs, key)
+
+			if event.Test == "" {
+				// a failed package without any failed test is usually a build failure or a panic out of tests
+				if event.Action == "fail" && !failed[event.Package] {
+					cases = append(cases, meta.TestCase{
+						Name:      event.Package,
+						ClassName: event.Package,
+						Time:      event.Elapsed,
+						Error: &meta.Error{
+							Message: "package failed",
+							Type:    "PackageFailure",
+							Text:    output,
+						},
+					})
+				}
+				continue
+			}
+
+			tc := meta.TestCase{
+				Name:      event.Test,
+				ClassName: event.Package,
+				Time:      event.Elapsed,
+			}
+			switch event.Action {
+			case "fail":
+				tc.Failure = &meta.Failure{
+					Message: "test failed",
+					Type:    "Failure",
+					Text:    output,
+				}
+				failed[event.Package] = true
+			case "skip":
+				tc.Skipped = &meta.Skipped{}
+				tc.SystemOut = output
+			}
+			cases = append(cases, tc)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return newTestSuite(className(fileName), cases), nil
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testreport
+
+import (
+	"bufio"
+	"bytes"
+	"regexp"
+	"strings"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/reaper/core/service/meta"
+)
+
+var tapResultRegexp = regexp.MustCompile(`^(not ok|ok)\b\s*(\d+)?\s*-?\s*([^#]*?)\s*(?:#\s*(\w+)\b\s*(.*))?$`)
+
+// tapParser parses the Test Anything Protocol output, the YAML diagnostics block
+// following a failed test point is used as the failure text
+type tapParser struct{}
+
+func (p *tapParser) Match(fileName string) bool {
+	return matchExt(fileName, ".tap")
+}
+
+func (p *tapParser) Parse(fileName string, data []byte) (*meta.TestSuite, error) {
+	var (
+		cases      []meta.TestCase
+		inYAML     bool
+		diagnostic []string
+	)
+	suiteName := className(fileName)
+
+	flushDiagnostic := func() {
+		if len(cases) > 0 && len(diagnostic) > 0 {
+			last := &cases[len(cases)-1]
+			text := strings.Join(diagnostic, "\n")
+			if last.Failure != nil {
+				last.Failure.Text = text
+			} else {
+				last.SystemOut = text
+			}
+		}
+		diagnostic = nil
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+
+		if inYAML {
+			if trimmed == "..." {
+				inYAML = false
+				flushDiagnostic()
+			} else {
+				diagnostic = append(diagnostic, strings.TrimPrefix(line, "  "))
+			}
+			continue
+		}
+		if trimmed == "---" && len(cases) > 0 {
+			inYAML = true
+			continue
+		}
+		// indented lines are subtests, only the top level test points are reported
+		if strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") {
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "Bail out!") {
+			cases = append(cases, meta.TestCase{
+				Name:      "Bail out",
+				ClassName: suiteName,
+				Error: &meta.Error{
+					Message: strings.TrimSpace(strings.TrimPrefix(trimmed, "Bail out!")),
+					Type:    "BailOut",
+				},
+			})
+			break
+		}
+
+		matches := tapResultRegexp.FindStringSubmatch(trimmed)
+		if matches == nil {
+			continue
+		}
+		name := matches[3]
+		if name == "" {
+			name = "test " + matches[2]
+		}
+		tc := meta.TestCase{
+			Name:      name,
+			ClassName: suiteName,
+		}
+		directive := strings.ToUpper(matches[4])
+		switch {
+		// failed todo tests are not treated as failures as defined by the protocol
+		case strings.HasPrefix(directive, "SKIP"), strings.HasPrefix(directive, "TODO"):
+			tc.Skipped = &meta.Skipped{}
+		case matches[1] == "not ok":
+			tc.Failure = &meta.Failure{
+				Message: trimmed,
+				Type:    "NotOK",
+			}
+		}
+		cases = append(cases, tc)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	flushDiagnostic()
+
+	return newTestSuite(suiteName, cases), nil
+}
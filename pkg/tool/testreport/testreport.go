@@ -0,0 +1,109 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testreport
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/reaper/core/service/meta"
+)
+
+const (
+	FormatJUnit  = "junit"
+	FormatTAP    = "tap"
+	FormatTRX    = "trx"
+	FormatGoTest = "gotest"
+)
+
+// Parser normalizes a test report file of a specific format into a junit style test suite,
+// Tests of the returned suite includes the skipped cases
+type Parser interface {
+	// Match reports whether the file in the test result directory should be parsed
+	Match(fileName string) bool
+	Parse(fileName string, data []byte) (*meta.TestSuite, error)
+}
+
+var parsers = map[string]Parser{
+	FormatTAP:    &tapParser{},
+	FormatTRX:    &trxParser{},
+	FormatGoTest: &goTestParser{},
+}
+
+// Register adds or replaces the parser of the given format
+func Register(format string, parser Parser) {
+	parsers[format] = parser
+}
+
+// IsValidFormat checks the test result format, empty format means junit
+func IsValidFormat(format string) bool {
+	if format == "" || format == FormatJUnit {
+		return true
+	}
+	_, ok := parsers[format]
+	return ok
+}
+
+// GetParser returns the parser of the given format, junit reports are merged by the junit report step itself
+func GetParser(format string) (Parser, error) {
+	parser, ok := parsers[format]
+	if !ok {
+		return nil, fmt.Errorf("unsupported test result format: %s", format)
+	}
+	return parser, nil
+}
+
+func matchExt(fileName string, exts ...string) bool {
+	ext := strings.ToLower(filepath.Ext(fileName))
+	for _, e := range exts {
+		if ext == e {
+			return true
+		}
+	}
+	return false
+}
+
+func className(fileName string) string {
+	base := filepath.Base(fileName)
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
+
+// newTestSuite summarizes the test cases into a test suite
+func newTestSuite(name string, cases []meta.TestCase) *meta.TestSuite {
+	suite := &meta.TestSuite{
+		Name:      name,
+		TestCases: cases,
+	}
+	if suite.TestCases == nil {
+		suite.TestCases = []meta.TestCase{}
+	}
+	for _, tc := range cases {
+		suite.Tests++
+		suite.Time += tc.Time
+		switch {
+		case tc.Failure != nil:
+			suite.Failures++
+		case tc.Error != nil:
+			suite.Errors++
+		case tc.Skipped != nil:
+			suite.Skips++
+		}
+	}
+	suite.Successes = suite.Tests - suite.Failures - suite.Errors - suite.Skips
+	return suite
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testreport
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTAP(t *testing.T) {
+	data := `TAP version 13
+1..5
+ok 1 - login works
+not ok 2 - logout works
+  ---
+  message: expected 200
+  ...
+ok 3 - upload # SKIP no s3
+not ok 4 - flaky # TODO fix later
+    ok 1 - nested subtest
+ok 5
+`
+	parser, err := GetParser(FormatTAP)
+	assert.NoError(t, err)
+	assert.True(t, parser.Match("result/api.tap"))
+	assert.False(t, parser.Match("result/api.xml"))
+
+	suite, err := parser.Parse("result/api.tap", []byte(data))
+	assert.NoError(t, err)
+	assert.Equal(t, 5, suite.Tests)
+	assert.Equal(t, 1, suite.Failures)
+	assert.Equal(t, 2, suite.Skips)
+	assert.Equal(t, 2, suite.Successes)
+	assert.Equal(t, "api", suite.TestCases[0].ClassName)
+	assert.Equal(t, "logout works", suite.TestCases[1].Name)
+	assert.Equal(t, "message: expected 200", suite.TestCases[1].Failure.Text)
+	assert.Equal(t, "test 5", suite.TestCases[4].Name)
+}
+
+func TestParseTAPBailOut(t *testing.T) {
+	data := `1..3
+ok 1 - first
+Bail out! database is down
+ok 2 - second
+`
+	parser, _ := GetParser(FormatTAP)
+	suite, err := parser.Parse("db.tap", []byte(data))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, suite.Tests)
+	assert.Equal(t, 1, suite.Errors)
+	assert.Equal(t, "database is down", suite.TestCases[1].Error.Message)
+}
+
+func TestParseTRX(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+<TestRun id="1" xmlns="http://microsoft.com/schemas/VisualStudio/TeamTest/2010">
+  <Results>
+    <UnitTestResult testId="a" testName="Add" duration="00:00:01.5000000" outcome="Passed" />
+    <UnitTestResult testId="b" testName="Sub" duration="00:01:00.0000000" outcome="Failed">
+      <Output>
+        <ErrorInfo>
+          <Message>Assert.Equal() Failure</Message>
+          <StackTrace>at Calc.Tests.Sub()</StackTrace>
+        </ErrorInfo>
+      </Output>
+    </UnitTestResult>
+    <UnitTestResult testId="c" testName="Div" duration="00:00:00" outcome="NotExecuted" />
+    <UnitTestResult testId="d" testName="Mul" duration="00:00:00" outcome="Timeout" />
+  </Results>
+  <TestDefinitions>
+    <UnitTest id="a" name="Add"><TestMethod className="Calc.Tests" name="Add" /></UnitTest>
+    <UnitTest id="b" name="Sub"><TestMethod className="Calc.Tests" name="Sub" /></UnitTest>
+  </TestDefinitions>
+</TestRun>`
+	parser, err := GetParser(FormatTRX)
+	assert.NoError(t, err)
+	suite, err := parser.Parse("calc.trx", []byte(data))
+	assert.NoError(t, err)
+	assert.Equal(t, 4, suite.Tests)
+	assert.Equal(t, 1, suite.Failures)
+	assert.Equal(t, 1, suite.Errors)
+	assert.Equal(t, 1, suite.Skips)
+	assert.Equal(t, 1, suite.Successes)
+	assert.Equal(t, 61.5, suite.Time)
+	assert.Equal(t, "Calc.Tests", suite.TestCases[1].ClassName)
+	assert.Equal(t, "Assert.Equal() Failure", suite.TestCases[1].Failure.Message)
+	assert.Equal(t, "at Calc.Tests.Sub()", suite.TestCases[1].Failure.Text)
+}
+
+func TestParseGoTest(t *testing.T) {
+	data := `{"Action":"run","Package":"example.com/a","Test":"TestOK"}
+{"Action":"output","Package":"example.com/a","Test":"TestOK","Output":"=== RUN   TestOK\n"}
+{"Action":"pass","Package":"example.com/a","Test":"TestOK","Elapsed":0.1}
+{"Action":"run","Package":"example.com/a","Test":"TestBad"}
+{"Action":"output","Package":"example.com/a","Test":"TestBad","Output":"    a_test.go:10: boom\n"}
+{"Action":"fail","Package":"example.com/a","Test":"TestBad","Elapsed":0.2}
+{"Action":"skip","Package":"example.com/a","Test":"TestSkip","Elapsed":0}
+{"Action":"fail","Package":"example.com/a","Elapsed":0.5}
+# example.com/b
+{"Action":"output","Package":"example.com/b","Output":"b.go:3: undefined: x\n"}
+{"Action":"fail","Package":"example.com/b","Elapsed":0}
+`
+	parser, err := GetParser(FormatGoTest)
+	assert.NoError(t, err)
+	suite, err := parser.Parse("go-test.json", []byte(data))
+	assert.NoError(t, err)
+	assert.Equal(t, 4, suite.Tests)
+	assert.Equal(t, 1, suite.Failures)
+	assert.Equal(t, 1, suite.Errors)
+	assert.Equal(t, 1, suite.Skips)
+	assert.Equal(t, "    a_test.go:10: boom\n", suite.TestCases[1].Failure.Text)
+	assert.Equal(t, "example.com/b", suite.TestCases[3].Name)
+	assert.Equal(t, "b.go:3: undefined: x\n", suite.TestCases[3].Error.Text)
+}
+
+func TestIsValidFormat(t *testing.T) {
+	assert.True(t, IsValidFormat(""))
+	assert.True(t, IsValidFormat(FormatJUnit))
+	assert.True(t, IsValidFormat(FormatGoTest))
+	assert.False(t, IsValidFormat("nunit"))
+
+	_, err := GetParser(FormatJUnit)
+	assert.Error(t, err)
+}
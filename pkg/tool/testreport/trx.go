@@ -0,0 +1,124 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testreport
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/reaper/core/service/meta"
+)
+
+// trxParser parses the Visual Studio test results (.trx) produced by `dotnet test --logger trx`
+type trxParser struct{}
+
+type trxTestRun struct {
+	Results         []*trxUnitTestResult `xml:"Results>UnitTestResult"`
+	TestDefinitions []*trxUnitTest       `xml:"TestDefinitions>UnitTest"`
+}
+
+type trxUnitTestResult struct {
+	TestID   string    `xml:"testId,attr"`
+	TestName string    `xml:"testName,attr"`
+	Duration string    `xml:"duration,attr"`
+	Outcome  string    `xml:"outcome,attr"`
+	Output   trxOutput `xml:"Output"`
+}
+
+type trxOutput struct {
+	StdOut    string `xml:"StdOut"`
+	StdErr    string `xml:"StdErr"`
+	ErrorInfo struct {
+		Message    string `xml:"Message"`
+		StackTrace string `xml:"StackTrace"`
+	} `xml:"ErrorInfo"`
+}
+
+type trxUnitTest struct {
+	ID         string `xml:"id,attr"`
+	TestMethod struct {
+		ClassName string `xml:"className,attr"`
+		Name      string `xml:"name,attr"`
+	} `xml:"TestMethod"`
+}
+
+func (p *trxParser) Match(fileName string) bool {
+	return matchExt(fileName, ".trx")
+}
+
+func (p *trxParser) Parse(fileName string, data []byte) (*meta.TestSuite, error) {
+	run := &trxTestRun{}
+	if err := xml.Unmarshal(data, run); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal trx report: %s", err)
+	}
+
+	classNames := make(map[string]string)
+	for _, def := range run.TestDefinitions {
+		classNames[def.ID] = def.TestMethod.ClassName
+	}
+
+	cases := make([]meta.TestCase, 0, len(run.Results))
+	for _, result := range run.Results {
+		tc := meta.TestCase{
+			Name:      result.TestName,
+			ClassName: classNames[result.TestID],
+			Time:      parseTRXDuration(result.Duration),
+			SystemOut: result.Output.StdOut,
+			SystemErr: result.Output.StdErr,
+		}
+		switch result.Outcome {
+		case "Passed", "PassedButRunAborted", "Warning":
+		case "Failed":
+			tc.Failure = &meta.Failure{
+				Message: strings.TrimSpace(result.Output.ErrorInfo.Message),
+				Type:    result.Outcome,
+				Text:    result.Output.ErrorInfo.StackTrace,
+			}
+		case "Error", "Timeout", "Aborted":
+			tc.Error = &meta.Error{
+				Message: strings.TrimSpace(result.Output.ErrorInfo.Message),
+				Type:    result.Outcome,
+				Text:    result.Output.ErrorInfo.StackTrace,
+			}
+		default:
+			// NotExecuted, Inconclusive, Pending and so on
+			tc.Skipped = &meta.Skipped{}
+		}
+		cases = append(cases, tc)
+	}
+
+	return newTestSuite(className(fileName), cases), nil
+}
+
+// parseTRXDuration parses the duration in the format of hh:mm:ss.fffffff into seconds
+func parseTRXDuration(duration string) float64 {
+	parts := strings.Split(duration, ":")
+	if len(parts) != 3 {
+		return 0
+	}
+	var seconds float64
+	for _, part := range parts {
+		value, err := strconv.ParseFloat(part, 64)
+		if err != nil {
+			return 0
+		}
+		seconds = seconds*60 + value
+	}
+	return seconds
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trivy
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	SeverityCritical = "CRITICAL"
+	SeverityHigh     = "HIGH"
+	SeverityMedium   = "MEDIUM"
+	SeverityLow      = "LOW"
+	SeverityUnknown  = "UNKNOWN"
+)
+
+var severityRank = map[string]int{
+	SeverityUnknown:  0,
+	SeverityLow:      1,
+	SeverityMedium:   2,
+	SeverityHigh:     3,
+	SeverityCritical: 4,
+}
+
+// Report is the subset of the trivy json report used by zadig
+type Report struct {
+	ArtifactName string    `json:"ArtifactName"`
+	Results      []*Result `json:"Results"`
+}
+
+type Result struct {
+	Target          string           `json:"Target"`
+	Vulnerabilities []*Vulnerability `json:"Vulnerabilities"`
+}
+
+type Vulnerability struct {
+	VulnerabilityID  string `json:"VulnerabilityID"`
+	PkgName          string `json:"PkgName"`
+	InstalledVersion string `json:"InstalledVersion"`
+	FixedVersion     string `json:"FixedVersion"`
+	Severity         string `json:"Severity"`
+}
+
+// Summary is the vulnerability count of an image by severity
+type Summary struct {
+	Critical int `bson:"critical" json:"critical" yaml:"critical"`
+	High     int `bson:"high"     json:"high"     yaml:"high"`
+	Medium   int `bson:"medium"   json:"medium"   yaml:"medium"`
+	Low      int `bson:"low"      json:"low"      yaml:"low"`
+	Unknown  int `bson:"unknown"  json:"unknown"  yaml:"unknown"`
+	Total    int `bson:"total"    json:"total"    yaml:"total"`
+}
+
+func ParseReport(data []byte) (*Report, error) {
+	report := &Report{}
+	if err := json.Unmarshal(data, report); err != nil {
+		return nil, fmt.Errorf("failed to parse trivy report: %v", err)
+	}
+	return report, nil
+}
+
+// Summarize counts the vulnerabilities of the report, a vulnerability reported by
+// several targets of the same package is only counted once.
+func (r *Report) Summarize() *Summary {
+	summary := &Summary{}
+	seen := make(map[string]bool)
+	for _, result := range r.Results {
+		for _, vuln := range result.Vulnerabilities {
+			key := vuln.VulnerabilityID + "/" + vuln.PkgName + "/" + vuln.InstalledVersion
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			switch strings.ToUpper(vuln.Severity) {
+			case SeverityCritical:
+				summary.Critical++
+			case SeverityHigh:
+				summary.High++
+			case SeverityMedium:
+				summary.Medium++
+			case SeverityLow:
+				summary.Low++
+			default:
+				summary.Unknown++
+			}
+			summary.Total++
+		}
+	}
+	return summary
+}
+
+// CountAtLeast returns the number of vulnerabilities whose severity is equal to or higher than the given one
+func (s *Summary) CountAtLeast(severity string) (int, error) {
+	rank, ok := severityRank[strings.ToUpper(severity)]
+	if !ok {
+		return 0, fmt.Errorf("invalid severity: %s", severity)
+	}
+	counts := []int{s.Unknown, s.Low, s.Medium, s.High, s.Critical}
+	total := 0
+	for i := rank; i < len(counts); i++ {
+		total += counts[i]
+	}
+	return total, nil
+}
+
+func IsValidSeverity(severity string) bool {
+	_, ok := severityRank[strings.ToUpper(severity)]
+	return ok
+}
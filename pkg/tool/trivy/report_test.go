@@ -0,0 +1,69 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trivy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testReport = `{
+  "ArtifactName": "koderover/demo:v1",
+  "Results": [
+    {
+      "Target": "koderover/demo:v1 (alpine 3.18.0)",
+      "Vulnerabilities": [
+        {"VulnerabilityID": "CVE-2023-0001", "PkgName": "openssl", "InstalledVersion": "3.1.0", "Severity": "CRITICAL"},
+        {"VulnerabilityID": "CVE-2023-0002", "PkgName": "openssl", "InstalledVersion": "3.1.0", "Severity": "HIGH"},
+        {"VulnerabilityID": "CVE-2023-0003", "PkgName": "busybox", "InstalledVersion": "1.36.0", "Severity": "LOW"}
+      ]
+    },
+    {
+      "Target": "app/go.mod"
+    },
+    {
+      "Target": "usr/lib/libssl",
+      "Vulnerabilities": [
+        {"VulnerabilityID": "CVE-2023-0001", "PkgName": "openssl", "InstalledVersion": "3.1.0", "Severity": "CRITICAL"},
+        {"VulnerabilityID": "CVE-2023-0004", "PkgName": "zlib", "InstalledVersion": "1.2.13", "Severity": "MEDIUM"}
+      ]
+    }
+  ]
+}`
+
+func TestSummarize(t *testing.T) {
+	report, err := ParseReport([]byte(testReport))
+	assert.NoError(t, err)
+
+	summary := report.Summarize()
+	assert.Equal(t, &Summary{Critical: 1, High: 1, Medium: 1, Low: 1, Total: 4}, summary)
+
+	count, err := summary.CountAtLeast("high")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	count, err = summary.CountAtLeast(SeverityUnknown)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, count)
+
+	_, err = summary.CountAtLeast("severe")
+	assert.Error(t, err)
+
+	_, err = ParseReport([]byte("not json"))
+	assert.Error(t, err)
+}
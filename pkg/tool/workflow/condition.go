@@ -0,0 +1,219 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Knetic/govaluate"
+)
+
+// Condition expressions are govaluate expressions. Workflow variables are referenced with the
+// bracket syntax using the same path as the {{.xxx}} templates, for example:
+//
+//	[workflow.params.branch] == "main" && contains([job.build.output.CHANGED], "true")
+//	changed_files("pkg/", "*.go") || matches([workflow.params.tag], "^v[0-9]+")
+//
+// the {{.xxx}} template syntax is not used here since templates are rendered as plain text
+// before the job runs, which would break the typing of the expression.
+
+// ConditionContext holds everything a condition expression can refer to.
+type ConditionContext struct {
+	// Variables are keyed by the variable path, e.g. workflow.params.branch
+	Variables map[string]string
+	// ChangedFiles is the list of changed files of the event that triggered the workflow task
+	ChangedFiles []string
+}
+
+func conditionFunctions(changedFiles []string) map[string]govaluate.ExpressionFunction {
+	return map[string]govaluate.ExpressionFunction{
+		"contains": func(args ...interface{}) (interface{}, error) {
+			if len(args) != 2 {
+				return nil, fmt.Errorf("contains requires 2 arguments, got %d", len(args))
+			}
+			return strings.Contains(toString(args[0]), toString(args[1])), nil
+		},
+		"matches": func(args ...interface{}) (interface{}, error) {
+			if len(args) != 2 {
+				return nil, fmt.Errorf("matches requires 2 arguments, got %d", len(args))
+			}
+			reg, err := regexp.Compile(toString(args[1]))
+			if err != nil {
+				return nil, fmt.Errorf("invalid pattern %s: %s", toString(args[1]), err)
+			}
+			return reg.MatchString(toString(args[0])), nil
+		},
+		"changed_files": func(args ...interface{}) (interface{}, error) {
+			if len(args) == 0 {
+				return nil, fmt.Errorf("changed_files requires at least 1 argument")
+			}
+			for _, file := range changedFiles {
+				for _, arg := range args {
+					if matchChangedFile(toString(arg), file) {
+						return true, nil
+					}
+				}
+			}
+			return false, nil
+		},
+	}
+}
+
+// ValidateCondition checks the syntax of the condition expression without evaluating it.
+func ValidateCondition(condition string) error {
+	if strings.TrimSpace(condition) == "" {
+		return nil
+	}
+	expression, err := govaluate.NewEvaluableExpressionWithFunctions(condition, conditionFunctions(nil))
+	if err != nil {
+		return fmt.Errorf("invalid condition %s: %s", condition, err)
+	}
+	if len(expression.Tokens()) == 0 {
+		return fmt.Errorf("invalid condition %s: empty expression", condition)
+	}
+	return nil
+}
+
+// EvaluateCondition evaluates the condition expression, an empty condition is always true.
+// Variable values are typed before evaluation: true/false become bool, a numeric value becomes float64 only
+// when it is compared or calculated with another numeric operand (see numericVariables), everything else
+// is kept as a string, so that e.g. versions like "1.10" and "1.1" are still different.
+// A variable not found in the context is evaluated as an empty string.
+func EvaluateCondition(condition string, ctx *ConditionContext) (bool, error) {
+	if strings.TrimSpace(condition) == "" {
+		return true, nil
+	}
+	if ctx == nil {
+		ctx = &ConditionContext{}
+	}
+
+	expression, err := govaluate.NewEvaluableExpressionWithFunctions(condition, conditionFunctions(ctx.ChangedFiles))
+	if err != nil {
+		return false, fmt.Errorf("invalid condition %s: %s", condition, err)
+	}
+
+	numericVars := numericVariables(expression.Tokens(), ctx.Variables)
+	parameters := make(map[string]interface{})
+	for _, name := range expression.Vars() {
+		parameters[name] = typedValue(ctx.Variables[name], numericVars[name])
+	}
+
+	result, err := expression.Evaluate(parameters)
+	if err != nil {
+		return false, fmt.Errorf("failed to evaluate condition %s: %s", condition, err)
+	}
+	resp, ok := result.(bool)
+	if !ok {
+		return false, fmt.Errorf("condition %s is not a boolean expression, result: %v", condition, result)
+	}
+	return resp, nil
+}
+
+func typedValue(value string, numeric bool) interface{} {
+	switch value {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	if numeric {
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f
+		}
+	}
+	return value
+}
+
+func isNumeric(value string) bool {
+	_, err := strconv.ParseFloat(value, 64)
+	return err == nil
+}
+
+// numericVariables returns the variables to evaluate as numbers. A variable is numeric if its value is a number and
+// the other operand next to it is numeric as well: for ordering comparisons and arithmetic the other operand can be
+// a number literal or a numeric variable, for == and != it must be a number literal, so two variables are
+// compared as strings.
+func numericVariables(tokens []govaluate.ExpressionToken, variables map[string]string) map[string]bool {
+	resp := make(map[string]bool)
+	numericOperand := func(token govaluate.ExpressionToken, allowVariable bool) bool {
+		switch token.Kind {
+		case govaluate.NUMERIC:
+			return true
+		case govaluate.VARIABLE:
+			return allowVariable && isNumeric(variables[toString(token.Value)])
+		}
+		return false
+	}
+
+	for i := 1; i+1 < len(tokens); i++ {
+		operator := tokens[i]
+		allowVariable := false
+		switch {
+		case operator.Kind == govaluate.MODIFIER:
+			allowVariable = true
+		case operator.Kind == govaluate.COMPARATOR:
+			switch toString(operator.Value) {
+			case ">", ">=", "<", "<=":
+				allowVariable = true
+			case "==", "!=":
+			default:
+				continue
+			}
+		default:
+			continue
+		}
+
+		left, right := tokens[i-1], tokens[i+1]
+		for _, pair := range [][2]govaluate.ExpressionToken{{left, right}, {right, left}} {
+			if pair[0].Kind == govaluate.VARIABLE && numericOperand(pair[0], true) && numericOperand(pair[1], allowVariable) {
+				resp[toString(pair[0].Value)] = true
+			}
+		}
+	}
+	return resp
+}
+
+func toString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// matchChangedFile matches a file against a directory prefix (ends with "/") or a glob pattern,
+// a glob pattern without "/" is matched against the base name of the file.
+func matchChangedFile(pattern, file string) bool {
+	pattern = strings.TrimPrefix(pattern, "/")
+	file = strings.TrimPrefix(file, "/")
+	if strings.HasSuffix(pattern, "/") {
+		return strings.HasPrefix(file, pattern)
+	}
+	if !strings.Contains(pattern, "/") {
+		matched, _ := path.Match(pattern, path.Base(file))
+		return matched
+	}
+	matched, _ := path.Match(pattern, file)
+	return matched
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluateCondition(t *testing.T) {
+	ctx := &ConditionContext{
+		Variables: map[string]string{
+			"workflow.params.branch":   "main",
+			"workflow.params.replicas": "3",
+			"job.build.output.CHANGED": "true",
+			"workflow.params.version":  "1.10",
+			"workflow.params.latest":   "1.1",
+			"workflow.params.id":       "007",
+			"workflow.params.min":      "2",
+		},
+		ChangedFiles: []string{"pkg/tool/workflow/condition.go", "README.md"},
+	}
+
+	tests := []struct {
+		condition string
+		expected  bool
+		wantErr   bool
+	}{
+		{condition: "", expected: true},
+		{condition: `[workflow.params.branch] == "main"`, expected: true},
+		{condition: `[workflow.params.branch] != "main"`, expected: false},
+		{condition: `[workflow.params.replicas] > 2`, expected: true},
+		{condition: `[workflow.params.replicas] == 3`, expected: true},
+		{condition: `[workflow.params.replicas] > [workflow.params.min]`, expected: true},
+		{condition: `[workflow.params.replicas] * 2 > 5`, expected: true},
+		{condition: `[workflow.params.version] == [workflow.params.latest]`, expected: false},
+		{condition: `[workflow.params.version] != "1.1"`, expected: true},
+		{condition: `[workflow.params.id] == "007"`, expected: true},
+		{condition: `[workflow.params.id] == "7"`, expected: false},
+		{condition: `[job.build.output.CHANGED]`, expected: true},
+		{condition: `[job.deploy.output.CHANGED] == ""`, expected: true},
+		{condition: `contains([workflow.params.branch], "ai")`, expected: true},
+		{condition: `matches([workflow.params.branch], "^release-.*")`, expected: false},
+		{condition: `changed_files("pkg/tool/")`, expected: true},
+		{condition: `changed_files("*.md")`, expected: true},
+		{condition: `changed_files("docs/", "*.yaml")`, expected: false},
+		{condition: `[workflow.params.branch]`, wantErr: true},
+		{condition: `[workflow.params.branch] ==`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.condition, func(t *testing.T) {
+			got, err := EvaluateCondition(tt.condition, ctx)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestValidateCondition(t *testing.T) {
+	assert.NoError(t, ValidateCondition(`[workflow.params.branch] == "main" && changed_files("pkg/")`))
+	assert.Error(t, ValidateCondition(`[workflow.params.branch == "main"`))
+	assert.Error(t, ValidateCondition(`unknown_func("a")`))
+}
@@ -0,0 +1,28 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package step
+
+// StepCacheRestoreSpec restores the cache entry of the key, the key is a template rendered in the workspace, e.g. go-{{ hashFiles "go.sum" }}.
+// if the key is missed, the restore keys are tried in order as prefixes and the latest matched entry is restored.
+type StepCacheRestoreSpec struct {
+	Key         string   `bson:"key"                                json:"key"                                       yaml:"key"`
+	RestoreKeys []string `bson:"restore_keys"                       json:"restore_keys"                              yaml:"restore_keys"`
+	Paths       []string `bson:"paths"                              json:"paths"                                     yaml:"paths"`
+	ProjectName string   `bson:"project_name"                       json:"project_name"                              yaml:"project_name"`
+	IgnoreErr   bool     `bson:"ignore_err"                         json:"ignore_err"                                yaml:"ignore_err"`
+	S3          *S3      `bson:"s3_storage"                         json:"s3_storage"                                yaml:"s3_storage"`
+}
@@ -0,0 +1,26 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package step
+
+// StepCacheSaveSpec saves the paths as the cache entry of the key, an existing entry is never overwritten.
+type StepCacheSaveSpec struct {
+	Key         string   `bson:"key"                                json:"key"                                       yaml:"key"`
+	Paths       []string `bson:"paths"                              json:"paths"                                     yaml:"paths"`
+	ProjectName string   `bson:"project_name"                       json:"project_name"                              yaml:"project_name"`
+	IgnoreErr   bool     `bson:"ignore_err"                         json:"ignore_err"                                yaml:"ignore_err"`
+	S3          *S3      `bson:"s3_storage"                         json:"s3_storage"                                yaml:"s3_storage"`
+}
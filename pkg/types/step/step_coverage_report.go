@@ -0,0 +1,40 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package step
+
+type StepCoverageReportSpec struct {
+	SourceWorkflow string `bson:"source_workflow"   json:"source_workflow"   yaml:"source_workflow"`
+	JobTaskName    string `bson:"job_task_name"     json:"job_task_name"     yaml:"job_task_name"`
+	TaskID         int64  `bson:"task_id"           json:"task_id"           yaml:"task_id"`
+	TestName       string `bson:"test_name"         json:"test_name"         yaml:"test_name"`
+	TestProject    string `bson:"test_project"      json:"test_project"      yaml:"test_project"`
+	// Format is one of cobertura, jacoco, go and lcov
+	Format     string `bson:"format"            json:"format"            yaml:"format"`
+	ReportPath string `bson:"report_path"       json:"report_path"       yaml:"report_path"`
+	// Threshold is the minimum total coverage percentage, the step fails if the coverage is lower
+	Threshold float64 `bson:"threshold"         json:"threshold"         yaml:"threshold"`
+	// MaxDrop is the maximum coverage drop in percentage points compared with BaseCoverage
+	MaxDrop float64 `bson:"max_drop"          json:"max_drop"          yaml:"max_drop"`
+	// Branch is the target branch on PR-triggered tasks, BaseCoverage is the latest coverage of it
+	Branch       string   `bson:"branch"            json:"branch"            yaml:"branch"`
+	PR           int      `bson:"pr"                json:"pr"                yaml:"pr"`
+	CommitID     string   `bson:"commit_id"         json:"commit_id"         yaml:"commit_id"`
+	BaseCoverage *float64 `bson:"base_coverage"     json:"base_coverage"     yaml:"base_coverage"`
+	S3DestDir    string   `bson:"s3_dest_dir"       json:"s3_dest_dir"       yaml:"s3_dest_dir"`
+	FileName     string   `bson:"file_name"         json:"file_name"         yaml:"file_name"`
+	S3Storage    *S3      `bson:"s3_storage"        json:"s3_storage"        yaml:"s3_storage"`
+}
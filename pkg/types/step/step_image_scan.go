@@ -0,0 +1,47 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package step
+
+import "github.com/koderover/zadig/v2/pkg/tool/trivy"
+
+type StepImageScanSpec struct {
+	Targets  []*ImageScanTarget `bson:"targets"  json:"targets"  yaml:"targets"`
+	Registry *RegistryNamespace `bson:"registry" json:"registry" yaml:"registry"`
+	// Severity is the lowest severity counted by the quality gate, one of CRITICAL, HIGH, MEDIUM, LOW and UNKNOWN
+	Severity string `bson:"severity" json:"severity" yaml:"severity"`
+	// Threshold is the number of counted vulnerabilities allowed per image
+	Threshold int `bson:"threshold" json:"threshold" yaml:"threshold"`
+	// Results are collected from the job output after the job finished
+	Results []*ImageScanResult `bson:"results,omitempty" json:"results,omitempty" yaml:"results,omitempty"`
+}
+
+type ImageScanTarget struct {
+	ServiceName   string `bson:"service_name"   json:"service_name"   yaml:"service_name"`
+	ServiceModule string `bson:"service_module" json:"service_module" yaml:"service_module"`
+	Image         string `bson:"image"          json:"image"          yaml:"image"`
+}
+
+type ImageScanResult struct {
+	ServiceName   string         `bson:"service_name"   json:"service_name"   yaml:"service_name"`
+	ServiceModule string         `bson:"service_module" json:"service_module" yaml:"service_module"`
+	Image         string         `bson:"image"          json:"image"          yaml:"image"`
+	Summary       *trivy.Summary `bson:"summary"        json:"summary"        yaml:"summary"`
+	// Counted is the number of vulnerabilities at or above the configured severity
+	Counted int    `bson:"counted"         json:"counted"         yaml:"counted"`
+	Passed  bool   `bson:"passed"          json:"passed"          yaml:"passed"`
+	Error   string `bson:"error,omitempty" json:"error,omitempty" yaml:"error,omitempty"`
+}
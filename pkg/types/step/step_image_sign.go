@@ -0,0 +1,30 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package step
+
+// the private key and its password of the signing key are passed to the job by the secret envs below
+// when the job is created, they are not kept in the step spec.
+const (
+	ImageSignPrivateKeyEnv = "COSIGN_PRIVATE_KEY"
+	ImageSignPasswordEnv   = "COSIGN_PASSWORD"
+)
+
+type StepImageSignSpec struct {
+	Image          string          `bson:"image"           json:"image"           yaml:"image"`
+	SigningKeyID   string          `bson:"signing_key_id"  json:"signing_key_id"  yaml:"signing_key_id"`
+	DockerRegistry *DockerRegistry `bson:"docker_registry" json:"docker_registry" yaml:"docker_registry"`
+}
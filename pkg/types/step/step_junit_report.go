@@ -31,4 +31,24 @@ type StepJunitReportSpec struct {
 	TestName      string `bson:"test_name"                  json:"test_name"                         yaml:"test_name"`
 	TestProject   string `bson:"test_project"               json:"test_project"                      yaml:"test_project"`
 	S3Storage     *S3    `bson:"s3_storage"                 json:"s3_storage"                        yaml:"s3_storage"`
+	CommitID      string `bson:"commit_id"                  json:"commit_id"                         yaml:"commit_id"`
+	// Format is the format of the test result files, empty means junit
+	Format string `bson:"format"                     json:"format"                            yaml:"format"`
+	// QuarantinedCases are still reported, but their failures do not fail the step
+	QuarantinedCases []*QuarantinedTestCase `bson:"quarantined_cases" json:"quarantined_cases" yaml:"quarantined_cases"`
+}
+
+type QuarantinedTestCase struct {
+	ClassName string `bson:"class_name" json:"class_name" yaml:"class_name"`
+	Name      string `bson:"name"       json:"name"       yaml:"name"`
+}
+
+// IsQuarantined checks whether the test case is in the quarantine list, an empty class name matches any class
+func (s *StepJunitReportSpec) IsQuarantined(className, name string) bool {
+	for _, c := range s.QuarantinedCases {
+		if c.Name == name && (c.ClassName == "" || c.ClassName == className) {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,30 @@
+/*
+Copyright 2025 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package step
+
+type StepSBOMSpec struct {
+	ServiceName   string `bson:"service_name"   json:"service_name"   yaml:"service_name"`
+	ServiceModule string `bson:"service_module" json:"service_module" yaml:"service_module"`
+	Image         string `bson:"image"          json:"image"          yaml:"image"`
+	// Format is the sbom format, one of cyclonedx and spdx-json
+	Format         string          `bson:"format"          json:"format"          yaml:"format"`
+	DockerRegistry *DockerRegistry `bson:"docker_registry" json:"docker_registry" yaml:"docker_registry"`
+	// the generated sbom is uploaded to ObjectKey of the object storage
+	ObjectStorageID string `bson:"object_storage_id" json:"object_storage_id" yaml:"object_storage_id"`
+	S3              *S3    `bson:"s3_storage"        json:"s3_storage"        yaml:"s3_storage"`
+	ObjectKey       string `bson:"object_key"        json:"object_key"        yaml:"object_key"`
+}